@@ -0,0 +1,71 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/lib/auth"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+var flagAdminSigningKey string
+var flagAdminExpiresIn time.Duration
+
+func init() {
+	adminTokenCmd.PersistentFlags().StringVarP(&flagAdminSigningKey, "signing-key", "k", "signing_key", "path of the operator's token signing key")
+	adminTokenCmd.PersistentFlags().DurationVarP(&flagAdminExpiresIn, "expires-in", "", 30*24*time.Hour, "how long the token is valid (0 for a token that never expires)")
+
+	adminCmd.AddCommand(adminTokenCmd)
+}
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "manage access to the cluster",
+	Long:  "Manage access to the cluster (these commands run locally, and don't contact the operator).",
+}
+
+var adminTokenCmd = &cobra.Command{
+	Use:   "token IDENTITY",
+	Short: "create a signed token",
+	Long:  "Create a token for IDENTITY which is signed with the operator's token signing key (for the hmac authentication type).",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		identity := args[0]
+		if err := auth.ValidateHMACIdentity(identity); err != nil {
+			errors.Exit(err)
+		}
+		if flagAdminExpiresIn < 0 {
+			errors.Exit(ErrorNegativeExpiration(flagAdminExpiresIn))
+		}
+
+		key, err := auth.ReadSigningKey(flagAdminSigningKey)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var expiration *time.Time
+		if flagAdminExpiresIn != 0 {
+			expiration = pointer.Time(time.Now().Add(flagAdminExpiresIn))
+		}
+		fmt.Println(auth.SignHMACToken(key, identity, expiration))
+	},
+}
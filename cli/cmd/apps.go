@@ -0,0 +1,87 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+)
+
+// appsArg is the argument to `cortex get` which lists all apps
+const appsArg = "apps"
+
+func getApps() ([]*schema.AppSummary, error) {
+	httpResponse, err := HTTPGet("/apps")
+	if err != nil {
+		return nil, err
+	}
+
+	var appsResponse schema.GetAppsResponse
+	err = libjson.Unmarshal(httpResponse, &appsResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "/apps", "response", string(httpResponse))
+	}
+	return appsResponse.Apps, nil
+}
+
+func runGetApps() (string, error) {
+	apps, err := getApps()
+	if err != nil {
+		return "", err
+	}
+	if len(apps) == 0 {
+		return "no apps are deployed", nil
+	}
+
+	out := fmt.Sprintf("%-23s%-14s%-14s%-27s%s\n", "APP", "ENVIRONMENT", "CONTEXT ID", "DEPLOYED", "STATUS")
+	for _, app := range apps {
+		deployedAt := "-"
+		if app.DeployedAt != nil {
+			deployedAt = libtime.LocalTimestamp(app.DeployedAt)
+		}
+		out += fmt.Sprintf("%-23s%-14s%-14s%-27s%s\n", app.Name, app.Environment, shortContextID(app.ContextID), deployedAt, appStatusStr(app))
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func runStatusAllApps() (string, error) {
+	apps, err := getApps()
+	if err != nil {
+		return "", err
+	}
+	if len(apps) == 0 {
+		return "no apps are deployed", nil
+	}
+
+	out := fmt.Sprintf("%-23s%s\n", "APP", "STATUS")
+	for _, app := range apps {
+		out += fmt.Sprintf("%-23s%s\n", app.Name, appStatusStr(app))
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func appStatusStr(app *schema.AppSummary) string {
+	if app.Running && app.Status != schema.AppStatusError {
+		return app.Status + " (workflow running)"
+	}
+	return app.Status
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+)
+
+var flagAuditLimit int
+
+func init() {
+	auditCmd.PersistentFlags().IntVarP(&flagAuditLimit, "limit", "n", 20, "maximum number of records to show (0 for all)")
+	addAppNameFlag(auditCmd)
+	addEnvFlag(auditCmd)
+	addWatchFlag(auditCmd)
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "get the audit log of an app",
+	Long:  "Get the audit log of an app (the calls which changed or tried to change it).",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rerun(runAudit)
+	},
+}
+
+func runAudit() (string, error) {
+	appName, err := AppNameFromFlagOrConfig()
+	if err != nil {
+		return "", err
+	}
+
+	params := map[string]string{
+		"appName": appName,
+		"limit":   s.Int(flagAuditLimit),
+	}
+	httpResponse, err := HTTPGet("/audit", params)
+	if err != nil {
+		return "", err
+	}
+
+	var auditResponse schema.GetAuditResponse
+	err = libjson.Unmarshal(httpResponse, &auditResponse)
+	if err != nil {
+		return "", errors.Wrap(err, "/audit", "response", string(httpResponse))
+	}
+
+	if len(auditResponse.Records) == 0 {
+		return "no audit records for app " + s.UserStr(appName), nil
+	}
+
+	out := auditRow("TIME", "ACTION", "IDENTITY", "ENVIRONMENT", "CONTEXT ID", "STATUS", "DURATION", "FLAGS") + "\n"
+	for i := len(auditResponse.Records) - 1; i >= 0; i-- {
+		record := auditResponse.Records[i]
+		timestamp := libtime.LocalTimestamp(&record.Timestamp)
+		duration := record.Duration.Round(time.Millisecond).String()
+		out += auditRow(timestamp, record.Action, record.Identity, record.Environment, shortContextID(record.ContextID), s.Int(record.StatusCode), duration, auditFlagsStr(record.Flags)) + "\n"
+		if record.Message != "" {
+			out += "  " + record.Message + "\n"
+		}
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func auditFlagsStr(flags map[string]string) string {
+	var flagStrs []string
+	for name, val := range flags {
+		if val != "" {
+			flagStrs = append(flagStrs, name+"="+val)
+		}
+	}
+	sort.Strings(flagStrs)
+	return strings.Join(flagStrs, " ")
+}
+
+func auditRow(timestamp string, action string, identity string, environment string, ctxID string, status string, duration string, flags string) string {
+	return fmt.Sprintf("%-27s%-10s%-23s%-14s%-14s%-8s%-10s%s", timestamp, action, identity, environment, ctxID, status, duration, flags)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+)
+
+func init() {
+	addAppNameFlag(cacheLsCmd)
+	addEnvFlag(cacheLsCmd)
+
+	addAppNameFlag(cacheRmCmd)
+	addEnvFlag(cacheRmCmd)
+	addResourceTypesToHelp(cacheRmCmd)
+
+	cacheCmd.AddCommand(cacheLsCmd)
+	cacheCmd.AddCommand(cacheRmCmd)
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "inspect and invalidate cached resources",
+	Long:  "Inspect and invalidate the cached outputs of an app's resources, which are reused by deployments instead of being recomputed.",
+}
+
+var cacheLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "list cached resources",
+	Long:  "List whether each of the app's resources has a cached output, and its size and age.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{"appName": appName}
+		httpResponse, err := HTTPGet("/cache", params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var cacheResponse schema.GetCacheResponse
+		err = libjson.Unmarshal(httpResponse, &cacheResponse)
+		if err != nil {
+			errors.Exit(err, "/cache", "response", string(httpResponse))
+		}
+
+		fmt.Println(cacheRow("TYPE", "NAME", "ID", "CACHED", "SIZE", "AGE"))
+		for _, res := range cacheResponse.Resources {
+			size := "-"
+			if res.Bytes != nil {
+				size = s.ByteSize(*res.Bytes)
+			}
+			age := "-"
+			if res.Cached {
+				age = libtime.Since(res.CachedAt)
+			}
+			fmt.Println(cacheRow(res.ResourceType.String(), res.Name, shortContextID(res.ResourceID), s.Bool(res.Cached), size, age))
+		}
+
+		if cacheResponse.RawDatasetBytes != nil {
+			fmt.Println()
+			fmt.Println("raw dataset (shared by all raw columns): " + s.ByteSize(*cacheResponse.RawDatasetBytes))
+		}
+	},
+}
+
+var cacheRmCmd = &cobra.Command{
+	Use:   "rm [RESOURCE_TYPE] RESOURCE_NAME",
+	Short: "invalidate a cached resource",
+	Long:  "Invalidate the cached output of a resource, and of the resources which depend on it, so that the next deployment recomputes them.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resourceName, resourceTypeStr := "", ""
+		switch len(args) {
+		case 1:
+			resourceName = args[0]
+		case 2:
+			resourceType, err := resource.VisibleResourceTypeFromPrefix(args[0])
+			if err != nil {
+				errors.Exit(err)
+			}
+			resourceTypeStr = resourceType.String()
+			resourceName = args[1]
+		}
+
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName":      appName,
+			"resourceName": resourceName,
+			"resourceType": resourceTypeStr,
+		}
+		httpResponse, err := HTTPPostJSONData("/cache/rm", nil, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var cacheRmResponse schema.CacheRmResponse
+		err = libjson.Unmarshal(httpResponse, &cacheRmResponse)
+		if err != nil {
+			errors.Exit(err, "/cache/rm", "response", string(httpResponse))
+		}
+		fmt.Println(cacheRmResponse.Message)
+	},
+}
+
+func cacheRow(resourceType string, name string, id string, cached string, size string, age string) string {
+	if len(name) > 33 {
+		name = name[0:30] + "..."
+	}
+	return fmt.Sprintf("%-20s%-35s%-14s%-8s%-11s%s", resourceType, name, id, cached, size, age)
+}
@@ -18,20 +18,26 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/cortexlabs/cortex/pkg/api/schema"
 	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
 	"github.com/cortexlabs/cortex/pkg/lib/zip"
 )
 
 var flagDeployForce bool
+var flagDeployMessage string
+var flagDeployDryRun bool
 
 func init() {
 	deployCmd.PersistentFlags().BoolVarP(&flagDeployForce, "force", "f", false, "stop all running jobs")
+	deployCmd.PersistentFlags().StringVarP(&flagDeployMessage, "message", "m", "", "message to record in the deployment history")
+	deployCmd.PersistentFlags().BoolVarP(&flagDeployDryRun, "dry-run", "", false, "show what would be computed without deploying")
 	addEnvFlag(deployCmd)
 }
 
@@ -41,30 +47,27 @@ var deployCmd = &cobra.Command{
 	Long:  "Deploy an application.",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		deploy(flagDeployForce, false)
+		if flagDeployDryRun {
+			deployPlan(flagDeployForce)
+			return
+		}
+		deploy(flagDeployForce, false, flagDeployMessage)
 	},
 }
 
-func deploy(force bool, ignoreCache bool) {
-	root := mustAppRoot()
-	_, err := appNameFromConfig() // Check proper app.yaml
+func deploy(force bool, ignoreCache bool, message string) {
+	appName, err := appNameFromConfig()
 	if err != nil {
 		errors.Exit(err)
 	}
-
-	zipInput := &zip.Input{
-		FileLists: []zip.FileListInput{
-			{
-				Sources:      allConfigPaths(root),
-				RemovePrefix: root,
-			},
-		},
-	}
+	zipInput := configZipInput()
 
 	params := map[string]string{
+		"appName":     appName,
 		"environment": flagEnv,
 		"force":       s.Bool(force),
 		"ignoreCache": s.Bool(ignoreCache),
+		"message":     message,
 	}
 
 	response, err := HTTPUploadZip("/deploy", zipInput, "config.zip", params)
@@ -79,3 +82,118 @@ func deploy(force bool, ignoreCache bool) {
 
 	fmt.Println(deployResponse.Message)
 }
+
+func deployPlan(force bool) {
+	appName, err := appNameFromConfig()
+	if err != nil {
+		errors.Exit(err)
+	}
+	zipInput := configZipInput()
+
+	params := map[string]string{
+		"appName":     appName,
+		"environment": flagEnv,
+		"force":       s.Bool(force),
+		"dryRun":      s.Bool(true),
+	}
+
+	response, err := HTTPUploadZip("/deploy", zipInput, "config.zip", params)
+	if err != nil {
+		errors.Exit(err)
+	}
+
+	var planResponse schema.DeployPlanResponse
+	if err := libjson.Unmarshal(response, &planResponse); err != nil {
+		errors.Exit(err, "/deploy", "response", string(response))
+	}
+
+	fmt.Println(deployPlanStr(&planResponse))
+}
+
+func configZipInput() *zip.Input {
+	root := mustAppRoot()
+	_, err := appNameFromConfig() // Check proper app.yaml
+	if err != nil {
+		errors.Exit(err)
+	}
+
+	return &zip.Input{
+		FileLists: []zip.FileListInput{
+			{
+				Sources:      allConfigPaths(root),
+				RemovePrefix: root,
+			},
+		},
+	}
+}
+
+func deployPlanStr(plan *schema.DeployPlanResponse) string {
+	out := fmt.Sprintf("Dry run, nothing was deployed (context %s)\n", plan.ContextID)
+	out += fmt.Sprintf("Result if deployed: %s\n", plan.Message)
+	if plan.StoppedWorkflow != "" {
+		out += fmt.Sprintf("Running workflow %s (context %s) would be stopped\n", plan.StoppedWorkflow, plan.StoppedContextID)
+	}
+
+	out += titleStr("Resources")
+	out += plannedResourceRow("NAME", "TYPE", "ACTION") + "\n"
+	for _, res := range plan.Resources {
+		out += plannedResourceRow(res.Name, res.ResourceType.String(), plannedActionStr(res.Cached)) + "\n"
+	}
+
+	out += titleStr("Workloads")
+	if len(plan.Workloads) == 0 {
+		out += "none\n"
+	}
+	for _, workload := range plan.Workloads {
+		out += fmt.Sprintf("%s (%s)\n", workload.WorkloadType, workload.WorkloadID)
+		if computeStr := plannedComputeStr(workload); computeStr != "" {
+			out += "  compute: " + computeStr + "\n"
+		}
+		for _, res := range workload.Resources {
+			out += fmt.Sprintf("  %s: %s\n", res.ResourceType.String(), res.Name)
+		}
+	}
+
+	return strings.TrimSpace(out)
+}
+
+func plannedResourceRow(name string, resourceType string, action string) string {
+	return fmt.Sprintf("%-35s%-24s%s", name, resourceType, action)
+}
+
+func plannedActionStr(cached bool) string {
+	if cached {
+		return "reuse cached"
+	}
+	return "recompute"
+}
+
+func plannedComputeStr(workload *schema.PlannedWorkload) string {
+	switch {
+	case workload.SparkCompute != nil:
+		c := workload.SparkCompute
+		return fmt.Sprintf("executors: %d, driver cpu: %s, driver mem: %s, executor cpu: %s, executor mem: %s",
+			c.Executors, c.DriverCPU.String(), c.DriverMem.String(), c.ExecutorCPU.String(), c.ExecutorMem.String())
+	case workload.TFCompute != nil:
+		c := workload.TFCompute
+		return fmt.Sprintf("cpu: %s, mem: %s, gpu: %s", quantityPtrStr(c.CPU), quantityPtrStr(c.Mem), int64PtrStr(c.GPU))
+	case workload.APICompute != nil:
+		c := workload.APICompute
+		return fmt.Sprintf("replicas: %d, cpu: %s, mem: %s, gpu: %d", c.Replicas, quantityPtrStr(c.CPU), quantityPtrStr(c.Mem), c.GPU)
+	}
+	return ""
+}
+
+func quantityPtrStr(quantity *userconfig.Quantity) string {
+	if quantity == nil {
+		return "-"
+	}
+	return quantity.String()
+}
+
+func int64PtrStr(val *int64) string {
+	if val == nil {
+		return "-"
+	}
+	return s.Int64(*val)
+}
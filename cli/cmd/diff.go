@@ -0,0 +1,135 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+func init() {
+	addAppNameFlag(diffCmd)
+	addEnvFlag(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [CONTEXT_ID] [CONTEXT_ID]",
+	Short: "compare deployments",
+	Long: `Compare deployments.
+
+With no arguments, the current deployment is compared to the app in the working directory.
+With one argument, the given deployment is compared to the app in the working directory.
+With two arguments, the two deployments are compared.`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var ctxIDs []string
+		if len(args) > 0 {
+			history, err := getHistory(appName)
+			if err != nil {
+				errors.Exit(err)
+			}
+			for _, arg := range args {
+				ctxID, err := resolveContextID(arg, appName, history)
+				if err != nil {
+					errors.Exit(err)
+				}
+				ctxIDs = append(ctxIDs, ctxID)
+			}
+		}
+
+		var httpResponse []byte
+		if len(ctxIDs) == 2 {
+			params := map[string]string{
+				"appName": appName,
+				"from":    ctxIDs[0],
+				"to":      ctxIDs[1],
+			}
+			httpResponse, err = HTTPGet("/diff", params)
+		} else {
+			var localAppName string
+			localAppName, err = appNameFromConfig()
+			if err != nil {
+				errors.Exit(err)
+			}
+			params := map[string]string{
+				"appName":     localAppName,
+				"environment": flagEnv,
+			}
+			if len(ctxIDs) == 1 {
+				params["from"] = ctxIDs[0]
+			}
+			httpResponse, err = HTTPUploadZip("/diff", configZipInput(), "config.zip", params)
+		}
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var diffResponse schema.DiffResponse
+		err = libjson.Unmarshal(httpResponse, &diffResponse)
+		if err != nil {
+			errors.Exit(err, "/diff", "response", string(httpResponse))
+		}
+
+		toName := shortContextID(diffResponse.ToContextID)
+		if len(ctxIDs) < 2 {
+			toName += " (local)"
+		}
+		fmt.Printf("Comparing %s to %s\n\n", shortContextID(diffResponse.FromContextID), toName)
+		fmt.Println(diffStr(diffResponse.Diff))
+	},
+}
+
+func diffStr(diff *context.Diff) string {
+	if diff == nil || diff.IsEmpty() {
+		return "no changes"
+	}
+
+	out := ""
+	for _, resourceDiff := range diff.Added {
+		out += diffRow("+", resourceDiff, "added") + "\n"
+	}
+	for _, resourceDiff := range diff.Removed {
+		out += diffRow("-", resourceDiff, "removed") + "\n"
+	}
+	for _, resourceDiff := range diff.Changed {
+		out += diffRow("~", resourceDiff, strings.Join(resourceDiff.Changes, ", ")) + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func diffRow(symbol string, resourceDiff *context.ResourceDiff, description string) string {
+	return fmt.Sprintf("%-2s%-24s%-35s%s", symbol, resourceDiff.ResourceType.String(), resourceDiff.Name, description)
+}
+
+func shortContextID(ctxID string) string {
+	if len(ctxID) > 12 {
+		return ctxID[:12]
+	}
+	return ctxID
+}
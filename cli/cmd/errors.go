@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	s "github.com/cortexlabs/cortex/pkg/api/strings"
 )
@@ -36,6 +37,13 @@ const (
 	ErrAPINotFound
 	ErrFailedToConnect
 	ErrCliNotInAppDir
+	ErrContextIDNotFound
+	ErrAmbiguousContextID
+	ErrOperatorResponse
+	ErrAllAppsArgs
+	ErrInvalidArchive
+	ErrIncompleteExport
+	ErrNegativeExpiration
 )
 
 var errorKinds = []string{
@@ -45,9 +53,16 @@ var errorKinds = []string{
 	"err_api_not_found",
 	"err_failed_to_connect",
 	"err_cli_not_in_app_dir",
+	"err_context_id_not_found",
+	"err_ambiguous_context_id",
+	"err_operator_response",
+	"err_all_apps_args",
+	"err_invalid_archive",
+	"err_incomplete_export",
+	"err_negative_expiration",
 }
 
-var _ = [1]int{}[int(ErrCliNotInAppDir)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrNegativeExpiration)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -126,3 +141,55 @@ func ErrorCliNotInAppDir() error {
 		message: "your current working directory is not in or under a cortex app directory (identified via a top-level app.yaml file)",
 	}
 }
+
+func ErrorContextIDNotFound(ctxID string, appName string) error {
+	return Error{
+		Kind:    ErrContextIDNotFound,
+		message: fmt.Sprintf("%s is not in the deployment history of app %s; run `cortex history` to see previous deployments", s.UserStr(ctxID), s.UserStr(appName)),
+	}
+}
+
+func ErrorAmbiguousContextID(ctxID string, matches []string) error {
+	return Error{
+		Kind:    ErrAmbiguousContextID,
+		message: fmt.Sprintf("%s matches multiple deployments (%s); please provide more characters", s.UserStr(ctxID), s.UserStrsOr(matches)),
+	}
+}
+
+func ErrorOperatorResponse(kind string, message string) error {
+	if flagDebug && kind != "" && kind != "err_unknown" {
+		message = fmt.Sprintf("%s [%s]", message, kind)
+	}
+	return Error{
+		Kind:    ErrOperatorResponse,
+		message: message,
+	}
+}
+
+func ErrorAllAppsArgs() error {
+	return Error{
+		Kind:    ErrAllAppsArgs,
+		message: "resource names and types cannot be specified with --all-apps",
+	}
+}
+
+func ErrorInvalidArchive(path string) error {
+	return Error{
+		Kind:    ErrInvalidArchive,
+		message: fmt.Sprintf("%s is not an archive created by `cortex export`", path),
+	}
+}
+
+func ErrorIncompleteExport(appName string) error {
+	return Error{
+		Kind:    ErrIncompleteExport,
+		message: fmt.Sprintf("the archive of %s is incomplete (the operator failed to export it); check the operator's logs and try again", s.UserStr(appName)),
+	}
+}
+
+func ErrorNegativeExpiration(expiresIn time.Duration) error {
+	return Error{
+		Kind:    ErrNegativeExpiration,
+		message: fmt.Sprintf("--expires-in must not be negative (got %s)", expiresIn),
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+var flagExportOutput string
+
+func init() {
+	exportCmd.PersistentFlags().StringVarP(&flagExportOutput, "output", "o", "", "path of the archive to create (default APP_NAME.zip)")
+	addEnvFlag(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [APP_NAME]",
+	Short: "export an app's deployment to an archive",
+	Long:  "Export an app's current deployment, its implementation files, and the outputs of its cached python packages, aggregates, and models to an archive which can be imported into another cluster.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var appName string
+		var err error
+		if len(args) == 1 {
+			appName = args[0]
+		} else {
+			appName, err = appNameFromConfig()
+			if err != nil {
+				errors.Exit(err)
+			}
+		}
+
+		outputPath := flagExportOutput
+		if outputPath == "" {
+			outputPath = appName + ".zip"
+		}
+
+		err = exportApp(appName, outputPath)
+		if err != nil {
+			errors.Exit(err)
+		}
+		fmt.Println("Exported " + appName + " to " + outputPath)
+	},
+}
+
+// exportApp streams the app's archive to a temporary file, which replaces outputPath once the archive is verified to be complete
+// (the operator can't report errors which occur after it starts sending the archive)
+func exportApp(appName string, outputPath string) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(outputPath), ".cortex-export-")
+	if err != nil {
+		return errors.Wrap(err, outputPath)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	params := map[string]string{"appName": appName}
+	err = HTTPTransferDownload("/export", tmpFile, params)
+	if err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, outputPath)
+	}
+
+	archive, err := zip.OpenReader(tmpFile.Name())
+	if err != nil {
+		return ErrorIncompleteExport(appName)
+	}
+	archive.Close()
+
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return errors.Wrap(err, outputPath)
+	}
+	return errors.Wrap(os.Rename(tmpFile.Name(), outputPath), outputPath)
+}
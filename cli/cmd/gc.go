@@ -0,0 +1,82 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+var flagGCDryRun bool
+
+func init() {
+	gcCmd.PersistentFlags().BoolVarP(&flagGCDryRun, "dry-run", "", false, "show what would be deleted without deleting it")
+	addEnvFlag(gcCmd)
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc [APP_NAME]",
+	Short: "delete an app's unused files from storage",
+	Long:  "Delete an app's files (e.g. old dataset versions and contexts) which are not used by its current deployment or its most recent deployments.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var appName string
+		var err error
+		if len(args) == 1 {
+			appName = args[0]
+		} else {
+			appName, err = appNameFromConfig()
+			if err != nil {
+				errors.Exit(err)
+			}
+		}
+
+		params := map[string]string{
+			"appName": appName,
+			"dryRun":  s.Bool(flagGCDryRun),
+		}
+		httpResponse, err := HTTPPostJSONData("/gc", nil, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var gcResponse schema.GCResponse
+		err = libjson.Unmarshal(httpResponse, &gcResponse)
+		if err != nil {
+			errors.Exit(err, "/gc", "response", string(httpResponse))
+		}
+
+		if len(gcResponse.Reclaimed) > 0 {
+			fmt.Println(gcRow("DIRECTORY", "OBJECTS", "SIZE"))
+			for _, reclaimed := range gcResponse.Reclaimed {
+				fmt.Println(gcRow(reclaimed.Prefix, s.Int(reclaimed.Objects), s.ByteSize(reclaimed.Bytes)))
+			}
+			fmt.Println()
+		}
+		fmt.Println(gcResponse.Message)
+	},
+}
+
+func gcRow(prefix string, objects string, size string) string {
+	return fmt.Sprintf("%-48s%-10s%s", prefix, objects, size)
+}
@@ -47,9 +47,19 @@ func init() {
 var getCmd = &cobra.Command{
 	Use:   "get [RESOURCE_TYPE] [RESOURCE_NAME]",
 	Short: "get information about resources",
-	Long:  "Get information about resources.",
+	Long:  "Get information about resources (or list all deployed apps with `cortex get apps`).",
 	Args:  cobra.RangeArgs(0, 2),
 	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 && args[0] == appsArg {
+			rerun(runGetApps)
+			return
+		}
+		if flagWatch {
+			watchResources(func(resourcesRes *schema.GetResourcesResponse) (string, error) {
+				return getStr(resourcesRes, args)
+			})
+			return
+		}
 		rerun(func() (string, error) {
 			return runGet(cmd, args)
 		})
@@ -61,7 +71,10 @@ func runGet(cmd *cobra.Command, args []string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return getStr(resourcesRes, args)
+}
 
+func getStr(resourcesRes *schema.GetResourcesResponse, args []string) (string, error) {
 	switch len(args) {
 	case 0:
 		return allResourcesStr(resourcesRes), nil
@@ -120,6 +133,69 @@ func getResourcesResponse() (*schema.GetResourcesResponse, error) {
 	return &resourcesRes, nil
 }
 
+// watchResources redraws f's output every second, using resource statuses which are streamed from the operator
+func watchResources(f func(*schema.GetResourcesResponse) (string, error)) {
+	appName, err := AppNameFromFlagOrConfig()
+	if err != nil {
+		errors.Exit(err)
+	}
+
+	connection, err := dialOperator("/resources/watch", map[string]string{"appName": appName})
+	if err != nil {
+		errors.Exit(err)
+	}
+	defer connection.Close()
+
+	updates := make(chan *schema.ResourcesUpdate)
+	readErrs := make(chan error)
+	go func() {
+		for {
+			var update schema.ResourcesUpdate
+			if err := connection.ReadJSON(&update); err != nil {
+				readErrs <- err
+				return
+			}
+			updates <- &update
+		}
+	}()
+
+	printer := newWatchPrinter()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var resourcesRes *schema.GetResourcesResponse
+	for true {
+		select {
+		case update := <-updates:
+			if update.Error != nil {
+				fmt.Println()
+				errors.Exit(operatorError(update.Error))
+			}
+			if resourcesRes == nil {
+				if update.Context == nil {
+					continue // wait for a complete update
+				}
+				resourcesRes = &schema.GetResourcesResponse{}
+			}
+			resourcesRes.Apply(update)
+		case err := <-readErrs:
+			fmt.Println()
+			errors.Exit(err, "/resources/watch")
+		case <-ticker.C:
+		}
+
+		if resourcesRes == nil {
+			continue
+		}
+		str, err := f(resourcesRes)
+		if err != nil {
+			fmt.Println()
+			errors.Exit(err)
+		}
+		printer.print(str)
+	}
+}
+
 func resourceByNameStr(resourceName string, resourcesRes *schema.GetResourcesResponse) (string, error) {
 	rs, err := resourcesRes.Context.VisibleResourceByName(resourceName)
 	if err != nil {
@@ -434,6 +510,9 @@ func describeAPI(name string, resourcesRes *schema.GetResourcesResponse) (string
 func dataStatusSummary(dataStatus *resource.DataStatus) string {
 	out := titleStr("Summary")
 	out += "Status:               " + dataStatus.Message() + "\n"
+	if dataStatus.MaxAttempts > 1 {
+		out += fmt.Sprintf("Attempt:              %d of %d\n", dataStatus.Attempt, dataStatus.MaxAttempts)
+	}
 	out += "Workload started at:  " + libtime.LocalTimestamp(dataStatus.Start) + "\n"
 	out += "Workload ended at:    " + libtime.LocalTimestamp(dataStatus.End) + "\n"
 	return out
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
+)
+
+func init() {
+	addAppNameFlag(historyCmd)
+	addEnvFlag(historyCmd)
+	addWatchFlag(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "get the deployment history of an app",
+	Long:  "Get the deployment history of an app.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rerun(runHistory)
+	},
+}
+
+func runHistory() (string, error) {
+	appName, err := AppNameFromFlagOrConfig()
+	if err != nil {
+		return "", err
+	}
+
+	history, err := getHistory(appName)
+	if err != nil {
+		return "", err
+	}
+
+	out := historyRow("", "CONTEXT ID", "DEPLOYED", "ENVIRONMENT", "IDENTITY", "MESSAGE") + "\n"
+	for i := len(history) - 1; i >= 0; i-- {
+		deployment := history[i]
+		current := ""
+		if i == len(history)-1 {
+			current = "*"
+		}
+		timestamp := libtime.LocalTimestamp(&deployment.Timestamp)
+		out += historyRow(current, shortContextID(deployment.ContextID), timestamp, deployment.Environment, deployment.Identity, deployment.Message) + "\n"
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func getHistory(appName string) ([]*schema.Deployment, error) {
+	params := map[string]string{"appName": appName}
+	httpResponse, err := HTTPGet("/history", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var historyResponse schema.GetHistoryResponse
+	err = libjson.Unmarshal(httpResponse, &historyResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "/history", "response", string(httpResponse))
+	}
+	return historyResponse.History, nil
+}
+
+func historyRow(current string, ctxID string, timestamp string, environment string, identity string, message string) string {
+	return fmt.Sprintf("%-2s%-14s%-27s%-14s%-23s%s", current, ctxID, timestamp, environment, identity, message)
+}
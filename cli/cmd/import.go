@@ -0,0 +1,108 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+var flagImportForce bool
+var flagImportMessage string
+
+func init() {
+	importCmd.PersistentFlags().BoolVarP(&flagImportForce, "force", "f", false, "stop all running jobs")
+	importCmd.PersistentFlags().StringVarP(&flagImportMessage, "message", "m", "", "message to record in the deployment history")
+	addEnvFlag(importCmd)
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import ARCHIVE",
+	Short: "import and deploy an exported app",
+	Long:  "Import an archive created by cortex export into the cluster, and deploy it; the archive's cached outputs are reused instead of being recomputed.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		archivePath := args[0]
+
+		manifest, err := readExportManifest(archivePath)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName":     manifest.AppName,
+			"environment": manifest.Environment,
+			"force":       s.Bool(flagImportForce),
+			"message":     flagImportMessage,
+		}
+		uploadInput := &HTTPUploadInput{
+			FilePaths: map[string]string{
+				"archive.zip": archivePath,
+			},
+		}
+		httpResponse, err := HTTPTransferUpload("/import", uploadInput, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var importResponse schema.ImportResponse
+		err = libjson.Unmarshal(httpResponse, &importResponse)
+		if err != nil {
+			errors.Exit(err, "/import", "response", string(httpResponse))
+		}
+		fmt.Println(importResponse.Message)
+	},
+}
+
+// readExportManifest reads the manifest of an archive created by cortex export, without reading the rest of the archive
+func readExportManifest(archivePath string) (*schema.ExportManifest, error) {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.Wrap(err, archivePath)
+	}
+	defer archive.Close()
+
+	for _, file := range archive.File {
+		if file.Name != schema.ExportManifestFile {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, errors.Wrap(err, archivePath, schema.ExportManifestFile)
+		}
+		defer reader.Close()
+
+		manifestBytes, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, errors.Wrap(err, archivePath, schema.ExportManifestFile)
+		}
+		var manifest schema.ExportManifest
+		if err := libjson.Unmarshal(manifestBytes, &manifest); err != nil {
+			return nil, errors.Wrap(err, archivePath, schema.ExportManifestFile)
+		}
+		return &manifest, nil
+	}
+	return nil, ErrorInvalidArchive(archivePath)
+}
@@ -45,10 +45,19 @@ func init() {
 	}
 }
 
+const (
+	authTypeAWS   = "aws"
+	authTypeToken = "token"
+)
+
+var authTypes = []string{authTypeAWS, authTypeToken}
+
 type CliConfig struct {
 	CortexURL          string `json:"cortex_url"`
+	AuthType           string `json:"auth_type"`
 	AWSAccessKeyID     string `json:"aws_access_key_id"`
 	AWSSecretAccessKey string `json:"aws_secret_access_key"`
+	Token              string `json:"token"`
 }
 
 func getPromptValidation(defaults *CliConfig) *cr.PromptValidation {
@@ -64,6 +73,24 @@ func getPromptValidation(defaults *CliConfig) *cr.PromptValidation {
 					Default:  defaults.CortexURL,
 				}),
 			},
+			{
+				StructField: "AuthType",
+				PromptOpts: &cr.PromptOptions{
+					Prompt: "Enter authentication type (aws or token)",
+				},
+				StringValidation: &cr.StringValidation{
+					Required:      true,
+					Default:       defaults.AuthType,
+					AllowedValues: authTypes,
+				},
+			},
+		},
+	}
+}
+
+func getAWSPromptValidation(defaults *CliConfig) *cr.PromptValidation {
+	return &cr.PromptValidation{
+		PromptItemValidations: []*cr.PromptItemValidation{
 			{
 				StructField: "AWSAccessKeyID",
 				PromptOpts: &cr.PromptOptions{
@@ -90,6 +117,25 @@ func getPromptValidation(defaults *CliConfig) *cr.PromptValidation {
 	}
 }
 
+func getTokenPromptValidation(defaults *CliConfig) *cr.PromptValidation {
+	return &cr.PromptValidation{
+		PromptItemValidations: []*cr.PromptItemValidation{
+			{
+				StructField: "Token",
+				PromptOpts: &cr.PromptOptions{
+					Prompt:      "Enter token",
+					MaskDefault: true,
+					HideTyping:  true,
+				},
+				StringValidation: &cr.StringValidation{
+					Required: true,
+					Default:  defaults.Token,
+				},
+			},
+		},
+	}
+}
+
 var fileValidation = &cr.StructValidation{
 	ShortCircuit:     false,
 	AllowExtraFields: true,
@@ -101,23 +147,56 @@ var fileValidation = &cr.StructValidation{
 				Required: true,
 			}),
 		},
+		{
+			Key:         "auth_type",
+			StructField: "AuthType",
+			StringValidation: &cr.StringValidation{
+				Default:       authTypeAWS,
+				AllowedValues: authTypes,
+			},
+		},
 		{
 			Key:         "aws_access_key_id",
 			StructField: "AWSAccessKeyID",
 			StringValidation: &cr.StringValidation{
-				Required: true,
+				AllowEmpty: true,
 			},
 		},
 		{
 			Key:         "aws_secret_access_key",
 			StructField: "AWSSecretAccessKey",
 			StringValidation: &cr.StringValidation{
-				Required: true,
+				AllowEmpty: true,
+			},
+		},
+		{
+			Key:         "token",
+			StructField: "Token",
+			StringValidation: &cr.StringValidation{
+				AllowEmpty: true,
 			},
 		},
 	},
 }
 
+// validateCredentials checks that the credentials for the config's auth type are present
+func validateCredentials(cliConfig *CliConfig) error {
+	switch cliConfig.AuthType {
+	case authTypeToken:
+		if cliConfig.Token == "" {
+			return errors.Wrap(cr.ErrorMustBeDefined(), "token")
+		}
+	default:
+		if cliConfig.AWSAccessKeyID == "" {
+			return errors.Wrap(cr.ErrorMustBeDefined(), "aws_access_key_id")
+		}
+		if cliConfig.AWSSecretAccessKey == "" {
+			return errors.Wrap(cr.ErrorMustBeDefined(), "aws_secret_access_key")
+		}
+	}
+	return nil
+}
+
 func configPath() string {
 	return filepath.Join(localDir, flagEnv+".json")
 }
@@ -142,6 +221,11 @@ func readCliConfig() (*CliConfig, []error) {
 	}
 
 	cachedCliConfigErrs = cr.Struct(cachedCliConfig, cliConfigData, fileValidation)
+	if len(cachedCliConfigErrs) == 0 {
+		if err := validateCredentials(cachedCliConfig); err != nil {
+			cachedCliConfigErrs = []error{err}
+		}
+	}
 	return cachedCliConfig, errors.WrapMultiple(cachedCliConfigErrs, configPath)
 }
 
@@ -168,6 +252,15 @@ func getDefaults() *CliConfig {
 	if defaults.CortexURL == "" && os.Getenv("CORTEX_OPERATOR_ENDPOINT") != "" {
 		defaults.CortexURL = os.Getenv("CORTEX_OPERATOR_ENDPOINT")
 	}
+	if defaults.Token == "" && os.Getenv("CORTEX_TOKEN") != "" {
+		defaults.Token = os.Getenv("CORTEX_TOKEN")
+	}
+	if defaults.AuthType == "" {
+		defaults.AuthType = authTypeAWS
+		if defaults.Token != "" {
+			defaults.AuthType = authTypeToken
+		}
+	}
 
 	return defaults
 }
@@ -182,6 +275,15 @@ func configure() *CliConfig {
 		errors.Exit(err)
 	}
 
+	if cachedCliConfig.AuthType == authTypeToken {
+		err = cr.ReadPrompt(cachedCliConfig, getTokenPromptValidation(defaults))
+	} else {
+		err = cr.ReadPrompt(cachedCliConfig, getAWSPromptValidation(defaults))
+	}
+	if err != nil {
+		errors.Exit(err)
+	}
+
 	err = libjson.WriteJSON(cachedCliConfig, configPath())
 	if err != nil {
 		errors.Exit(err)
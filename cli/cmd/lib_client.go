@@ -51,6 +51,12 @@ var httpClient = &http.Client{
 	Transport: httpTransport,
 }
 
+// httpTransferClient is used for requests which transfer an app's files (e.g. cortex export and cortex import)
+var httpTransferClient = &http.Client{
+	Timeout:   time.Minute * 30,
+	Transport: httpTransport,
+}
+
 func HTTPGet(endpoint string, qParams ...map[string]string) ([]byte, error) {
 	req, err := operatorRequest("GET", endpoint, nil, qParams)
 	if err != nil {
@@ -59,6 +65,25 @@ func HTTPGet(endpoint string, qParams ...map[string]string) ([]byte, error) {
 	return makeRequest(req)
 }
 
+// HTTPTransferDownload is like HTTPGet, but allows the request to take longer, and copies the response to writer as it is received
+func HTTPTransferDownload(endpoint string, writer io.Writer, qParams ...map[string]string) error {
+	req, err := operatorRequest("GET", endpoint, nil, qParams)
+	if err != nil {
+		return err
+	}
+
+	response, err := doRequest(req, httpTransferClient)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if _, err := io.Copy(writer, response.Body); err != nil {
+		return errors.Wrap(err, errStrRead)
+	}
+	return nil
+}
+
 func HTTPPostJSONData(endpoint string, requestData interface{}, qParams ...map[string]string) ([]byte, error) {
 	jsonRequestData, err := libjson.Marshal(requestData)
 	if err != nil {
@@ -83,38 +108,67 @@ type HTTPUploadInput struct {
 }
 
 func HTTPUpload(endpoint string, input *HTTPUploadInput, qParams ...map[string]string) ([]byte, error) {
-	body := new(bytes.Buffer)
-	writer := multipart.NewWriter(body)
+	req, err := uploadRequest(endpoint, input, qParams)
+	if err != nil {
+		return nil, err
+	}
+	return makeRequest(req)
+}
 
+// HTTPTransferUpload is like HTTPUpload, but allows the request to take longer
+func HTTPTransferUpload(endpoint string, input *HTTPUploadInput, qParams ...map[string]string) ([]byte, error) {
+	req, err := uploadRequest(endpoint, input, qParams)
+	if err != nil {
+		return nil, err
+	}
+	return makeRequestWithClient(req, httpTransferClient)
+}
+
+// uploadRequest streams the files into the request body as it is sent, so that they aren't read into memory
+func uploadRequest(endpoint string, input *HTTPUploadInput, qParams []map[string]string) (*http.Request, error) {
+	// The files are opened first, so that missing files are reported instead of failing the request
+	fileReaders := make(map[string]io.Reader)
+	var openFiles []*os.File
 	for fileName, filePath := range input.FilePaths {
 		file, err := files.Open(filePath)
 		if err != nil {
+			for _, openFile := range openFiles {
+				openFile.Close()
+			}
 			return nil, err
 		}
-
-		defer file.Close()
-		if err := addFileToMultipart(fileName, writer, file); err != nil {
-			return nil, err
-		}
+		openFiles = append(openFiles, file)
+		fileReaders[fileName] = file
 	}
-
 	for fileName, fileBytes := range input.Bytes {
-		if err := addFileToMultipart(fileName, writer, bytes.NewReader(fileBytes)); err != nil {
-			return nil, err
-		}
+		fileReaders[fileName] = bytes.NewReader(fileBytes)
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, errors.Wrap(err, errStrCantMakeRequest)
-	}
+	body, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+	go func() {
+		defer func() {
+			for _, openFile := range openFiles {
+				openFile.Close()
+			}
+		}()
+		for fileName, reader := range fileReaders {
+			if err := addFileToMultipart(fileName, writer, reader); err != nil {
+				bodyWriter.CloseWithError(err)
+				return
+			}
+		}
+		bodyWriter.CloseWithError(writer.Close())
+	}()
 
 	req, err := operatorRequest("POST", endpoint, body, qParams)
 	if err != nil {
+		body.Close()
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	return makeRequest(req)
+	return req, nil
 }
 
 func addFileToMultipart(fileName string, writer *multipart.Writer, reader io.Reader) error {
@@ -147,17 +201,30 @@ func StreamLogs(appName string, resourceName string, resourceType string, verbos
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
-	req, err := operatorRequest("GET", "/logs/read", nil, nil)
+	params := map[string]string{
+		"resourceName": resourceName,
+		"resourceType": resourceType,
+		"appName":      appName,
+		"verbose":      strconv.FormatBool(verbose),
+	}
+	connection, err := dialOperator("/logs/read", params)
 	if err != nil {
 		return err
 	}
+	defer connection.Close()
 
-	values := req.URL.Query()
-	values.Set("resourceName", resourceName)
-	values.Set("resourceType", resourceType)
-	values.Set("appName", appName)
-	values.Set("verbose", strconv.FormatBool(verbose))
-	req.URL.RawQuery = values.Encode()
+	done := make(chan struct{})
+	handleConnection(connection, done)
+	closeConnection(connection, done, interrupt)
+	return nil
+}
+
+// dialOperator opens a websocket connection to the operator
+func dialOperator(endpoint string, qParams map[string]string) (*websocket.Conn, error) {
+	req, err := operatorRequest("GET", endpoint, nil, []map[string]string{qParams})
+	if err != nil {
+		return nil, err
+	}
 	wsURL := req.URL.String()
 	wsURL = strings.Replace(wsURL, "http", "ws", 1)
 
@@ -172,7 +239,7 @@ func StreamLogs(appName string, resourceName string, resourceType string, verbos
 	connection, response, err := dialer.Dial(wsURL, header)
 	if response == nil {
 		cliConfig := getValidCliConfig()
-		return ErrorFailedToConnect(strings.Replace(cliConfig.CortexURL, "http", "ws", 1))
+		return nil, ErrorFailedToConnect(strings.Replace(cliConfig.CortexURL, "http", "ws", 1))
 	}
 	defer response.Body.Close()
 
@@ -180,21 +247,17 @@ func StreamLogs(appName string, resourceName string, resourceType string, verbos
 		bodyBytes, err := ioutil.ReadAll(response.Body)
 		if err != nil || bodyBytes == nil || string(bodyBytes) == "" {
 			cliConfig := getValidCliConfig()
-			return ErrorFailedToConnect(strings.Replace(cliConfig.CortexURL, "http", "ws", 1))
+			return nil, ErrorFailedToConnect(strings.Replace(cliConfig.CortexURL, "http", "ws", 1))
 		}
 		var output schema.ErrorResponse
 		err = libjson.Unmarshal(bodyBytes, &output)
 		if err != nil || output.Error == "" {
-			return errors.New(string(bodyBytes))
+			return nil, errors.New(string(bodyBytes))
 		}
-		return errors.New(output.Error)
+		return nil, operatorError(&output)
 	}
-	defer connection.Close()
 
-	done := make(chan struct{})
-	handleConnection(connection, done)
-	closeConnection(connection, done, interrupt)
-	return nil
+	return connection, nil
 }
 
 func handleConnection(connection *websocket.Conn, done chan struct{}) {
@@ -255,17 +318,36 @@ func operatorRequest(method string, endpoint string, body io.Reader, qParams []m
 }
 
 func makeRequest(request *http.Request) ([]byte, error) {
+	return makeRequestWithClient(request, httpClient)
+}
+
+func makeRequestWithClient(request *http.Request, client *http.Client) ([]byte, error) {
+	response, err := doRequest(request, client)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errStrRead)
+	}
+	return bodyBytes, nil
+}
+
+// doRequest sends request to the operator, and returns the response if it succeeded (the caller must close its body)
+func doRequest(request *http.Request, client *http.Client) (*http.Response, error) {
 	request.Header.Set("Authorization", authHeader())
 	request.Header.Set("CortexAPIVersion", consts.CortexVersion)
 
-	response, err := httpClient.Do(request)
+	response, err := client.Do(request)
 	if err != nil {
 		cliConfig := getValidCliConfig()
 		return nil, ErrorFailedToConnect(cliConfig.CortexURL)
 	}
-	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
+		defer response.Body.Close()
 		bodyBytes, err := ioutil.ReadAll(response.Body)
 		if err != nil {
 			return nil, errors.Wrap(err, errStrRead)
@@ -277,17 +359,24 @@ func makeRequest(request *http.Request) ([]byte, error) {
 			return nil, errors.New(strings.TrimSpace(string(bodyBytes)))
 		}
 
-		return nil, errors.New(output.Error)
+		return nil, operatorError(&output)
 	}
 
-	bodyBytes, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, errors.Wrap(err, errStrRead)
+	return response, nil
+}
+
+// operatorError renders a structured error response from the operator
+func operatorError(output *schema.ErrorResponse) error {
+	if output.Message == "" {
+		return errors.New(output.Error)
 	}
-	return bodyBytes, nil
+	return errors.Wrap(ErrorOperatorResponse(output.Kind, output.Message), output.Path...)
 }
 
 func authHeader() string {
 	cliConfig := getValidCliConfig()
+	if cliConfig.AuthType == authTypeToken {
+		return fmt.Sprintf("Bearer %s", cliConfig.Token)
+	}
 	return fmt.Sprintf("CortexAWS %s|%s", cliConfig.AWSAccessKeyID, cliConfig.AWSSecretAccessKey)
 }
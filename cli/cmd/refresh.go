@@ -33,6 +33,6 @@ var refreshCmd = &cobra.Command{
 	Long:  "Delete cached resources and deploy.",
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		deploy(flagRefreshForce, true)
+		deploy(flagRefreshForce, true, "")
 	},
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+func init() {
+	addAppNameFlag(retryCmd)
+	addEnvFlag(retryCmd)
+	addResourceTypesToHelp(retryCmd)
+}
+
+var retryCmd = &cobra.Command{
+	Use:   "retry [RESOURCE_TYPE] RESOURCE_NAME",
+	Short: "retry a failed resource",
+	Long:  "Rerun the failed workload of a resource, and the workloads of the resources which depend on it, without redeploying the app.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resourceName, resourceTypeStr := "", ""
+		switch len(args) {
+		case 1:
+			resourceName = args[0]
+		case 2:
+			resourceType, err := resource.VisibleResourceTypeFromPrefix(args[0])
+			if err != nil {
+				errors.Exit(err)
+			}
+			resourceTypeStr = resourceType.String()
+			resourceName = args[1]
+		}
+
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName":      appName,
+			"resourceName": resourceName,
+			"resourceType": resourceTypeStr,
+		}
+		httpResponse, err := HTTPPostJSONData("/retry", nil, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var retryResponse schema.RetryResponse
+		err = libjson.Unmarshal(httpResponse, &retryResponse)
+		if err != nil {
+			errors.Exit(err, "/retry", "response", string(httpResponse))
+		}
+		fmt.Println(retryResponse.Message)
+	},
+}
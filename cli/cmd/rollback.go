@@ -0,0 +1,103 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/slices"
+)
+
+var flagRollbackForce bool
+var flagRollbackMessage string
+
+func init() {
+	rollbackCmd.PersistentFlags().BoolVarP(&flagRollbackForce, "force", "f", false, "stop all running jobs")
+	rollbackCmd.PersistentFlags().StringVarP(&flagRollbackMessage, "message", "m", "", "message to record in the deployment history")
+	addAppNameFlag(rollbackCmd)
+	addEnvFlag(rollbackCmd)
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback CONTEXT_ID",
+	Short: "redeploy a previous deployment",
+	Long:  "Redeploy a previous deployment from the app's history (see `cortex history`).",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		appName, err := AppNameFromFlagOrConfig()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		history, err := getHistory(appName)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		ctxID, err := resolveContextID(args[0], appName, history)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		params := map[string]string{
+			"appName": appName,
+			"ctxID":   ctxID,
+			"force":   s.Bool(flagRollbackForce),
+			"message": flagRollbackMessage,
+		}
+		httpResponse, err := HTTPPostJSONData("/rollback", nil, params)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		var deployResponse schema.DeployResponse
+		err = libjson.Unmarshal(httpResponse, &deployResponse)
+		if err != nil {
+			errors.Exit(err, "/rollback", "response", string(httpResponse))
+		}
+		fmt.Println(deployResponse.Message)
+	},
+}
+
+// resolveContextID expands a (possibly abbreviated) context ID using the app's history
+func resolveContextID(ctxIDPrefix string, appName string, history []*schema.Deployment) (string, error) {
+	var matches []string
+	for _, deployment := range history {
+		if deployment.ContextID == ctxIDPrefix {
+			return ctxIDPrefix, nil
+		}
+		if strings.HasPrefix(deployment.ContextID, ctxIDPrefix) && !slices.HasString(matches, deployment.ContextID) {
+			matches = append(matches, deployment.ContextID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", ErrorContextIDNotFound(ctxIDPrefix, appName)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", ErrorAmbiguousContextID(ctxIDPrefix, matches)
+	}
+}
@@ -38,6 +38,7 @@ var cmdStr string
 var flagEnv string
 var flagWatch bool
 var flagAppName string
+var flagDebug bool
 
 var configFileExts = []string{"json", "yaml", "yml"}
 
@@ -66,17 +67,33 @@ func Execute() {
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	cobra.EnableCommandSorting = false
 
+	// Hidden, since it is only needed when reporting issues
+	rootCmd.PersistentFlags().BoolVarP(&flagDebug, "debug", "", false, "include the kinds of the operator's errors in their messages")
+	rootCmd.PersistentFlags().MarkHidden("debug")
+
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(deployCmd)
+	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(refreshCmd)
 	rootCmd.AddCommand(predictCmd)
 	rootCmd.AddCommand(deleteCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(retryCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(gcCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(diffCmd)
 
 	rootCmd.AddCommand(getCmd)
 	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(logsCmd)
 
 	rootCmd.AddCommand(configureCmd)
+	rootCmd.AddCommand(adminCmd)
 	rootCmd.AddCommand(completionCmd)
 
 	rootCmd.Execute()
@@ -136,38 +153,14 @@ func watchHeader() string {
 
 func rerun(f func() (string, error)) {
 	if flagWatch {
-		print("\033[H\033[2J") // clear the screen
-
-		prevLines := 0
-		nextLines := 0
-
+		printer := newWatchPrinter()
 		for true {
 			str, err := f()
 			if err != nil {
 				fmt.Println()
 				errors.Exit(err)
 			}
-
-			str = watchHeader() + "\n" + str
-			str = strings.TrimRight(str, "\n") + "\n" // ensure a single new line at the end
-			strSlice := strings.Split(str, "\n")
-			nextLines = len(strSlice)
-
-			for prevLines > nextLines {
-				fmt.Printf("\033[%dA\033[2K", 1) // move the cursor up and clear the line
-				prevLines--
-			}
-
-			for i := 0; i < prevLines; i++ {
-				fmt.Printf("\033[%dA", 1) // move the cursor up
-			}
-
-			prevLines = nextLines
-
-			for _, strLine := range strSlice {
-				fmt.Printf("\033[2K%s\n", strLine) // clear the line and print the new line
-			}
-
+			printer.print(str)
 			time.Sleep(time.Second)
 		}
 	} else {
@@ -178,3 +171,35 @@ func rerun(f func() (string, error)) {
 		fmt.Println(str)
 	}
 }
+
+// watchPrinter redraws a command's output in place
+type watchPrinter struct {
+	prevLines int
+}
+
+func newWatchPrinter() *watchPrinter {
+	print("\033[H\033[2J") // clear the screen
+	return &watchPrinter{}
+}
+
+func (p *watchPrinter) print(str string) {
+	str = watchHeader() + "\n" + str
+	str = strings.TrimRight(str, "\n") + "\n" // ensure a single new line at the end
+	strSlice := strings.Split(str, "\n")
+	nextLines := len(strSlice)
+
+	for p.prevLines > nextLines {
+		fmt.Printf("\033[%dA\033[2K", 1) // move the cursor up and clear the line
+		p.prevLines--
+	}
+
+	for i := 0; i < p.prevLines; i++ {
+		fmt.Printf("\033[%dA", 1) // move the cursor up
+	}
+
+	p.prevLines = nextLines
+
+	for _, strLine := range strSlice {
+		fmt.Printf("\033[2K%s\n", strLine) // clear the line and print the new line
+	}
+}
@@ -26,9 +26,13 @@ import (
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	"github.com/cortexlabs/cortex/pkg/api/resource"
 	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 )
 
+var flagStatusAllApps bool
+
 func init() {
+	statusCmd.PersistentFlags().BoolVarP(&flagStatusAllApps, "all-apps", "", false, "show the status of every deployed app")
 	addAppNameFlag(statusCmd)
 	addEnvFlag(statusCmd)
 	addWatchFlag(statusCmd)
@@ -41,6 +45,19 @@ var statusCmd = &cobra.Command{
 	Long:  "Get resource statuses.",
 	Args:  cobra.RangeArgs(0, 2),
 	Run: func(cmd *cobra.Command, args []string) {
+		if flagStatusAllApps {
+			if len(args) > 0 {
+				errors.Exit(ErrorAllAppsArgs())
+			}
+			rerun(runStatusAllApps)
+			return
+		}
+		if flagWatch && len(args) == 0 {
+			watchResources(func(resourcesRes *schema.GetResourcesResponse) (string, error) {
+				return resourceStatusesStr(resourcesRes), nil
+			})
+			return
+		}
 		rerun(func() (string, error) {
 			return runStatus(cmd, args)
 		})
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortexlabs/cortex/pkg/aggregators"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/zip"
+	"github.com/cortexlabs/cortex/pkg/transformers"
+)
+
+func init() {
+	addEnvFlag(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "validate an application's configuration",
+	Long:  "Validate an application's configuration locally, without connecting to a cluster, and report all of the errors which are found.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the same files that cortex deploy would upload
+		zipBytes, err := zip.ToMem(configZipInput())
+		if err != nil {
+			errors.Exit(err)
+		}
+		configFiles, err := zip.UnzipMemToMem(zipBytes)
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		builtinAggregators, builtinTransformers, err := builtinSignatures()
+		if err != nil {
+			errors.Exit(err)
+		}
+
+		errs := userconfig.Check(configFiles, flagEnv, builtinAggregators, builtinTransformers)
+		if errors.HasErrors(errs) {
+			for _, err := range errs {
+				errors.PrintError(err)
+			}
+			os.Exit(1)
+		}
+		fmt.Println("Configuration is valid for environment " + flagEnv)
+	},
+}
+
+// builtinSignatures returns the signatures of the built-in aggregators and transformers, which are compiled into the CLI
+func builtinSignatures() (map[string]*userconfig.Aggregator, map[string]*userconfig.Transformer, error) {
+	aggregatorsConfig, err := userconfig.NewPartialBytes(aggregators.ConfigYAML, "aggregators.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+	builtinAggregators := make(map[string]*userconfig.Aggregator, len(aggregatorsConfig.Aggregators))
+	for _, aggregator := range aggregatorsConfig.Aggregators {
+		builtinAggregators["cortex."+aggregator.Name] = aggregator
+	}
+
+	transformersConfig, err := userconfig.NewPartialBytes(transformers.ConfigYAML, "transformers.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+	builtinTransformers := make(map[string]*userconfig.Transformer, len(transformersConfig.Transformers))
+	for _, transformer := range transformersConfig.Transformers {
+		builtinTransformers["cortex."+transformer.Name] = transformer
+	}
+
+	return builtinAggregators, builtinTransformers, nil
+}
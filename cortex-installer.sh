@@ -123,6 +123,21 @@ export CORTEX_LOG_GROUP="${CORTEX_LOG_GROUP:-cortex}"
 export CORTEX_BUCKET="${CORTEX_BUCKET:-cortex-$random_id}"
 export CORTEX_REGION="${CORTEX_REGION:-us-west-2}"
 export CORTEX_NAMESPACE="${CORTEX_NAMESPACE:-cortex}"
+export CORTEX_STORAGE_TYPE="${CORTEX_STORAGE_TYPE:-s3}"
+export CORTEX_STORAGE_ENDPOINT="${CORTEX_STORAGE_ENDPOINT:-""}"
+export CORTEX_STORAGE_LOCAL_DIR="${CORTEX_STORAGE_LOCAL_DIR:-/mnt/cortex}"
+export CORTEX_LOG_STORE_TYPE="${CORTEX_LOG_STORE_TYPE:-cloudwatch}"
+export CORTEX_LOG_STORE_ENDPOINT="${CORTEX_LOG_STORE_ENDPOINT:-""}"
+export CORTEX_LOG_STORE_LOCAL_DIR="${CORTEX_LOG_STORE_LOCAL_DIR:-/var/log/cortex}"
+export CORTEX_AUTH_TYPE="${CORTEX_AUTH_TYPE:-aws}"
+export CORTEX_AUTH_SECRETS_PATH="${CORTEX_AUTH_SECRETS_PATH:-""}"
+export CORTEX_RBAC_POLICY_PATH="${CORTEX_RBAC_POLICY_PATH:-""}"
+export CORTEX_QUOTA_POLICY_PATH="${CORTEX_QUOTA_POLICY_PATH:-""}"
+export CORTEX_OPERATOR_REPLICAS="${CORTEX_OPERATOR_REPLICAS:-1}"
+export CORTEX_WORKFLOW_ENGINE="${CORTEX_WORKFLOW_ENGINE:-argo}"
+export CORTEX_GC_SCHEDULE="${CORTEX_GC_SCHEDULE-@daily}"
+export CORTEX_GC_KEEP_CONTEXTS="${CORTEX_GC_KEEP_CONTEXTS:-10}"
+export CORTEX_METRICS_TOKEN="${CORTEX_METRICS_TOKEN:-""}"
 
 export CORTEX_IMAGE_ARGO_CONTROLLER="${CORTEX_IMAGE_ARGO_CONTROLLER:-cortexlabs/argo-controller:$CORTEX_VERSION_STABLE}"
 export CORTEX_IMAGE_ARGO_EXECUTOR="${CORTEX_IMAGE_ARGO_EXECUTOR:-cortexlabs/argo-executor:$CORTEX_VERSION_STABLE}"
@@ -173,7 +188,9 @@ function install_operator() {
   setup_configmap
   setup_secrets
   setup_spark
-  setup_argo
+  if [ "$CORTEX_WORKFLOW_ENGINE" == "argo" ]; then
+    setup_argo
+  fi
   setup_nginx
   setup_fluentd
   setup_operator
@@ -252,6 +269,11 @@ function get_endpoints() {
 #################
 
 function setup_bucket() {
+  if [ "$CORTEX_STORAGE_TYPE" != "s3" ]; then
+    echo -e "\nUsing $CORTEX_STORAGE_TYPE storage"
+    return
+  fi
+
   if ! aws s3api head-bucket --bucket $CORTEX_BUCKET --output json 2>/dev/null; then
     if aws s3 ls "s3://$CORTEX_BUCKET" --output json 2>&1 | grep -q 'NoSuchBucket'; then
       echo -e "\nCreating S3 bucket: $CORTEX_BUCKET"
@@ -269,6 +291,11 @@ function setup_bucket() {
 }
 
 function setup_cloudwatch_logs() {
+  if [ "$CORTEX_LOG_STORE_TYPE" != "cloudwatch" ]; then
+    echo -e "\nUsing $CORTEX_LOG_STORE_TYPE log store"
+    return
+  fi
+
   if ! aws logs list-tags-log-group --log-group-name $CORTEX_LOG_GROUP --region $CORTEX_REGION --output json 2>&1 | grep -q "\"tags\":"; then
     echo -e "\nCreating CloudWatch log group: $CORTEX_LOG_GROUP"
     aws logs create-log-group --log-group-name $CORTEX_LOG_GROUP --region $CORTEX_REGION
@@ -309,7 +336,29 @@ function setup_configmap() {
     --from-literal='IMAGE_TF_TRAIN_GPU'=$CORTEX_IMAGE_TF_TRAIN_GPU \
     --from-literal='IMAGE_TF_SERVE_GPU'=$CORTEX_IMAGE_TF_SERVE_GPU \
     --from-literal='ENABLE_TELEMETRY'=$CORTEX_ENABLE_TELEMETRY \
+    --from-literal='STORAGE_TYPE'=$CORTEX_STORAGE_TYPE \
+    --from-literal='STORAGE_ENDPOINT'=$CORTEX_STORAGE_ENDPOINT \
+    --from-literal='STORAGE_LOCAL_DIR'=$CORTEX_STORAGE_LOCAL_DIR \
+    --from-literal='LOG_STORE_TYPE'=$CORTEX_LOG_STORE_TYPE \
+    --from-literal='LOG_STORE_ENDPOINT'=$CORTEX_LOG_STORE_ENDPOINT \
+    --from-literal='LOG_STORE_LOCAL_DIR'=$CORTEX_LOG_STORE_LOCAL_DIR \
+    --from-literal='AUTH_TYPE'=$CORTEX_AUTH_TYPE \
+    --from-literal='WORKFLOW_ENGINE'=$CORTEX_WORKFLOW_ENGINE \
+    --from-literal='GC_SCHEDULE'="$CORTEX_GC_SCHEDULE" \
+    --from-literal='GC_KEEP_CONTEXTS'=$CORTEX_GC_KEEP_CONTEXTS \
     -o yaml --dry-run | kubectl apply -f - >/dev/null
+
+  if [ "$CORTEX_RBAC_POLICY_PATH" != "" ]; then
+    kubectl -n=$CORTEX_NAMESPACE create configmap 'cortex-rbac' \
+      --from-file='policy.yaml'=$CORTEX_RBAC_POLICY_PATH \
+      -o yaml --dry-run | kubectl apply -f - >/dev/null
+  fi
+
+  if [ "$CORTEX_QUOTA_POLICY_PATH" != "" ]; then
+    kubectl -n=$CORTEX_NAMESPACE create configmap 'cortex-quota' \
+      --from-file='quotas.yaml'=$CORTEX_QUOTA_POLICY_PATH \
+      -o yaml --dry-run | kubectl apply -f - >/dev/null
+  fi
 }
 
 #######################
@@ -321,6 +370,22 @@ function setup_secrets() {
     --from-literal='AWS_ACCESS_KEY_ID'=$AWS_ACCESS_KEY_ID \
     --from-literal='AWS_SECRET_ACCESS_KEY'=$AWS_SECRET_ACCESS_KEY \
     -o yaml --dry-run | kubectl apply -f - >/dev/null
+
+  if [ "$CORTEX_AUTH_TYPE" != "aws" ]; then
+    if [ "$CORTEX_AUTH_SECRETS_PATH" == "" ]; then
+      echo -e "\nCORTEX_AUTH_SECRETS_PATH must be set when CORTEX_AUTH_TYPE is $CORTEX_AUTH_TYPE"
+      exit 1
+    fi
+    kubectl -n=$CORTEX_NAMESPACE create secret generic 'cortex-auth' \
+      --from-file=$CORTEX_AUTH_SECRETS_PATH \
+      -o yaml --dry-run | kubectl apply -f - >/dev/null
+  fi
+
+  if [ "$CORTEX_METRICS_TOKEN" != "" ]; then
+    kubectl -n=$CORTEX_NAMESPACE create secret generic 'cortex-metrics' \
+      --from-literal='METRICS_TOKEN'=$CORTEX_METRICS_TOKEN \
+      -o yaml --dry-run | kubectl apply -f - >/dev/null
+  fi
 }
 
 ##################
@@ -1169,6 +1234,11 @@ spec:
 #####################
 
 function setup_fluentd() {
+  # Logs must be shipped to other log stores by the cluster's own log collector
+  if [ "$CORTEX_LOG_STORE_TYPE" != "cloudwatch" ]; then
+    return
+  fi
+
   echo "
 apiVersion: v1
 kind: ServiceAccount
@@ -1327,7 +1397,7 @@ metadata:
   labels:
     workloadType: operator
 spec:
-  replicas: 1
+  replicas: ${CORTEX_OPERATOR_REPLICAS}
   template:
     metadata:
       labels:
@@ -1338,6 +1408,18 @@ spec:
       - name: operator
         image: ${CORTEX_IMAGE_OPERATOR}
         imagePullPolicy: Always
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8888
+          initialDelaySeconds: 5
+          periodSeconds: 10
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: 8888
+          periodSeconds: 10
+          timeoutSeconds: 5
         env:
           - name: AWS_ACCESS_KEY_ID
             valueFrom:
@@ -1349,13 +1431,37 @@ spec:
               secretKeyRef:
                 name: aws-credentials
                 key: AWS_SECRET_ACCESS_KEY
+          - name: CORTEX_METRICS_TOKEN
+            valueFrom:
+              secretKeyRef:
+                name: cortex-metrics
+                key: METRICS_TOKEN
+                optional: true
         volumeMounts:
           - name: cortex-config
             mountPath: /configs/cortex
+          - name: cortex-auth
+            mountPath: /configs/auth
+          - name: cortex-rbac
+            mountPath: /configs/rbac
+          - name: cortex-quota
+            mountPath: /configs/quota
       volumes:
         - name: cortex-config
           configMap:
             name: cortex-config
+        - name: cortex-auth
+          secret:
+            secretName: cortex-auth
+            optional: true
+        - name: cortex-rbac
+          configMap:
+            name: cortex-rbac
+            optional: true
+        - name: cortex-quota
+          configMap:
+            name: cortex-quota
+            optional: true
       serviceAccountName: operator
 ---
 kind: Service
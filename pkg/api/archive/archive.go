@@ -0,0 +1,260 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/msgpack"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
+)
+
+// Export is an archive of an app's deployment, which is written by Write
+type Export struct {
+	manifest []byte
+	ctx      []byte
+	keys     []string
+}
+
+// NewExport creates an archive of ctx which includes the objects with the given storage keys. statusKeys are
+// written after the other keys, so that they are extracted last.
+func NewExport(manifest *schema.ExportManifest, ctx *context.Context, keys []string, statusKeys []string) (*Export, error) {
+	manifestBytes, err := libjson.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	ctxBytes, err := msgpack.Marshal(ctx.ToSerial())
+	if err != nil {
+		return nil, errors.Wrap(err, ctx.App.Name, "context")
+	}
+
+	return &Export{
+		manifest: manifestBytes,
+		ctx:      ctxBytes,
+		keys:     append(append([]string{}, keys...), statusKeys...),
+	}, nil
+}
+
+// Write writes the archive to writer as a zip file, copying each object from client in turn so that the archive is never held in memory
+func (export *Export) Write(writer io.Writer, client libstorage.Client) error {
+	archive := zip.NewWriter(writer)
+
+	for _, file := range []struct {
+		path    string
+		content []byte
+	}{
+		{schema.ExportManifestFile, export.manifest},
+		{schema.ExportContextFile, export.ctx},
+	} {
+		fileWriter, err := archive.Create(file.path)
+		if err != nil {
+			return errors.Wrap(err, "export", file.path)
+		}
+		if _, err := fileWriter.Write(file.content); err != nil {
+			return errors.Wrap(err, "export", file.path)
+		}
+	}
+
+	for _, key := range export.keys {
+		fileWriter, err := archive.Create(filepath.Join(schema.ExportObjectsDir, key))
+		if err != nil {
+			return errors.Wrap(err, "export", key)
+		}
+		if err := client.ReadToWriter(key, fileWriter); err != nil {
+			return errors.Wrap(err, "export", key)
+		}
+	}
+
+	return errors.Wrap(archive.Close(), "export")
+}
+
+// Import is an archive which has been checked by Read, and whose objects are uploaded by Upload
+type Import struct {
+	Manifest    *schema.ExportManifest
+	Context     *context.Context
+	appFiles    []*zip.File
+	statusFiles []*zip.File
+	sharedFiles map[string]*zip.File
+	impls       map[string][]byte
+}
+
+// Read reads the manifest and context of an archive written by Export, and checks the keys of all of its objects.
+// The archived context's status prefix is replaced by statusPrefix, since it determines where statuses are uploaded.
+// Only the manifest, context, and implementations are read into memory.
+func Read(archive *zip.Reader, appName string, statusPrefix string) (*Import, error) {
+	archiveFiles := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		archiveFiles[file.Name] = file
+	}
+
+	manifestBytes, err := readArchiveFile(archiveFiles, schema.ExportManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var manifest schema.ExportManifest
+	if err := libjson.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, errors.Wrap(err, schema.ExportManifestFile)
+	}
+	if manifest.AppName != appName {
+		return nil, ErrorArchiveAppMismatch(appName, manifest.AppName)
+	}
+	if manifest.CortexVersion != consts.CortexVersion {
+		return nil, ErrorArchiveVersionMismatch(manifest.CortexVersion)
+	}
+
+	ctxBytes, err := readArchiveFile(archiveFiles, schema.ExportContextFile)
+	if err != nil {
+		return nil, err
+	}
+	var serial context.Serial
+	if err := msgpack.Unmarshal(ctxBytes, &serial); err != nil {
+		return nil, errors.Wrap(err, schema.ExportContextFile)
+	}
+	ctx, err := serial.ContextFromSerial()
+	if err != nil {
+		return nil, errors.Wrap(err, schema.ExportContextFile)
+	}
+	if ctx.App.Name != appName {
+		return nil, ErrorArchiveAppMismatch(appName, ctx.App.Name)
+	}
+	ctx.StatusPrefix = statusPrefix
+
+	imp := &Import{
+		Manifest:    &manifest,
+		Context:     ctx,
+		sharedFiles: make(map[string]*zip.File),
+		impls:       make(map[string][]byte),
+	}
+
+	for path, file := range archiveFiles {
+		if path == schema.ExportManifestFile || path == schema.ExportContextFile {
+			continue
+		}
+		if !strings.HasPrefix(path, schema.ExportObjectsDir+"/") {
+			return nil, ErrorInvalidArchive(path + " is not in " + schema.ExportObjectsDir)
+		}
+		key := strings.TrimPrefix(path, schema.ExportObjectsDir+"/")
+		keyType, err := ctx.ArchivedKeyType(key)
+		if err != nil {
+			return nil, ErrorInvalidArchive(err.Error())
+		}
+
+		switch keyType {
+		case context.ArchivedAppKey:
+			imp.appFiles = append(imp.appFiles, file)
+		case context.ArchivedStatusKey:
+			imp.statusFiles = append(imp.statusFiles, file)
+		case context.ArchivedSharedKey:
+			imp.sharedFiles[key] = file
+		case context.ArchivedImplKey:
+			impl, err := readArchiveFile(archiveFiles, path)
+			if err != nil {
+				return nil, err
+			}
+			if err := context.VerifyArchivedImpl(key, impl); err != nil {
+				return nil, ErrorInvalidArchive(err.Error())
+			}
+			imp.impls[key] = impl
+		}
+	}
+
+	return imp, nil
+}
+
+// Upload uploads the archive's objects to client, and returns the number of resources whose statuses were restored.
+// Statuses are uploaded last, so that a failed import doesn't mark resources as cached without their outputs.
+func (imp *Import) Upload(client libstorage.Client) (int, error) {
+	// Shared objects may be used by other apps, so existing ones are never overwritten
+	for key, impl := range imp.impls {
+		impl, key := impl, key
+		if err := uploadSharedObject(client, key, func() error { return client.UploadBytes(impl, key) }); err != nil {
+			return 0, err
+		}
+	}
+	for key, file := range imp.sharedFiles {
+		file := file
+		if err := uploadSharedObject(client, key, func() error { return uploadArchiveFile(client, file) }); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, file := range imp.appFiles {
+		if err := uploadArchiveFile(client, file); err != nil {
+			return 0, err
+		}
+	}
+
+	restoredIDs := strset.New()
+	for _, file := range imp.statusFiles {
+		if err := uploadArchiveFile(client, file); err != nil {
+			return 0, err
+		}
+		statusKey := strings.TrimPrefix(file.Name, filepath.Join(schema.ExportObjectsDir, imp.Context.StatusPrefix)+"/")
+		restoredIDs.Add(strings.Split(statusKey, "/")[0])
+	}
+
+	return len(restoredIDs), nil
+}
+
+func readArchiveFile(archiveFiles map[string]*zip.File, path string) ([]byte, error) {
+	file, ok := archiveFiles[path]
+	if !ok {
+		return nil, ErrorInvalidArchive(path + " is missing")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+	return content, nil
+}
+
+func uploadSharedObject(client libstorage.Client, key string, upload func() error) error {
+	isUploaded, err := client.IsFile(key)
+	if err != nil {
+		return errors.Wrap(err, key)
+	}
+	if isUploaded {
+		return nil
+	}
+	return upload()
+}
+
+func uploadArchiveFile(client libstorage.Client, file *zip.File) error {
+	reader, err := file.Open()
+	if err != nil {
+		return errors.Wrap(err, file.Name)
+	}
+	defer reader.Close()
+
+	return client.UploadReader(reader, strings.TrimPrefix(file.Name, schema.ExportObjectsDir+"/"))
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/archive"
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/msgpack"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
+)
+
+const (
+	statusPrefix = "apps/app/resource_statuses"
+	aggregateKey = "apps/app/data/v1/e1/aggregates/1.msgpack"
+	constantKey  = "constants/c1.msgpack"
+)
+
+var impl = []byte("def aggregate_spark(data, columns, args):\n    return 0\n")
+var implKey = "aggregators/" + hash.Bytes(impl) + ".py"
+
+func testContext(appName string) *context.Context {
+	return &context.Context{
+		ID: "ctx1",
+		App: &context.App{
+			App: &userconfig.App{Name: appName},
+		},
+		Environment: &context.Environment{
+			Environment: &userconfig.Environment{
+				ResourceConfigFields: userconfig.ResourceConfigFields{Name: "dev"},
+				Data:                 &userconfig.CSVData{Path: "s3a://data/data.csv"},
+			},
+		},
+		StatusPrefix: "apps/" + appName + "/resource_statuses",
+		Aggregates: context.Aggregates{
+			"agg": &context.Aggregate{
+				Aggregate: &userconfig.Aggregate{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "agg"},
+				},
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           "1",
+						ResourceType: resource.AggregateType,
+					},
+				},
+				Key: aggregateKey,
+			},
+		},
+		Aggregators: context.Aggregators{
+			"aggregator": &context.Aggregator{
+				ResourceFields: &context.ResourceFields{
+					ID:           "2",
+					ResourceType: resource.AggregatorType,
+				},
+				ImplKey: implKey,
+			},
+		},
+		Constants: context.Constants{
+			"c1": &context.Constant{
+				ResourceFields: &context.ResourceFields{
+					ID:           "3",
+					ResourceType: resource.ConstantType,
+				},
+				Key: constantKey,
+			},
+		},
+	}
+}
+
+func testManifest(appName string) *schema.ExportManifest {
+	return &schema.ExportManifest{
+		AppName:       appName,
+		ContextID:     "ctx1",
+		CortexVersion: consts.CortexVersion,
+		Resources:     []string{"agg"},
+	}
+}
+
+func localClient(t *testing.T, name string) *libstorage.LocalClient {
+	tmpDir, err := files.TmpDir()
+	require.NoError(t, err)
+	client, err := libstorage.NewLocalClient(tmpDir, name)
+	require.NoError(t, err)
+	return client
+}
+
+// writeArchive writes an archive with the given manifest and context, and the given files (by path in the archive)
+func writeArchive(t *testing.T, manifest *schema.ExportManifest, ctx *context.Context, archiveFiles map[string][]byte) *zip.Reader {
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+
+	if manifest != nil {
+		manifestBytes, err := libjson.Marshal(manifest)
+		require.NoError(t, err)
+		archiveFiles[schema.ExportManifestFile] = manifestBytes
+	}
+	if ctx != nil {
+		ctxBytes, err := msgpack.Marshal(ctx.ToSerial())
+		require.NoError(t, err)
+		archiveFiles[schema.ExportContextFile] = ctxBytes
+	}
+
+	for path, content := range archiveFiles {
+		fileWriter, err := zipWriter.Create(path)
+		require.NoError(t, err)
+		_, err = fileWriter.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return zipReader
+}
+
+func TestExportImport(t *testing.T) {
+	src := localClient(t, "src")
+	dst := localClient(t, "dst")
+	defer os.RemoveAll(src.Dir)
+	defer os.RemoveAll(dst.Dir)
+
+	objects := map[string][]byte{
+		implKey:                    impl,
+		constantKey:                []byte("constant"),
+		aggregateKey:               []byte("aggregate"),
+		statusPrefix + "/1/w1":     []byte("status"),
+		statusPrefix + "/1/latest": []byte("w1"),
+	}
+	for key, content := range objects {
+		require.NoError(t, src.UploadBytes(content, key))
+	}
+
+	// Existing shared objects are not overwritten
+	require.NoError(t, dst.UploadBytes([]byte("existing constant"), constantKey))
+
+	export, err := archive.NewExport(testManifest("app"), testContext("app"),
+		[]string{implKey, constantKey, aggregateKey},
+		[]string{statusPrefix + "/1/w1", statusPrefix + "/1/latest"},
+	)
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	require.NoError(t, export.Write(buf, src))
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	imp, err := archive.Read(zipReader, "app", statusPrefix)
+	require.NoError(t, err)
+	require.Equal(t, "app", imp.Context.App.Name)
+	require.Equal(t, "ctx1", imp.Context.ID)
+	require.Equal(t, "ctx1", imp.Manifest.ContextID)
+	require.Equal(t, []string{"agg"}, imp.Manifest.Resources)
+
+	numRestored, err := imp.Upload(dst)
+	require.NoError(t, err)
+	require.Equal(t, 1, numRestored)
+
+	for key, content := range objects {
+		if key == constantKey {
+			content = []byte("existing constant")
+		}
+		uploaded, err := dst.ReadBytes(key)
+		require.NoError(t, err, key)
+		require.Equal(t, content, uploaded, key)
+	}
+
+	keys, err := dst.ListKeys("")
+	require.NoError(t, err)
+	require.Len(t, keys, len(objects))
+}
+
+func TestImportRestoredCount(t *testing.T) {
+	dst := localClient(t, "dst")
+	defer os.RemoveAll(dst.Dir)
+
+	// The manifest's resources are chosen by the uploader, so only the uploaded statuses are counted
+	manifest := testManifest("app")
+	manifest.Resources = []string{"agg", "agg2", "agg3"}
+	zipReader := writeArchive(t, manifest, testContext("app"), map[string][]byte{
+		"objects/" + aggregateKey: []byte("aggregate"),
+	})
+
+	imp, err := archive.Read(zipReader, "app", statusPrefix)
+	require.NoError(t, err)
+	numRestored, err := imp.Upload(dst)
+	require.NoError(t, err)
+	require.Equal(t, 0, numRestored)
+}
+
+func TestImportStatusPrefix(t *testing.T) {
+	dst := localClient(t, "dst")
+	defer os.RemoveAll(dst.Dir)
+
+	// The archived status prefix is replaced by the importer's
+	ctx := testContext("app")
+	ctx.StatusPrefix = "apps/other/resource_statuses"
+	zipReader := writeArchive(t, testManifest("app"), ctx, map[string][]byte{
+		"objects/" + statusPrefix + "/1/latest": []byte("w1"),
+	})
+	imp, err := archive.Read(zipReader, "app", statusPrefix)
+	require.NoError(t, err)
+	require.Equal(t, statusPrefix, imp.Context.StatusPrefix)
+	numRestored, err := imp.Upload(dst)
+	require.NoError(t, err)
+	require.Equal(t, 1, numRestored)
+
+	zipReader = writeArchive(t, testManifest("app"), ctx, map[string][]byte{
+		"objects/apps/other/resource_statuses/1/latest": []byte("w1"),
+	})
+	_, err = archive.Read(zipReader, "app", statusPrefix)
+	require.Error(t, err)
+	require.Equal(t, archive.ErrInvalidArchive, errors.Cause(err).(archive.Error).Kind)
+}
+
+func TestImportRejected(t *testing.T) {
+	oldVersionManifest := testManifest("app")
+	oldVersionManifest.CortexVersion = "0.1.0"
+
+	for _, tc := range []struct {
+		name         string
+		manifest     *schema.ExportManifest
+		ctx          *context.Context
+		archiveFiles map[string][]byte
+		expected     archive.ErrorKind
+	}{
+		{"manifest app mismatch", testManifest("other"), testContext("app"), nil, archive.ErrArchiveAppMismatch},
+		{"context app mismatch", testManifest("app"), testContext("other"), nil, archive.ErrArchiveAppMismatch},
+		{"version mismatch", oldVersionManifest, testContext("app"), nil, archive.ErrArchiveVersionMismatch},
+		{"missing manifest", nil, testContext("app"), nil, archive.ErrInvalidArchive},
+		{"missing context", testManifest("app"), nil, nil, archive.ErrInvalidArchive},
+		{"outside of objects", testManifest("app"), testContext("app"), map[string][]byte{
+			aggregateKey: []byte("aggregate"),
+		}, archive.ErrInvalidArchive},
+		{"other app", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/apps/other/data/v1/e1/aggregates/1.msgpack": []byte("aggregate"),
+		}, archive.ErrInvalidArchive},
+		{"parent directory", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/apps/app/../other/resource_statuses/1/latest": []byte("w1"),
+		}, archive.ErrInvalidArchive},
+		{"absolute path", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects//" + aggregateKey: []byte("aggregate"),
+		}, archive.ErrInvalidArchive},
+		{"unreferenced app object", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/apps/app/contexts/ctx1.msgpack": []byte("context"),
+		}, archive.ErrInvalidArchive},
+		{"unreferenced shared object", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/constants/c2.msgpack": []byte("constant"),
+		}, archive.ErrInvalidArchive},
+		{"status of unknown resource", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/" + statusPrefix + "/2/latest": []byte("w1"),
+		}, archive.ErrInvalidArchive},
+		{"modified implementation", testManifest("app"), testContext("app"), map[string][]byte{
+			"objects/" + implKey: []byte("import os\n"),
+		}, archive.ErrInvalidArchive},
+	} {
+		archiveFiles := tc.archiveFiles
+		if archiveFiles == nil {
+			archiveFiles = map[string][]byte{}
+		}
+		// Valid objects are rejected along with the invalid ones
+		archiveFiles["objects/"+aggregateKey] = []byte("aggregate")
+
+		zipReader := writeArchive(t, tc.manifest, tc.ctx, archiveFiles)
+		_, err := archive.Read(zipReader, "app", statusPrefix)
+		require.Error(t, err, tc.name)
+		require.Equal(t, tc.expected, errors.Cause(err).(archive.Error).Kind, tc.name)
+	}
+}
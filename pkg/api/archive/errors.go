@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"fmt"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/consts"
+)
+
+type ErrorKind int
+
+const (
+	ErrUnknown ErrorKind = iota
+	ErrInvalidArchive
+	ErrArchiveAppMismatch
+	ErrArchiveVersionMismatch
+)
+
+var errorKinds = []string{
+	"err_unknown",
+	"err_invalid_archive",
+	"err_archive_app_mismatch",
+	"err_archive_version_mismatch",
+}
+
+var _ = [1]int{}[int(ErrArchiveVersionMismatch)-(len(errorKinds)-1)] // Ensure list length matches
+
+func (t ErrorKind) String() string {
+	return errorKinds[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t ErrorKind) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *ErrorKind) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(errorKinds); i++ {
+		if enum == errorKinds[i] {
+			*t = ErrorKind(i)
+			return nil
+		}
+	}
+
+	*t = ErrUnknown
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *ErrorKind) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t ErrorKind) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+type Error struct {
+	Kind    ErrorKind
+	message string
+}
+
+func (e Error) Error() string {
+	return e.message
+}
+
+func ErrorInvalidArchive(reason string) error {
+	return Error{
+		Kind:    ErrInvalidArchive,
+		message: fmt.Sprintf("invalid export archive: %s", reason),
+	}
+}
+
+func ErrorArchiveAppMismatch(appName string, archiveAppName string) error {
+	return Error{
+		Kind:    ErrArchiveAppMismatch,
+		message: fmt.Sprintf("the app name in the request (%s) does not match the app name in the archive (%s)", s.UserStr(appName), s.UserStr(archiveAppName)),
+	}
+}
+
+func ErrorArchiveVersionMismatch(archiveVersion string) error {
+	return Error{
+		Kind:    ErrArchiveVersionMismatch,
+		message: fmt.Sprintf("the archive was exported from cortex version %s, but this cluster is running version %s", archiveVersion, consts.CortexVersion),
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+)
+
+type ArchivedKeyType int
+
+const (
+	ArchivedAppKey    ArchivedKeyType = iota // an object of the importing app, which is overwritten
+	ArchivedStatusKey                        // a resource status of the importing app, which is uploaded after all other objects
+	ArchivedSharedKey                        // an object which is shared by apps, which is only uploaded if it doesn't exist
+	ArchivedImplKey                          // a shared implementation, whose name is the hash of its content
+)
+
+// Shared objects are stored outside of the apps' directories, under keys which are derived from their contents
+var sharedDirs = []string{
+	consts.AggregatorsDir,
+	consts.TransformersDir,
+	consts.ModelImplsDir,
+	consts.ConstantsDir,
+	consts.PythonPackagesDir,
+}
+
+var implDirs = []string{
+	consts.AggregatorsDir,
+	consts.TransformersDir,
+	consts.ModelImplsDir,
+}
+
+// ExportedFileKeys returns the keys of the files which ctx references (other than resource outputs)
+func (ctx *Context) ExportedFileKeys() strset.Set {
+	keys := strset.New()
+	for _, aggregator := range ctx.Aggregators {
+		keys.Add(aggregator.ImplKey)
+	}
+	for _, transformer := range ctx.Transformers {
+		keys.Add(transformer.ImplKey)
+	}
+	for _, constant := range ctx.Constants {
+		keys.Add(constant.Key)
+	}
+	for _, model := range ctx.Models {
+		keys.Add(model.ImplKey)
+	}
+	for _, pythonPackage := range ctx.PythonPackages {
+		keys.Add(pythonPackage.SrcKey)
+	}
+	return keys
+}
+
+// ExportedOutputKey returns the key of res's output if it is exported, or "" if it isn't
+// (datasets and transformed columns are recomputed from the raw data when they are needed)
+func ExportedOutputKey(res ComputedResource) string {
+	switch res := res.(type) {
+	case *PythonPackage:
+		return res.PackageKey
+	case *Aggregate:
+		return res.Key
+	case *Model:
+		return res.Key
+	}
+	return ""
+}
+
+// ArchivedKeyType checks whether an object in an archive of ctx may be imported, and returns how it must be uploaded.
+// The keys in ctx are chosen by the uploader of the archive, so only keys in the app's directory or in a shared
+// directory are accepted. ctx.App.Name and ctx.StatusPrefix must be set by the importer rather than read from the archive.
+func (ctx *Context) ArchivedKeyType(key string) (ArchivedKeyType, error) {
+	if key == "" || filepath.IsAbs(key) || filepath.Clean(key) != key || strset.New(strings.Split(key, "/")...).Has("..") {
+		return 0, ErrorInvalidArchivedKey(key, "is not a relative path")
+	}
+
+	appPrefix := filepath.Join(consts.AppsDir, ctx.App.Name) + "/"
+	statusPrefix := ctx.StatusPrefix + "/"
+
+	allowedKeys := ctx.ExportedFileKeys()
+	for _, res := range ctx.DataComputedResources() {
+		outputKey := ExportedOutputKey(res)
+		if outputKey == "" {
+			continue
+		}
+		allowedKeys.Add(outputKey)
+		// Resource IDs are read from the archive too, so the status must also be checked against the app's status prefix
+		if strings.HasPrefix(key, statusPrefix) && strings.HasPrefix(key, filepath.Join(ctx.StatusPrefix, res.GetID())+"/") {
+			return ArchivedStatusKey, nil
+		}
+	}
+
+	if !allowedKeys.Has(key) {
+		return 0, ErrorInvalidArchivedKey(key, "is not referenced by the archived context")
+	}
+
+	if strings.HasPrefix(key, appPrefix) {
+		if strings.HasPrefix(key, statusPrefix) {
+			return 0, ErrorInvalidArchivedKey(key, "is not the status of an archived resource")
+		}
+		return ArchivedAppKey, nil
+	}
+	for _, dir := range implDirs {
+		if strings.HasPrefix(key, dir+"/") {
+			return ArchivedImplKey, nil
+		}
+	}
+	for _, dir := range sharedDirs {
+		if strings.HasPrefix(key, dir+"/") {
+			return ArchivedSharedKey, nil
+		}
+	}
+	return 0, ErrorInvalidArchivedKey(key, "is not in the app's directory ("+appPrefix+") or in a shared directory")
+}
+
+// VerifyArchivedImpl checks that the name of an archived implementation is the hash of its content
+func VerifyArchivedImpl(key string, content []byte) error {
+	if hash.Bytes(content)+".py" != filepath.Base(key) {
+		return ErrorArchivedImplMismatch(key)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+)
+
+func testArchivedContext(aggregateKey string, aggregateID string, aggregatorKey string) *context.Context {
+	return &context.Context{
+		App: &context.App{
+			App: &userconfig.App{Name: "app"},
+		},
+		StatusPrefix: "apps/app/resource_statuses",
+		Aggregates: context.Aggregates{
+			"agg": &context.Aggregate{
+				Aggregate: &userconfig.Aggregate{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "agg"},
+				},
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           aggregateID,
+						ResourceType: resource.AggregateType,
+					},
+				},
+				Key: aggregateKey,
+			},
+		},
+		Aggregators: context.Aggregators{
+			"aggregator": &context.Aggregator{
+				ResourceFields: &context.ResourceFields{
+					ID:           "2",
+					ResourceType: resource.AggregatorType,
+				},
+				ImplKey: aggregatorKey,
+			},
+		},
+	}
+}
+
+func TestArchivedKeyType(t *testing.T) {
+	ctx := testArchivedContext("apps/app/data/v1/e1/aggregates/1.msgpack", "1", "aggregators/a.py")
+
+	for key, expected := range map[string]context.ArchivedKeyType{
+		"apps/app/data/v1/e1/aggregates/1.msgpack": context.ArchivedAppKey,
+		"apps/app/resource_statuses/1/w1":          context.ArchivedStatusKey,
+		"apps/app/resource_statuses/1/latest":      context.ArchivedStatusKey,
+		"aggregators/a.py":                         context.ArchivedImplKey,
+	} {
+		keyType, err := ctx.ArchivedKeyType(key)
+		require.NoError(t, err, key)
+		require.Equal(t, expected, keyType, key)
+	}
+
+	for _, key := range []string{
+		"",
+		"apps/app/data/v1/e1/aggregates/2.msgpack", // not referenced by the context
+		"apps/app/resource_statuses/2/w1",          // not an archived resource
+		"apps/app/contexts/1.msgpack",
+		"apps/other/data/v1/e1/aggregates/1.msgpack",
+		"/apps/app/data/v1/e1/aggregates/1.msgpack",
+		"apps/app/../other/resource_statuses/1/w1",
+		"../apps/app/resource_statuses/1/w1",
+	} {
+		_, err := ctx.ArchivedKeyType(key)
+		require.Error(t, err, key)
+	}
+}
+
+func TestArchivedKeyTypeMaliciousContext(t *testing.T) {
+	// The keys in an archived context are chosen by its uploader
+	for _, ctx := range []*context.Context{
+		testArchivedContext("apps/other/data/v1/e1/aggregates/1.msgpack", "1", "aggregators/a.py"),
+		testArchivedContext("apps/app/../other/aggregates/1.msgpack", "1", "aggregators/a.py"),
+		testArchivedContext("../../etc/passwd", "1", "aggregators/a.py"),
+		testArchivedContext("config/cortex.yaml", "1", "aggregators/a.py"),
+		testArchivedContext("apps/app/resource_statuses/3/w1", "1", "aggregators/a.py"),
+	} {
+		key := ctx.Aggregates["agg"].Key
+		_, err := ctx.ArchivedKeyType(key)
+		require.Error(t, err, key)
+		require.Equal(t, context.ErrInvalidArchivedKey, errors.Cause(err).(context.Error).Kind, key)
+	}
+
+	// A resource ID can't escape the app's status prefix
+	ctx := testArchivedContext("apps/app/data/v1/e1/aggregates/1.msgpack", "../../other/resource_statuses/1", "aggregators/a.py")
+	_, err := ctx.ArchivedKeyType("apps/other/resource_statuses/1/w1")
+	require.Error(t, err)
+}
+
+func TestVerifyArchivedImpl(t *testing.T) {
+	impl := []byte("def aggregate_spark(data, columns, args):\n    return 0\n")
+	key := "aggregators/" + hash.Bytes(impl) + ".py"
+
+	require.NoError(t, context.VerifyArchivedImpl(key, impl))
+	err := context.VerifyArchivedImpl(key, []byte("import os\n"))
+	require.Error(t, err)
+	require.Equal(t, context.ErrArchivedImplMismatch, errors.Cause(err).(context.Error).Kind)
+}
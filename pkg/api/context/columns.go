@@ -17,12 +17,7 @@ limitations under the License.
 package context
 
 import (
-	"github.com/cortexlabs/cortex/pkg/api/resource"
-	s "github.com/cortexlabs/cortex/pkg/api/strings"
 	"github.com/cortexlabs/cortex/pkg/api/userconfig"
-	"github.com/cortexlabs/cortex/pkg/lib/cast"
-	"github.com/cortexlabs/cortex/pkg/lib/configreader"
-	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 )
@@ -88,38 +83,11 @@ func GetColumnRuntimeTypes(
 	rawColumns RawColumns,
 ) (map[string]interface{}, error) {
 
-	err := userconfig.ValidateColumnInputValues(columnInputValues)
-	if err != nil {
-		return nil, err
-	}
-
-	columnRuntimeTypes := make(map[string]interface{}, len(columnInputValues))
-
-	for inputName, columnInputValue := range columnInputValues {
-		if rawColumnName, ok := columnInputValue.(string); ok {
-			rawColumn, ok := rawColumns[rawColumnName]
-			if !ok {
-				return nil, errors.Wrap(userconfig.ErrorUndefinedResource(rawColumnName, resource.RawColumnType), inputName)
-			}
-			columnRuntimeTypes[inputName] = rawColumn.GetType()
-			continue
-		}
-
-		if rawColumnNames, ok := cast.InterfaceToStrSlice(columnInputValue); ok {
-			rawColumnTypes := make([]userconfig.ColumnType, len(rawColumnNames))
-			for i, rawColumnName := range rawColumnNames {
-				rawColumn, ok := rawColumns[rawColumnName]
-				if !ok {
-					return nil, errors.Wrap(userconfig.ErrorUndefinedResource(rawColumnName, resource.RawColumnType), inputName, s.Index(i))
-				}
-				rawColumnTypes[i] = rawColumn.GetType()
-			}
-			columnRuntimeTypes[inputName] = rawColumnTypes
-			continue
+	return userconfig.ColumnRuntimeTypes(columnInputValues, func(name string) (userconfig.ColumnType, bool) {
+		rawColumn, ok := rawColumns[name]
+		if !ok {
+			return userconfig.UnknownColumnType, false
 		}
-
-		return nil, errors.Wrap(configreader.ErrorInvalidPrimitiveType(columnInputValue, s.PrimTypeString, s.PrimTypeStringList), inputName) // unexpected
-	}
-
-	return columnRuntimeTypes, nil
+		return rawColumn.GetType(), true
+	})
 }
@@ -0,0 +1,209 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"sort"
+
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+)
+
+const (
+	ChangeCompute        = "compute"
+	ChangeInputs         = "inputs"
+	ChangeImplementation = "implementation"
+	ChangeTags           = "tags"
+	ChangeConfiguration  = "configuration"
+)
+
+type Diff struct {
+	Added   []*ResourceDiff `json:"added"`
+	Removed []*ResourceDiff `json:"removed"`
+	Changed []*ResourceDiff `json:"changed"`
+}
+
+type ResourceDiff struct {
+	Name         string        `json:"name"`
+	ResourceType resource.Type `json:"resource_type"`
+	FromID       string        `json:"from_id"`
+	ToID         string        `json:"to_id"`
+	Changes      []string      `json:"changes"`
+}
+
+func (diff *Diff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// DiffContexts compares the resources in two contexts by type and name
+func DiffContexts(from *Context, to *Context) *Diff {
+	diff := &Diff{}
+
+	fromResources := resourcesByTypeAndName(from)
+	toResources := resourcesByTypeAndName(to)
+
+	for key, fromRes := range fromResources {
+		toRes, ok := toResources[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, newResourceDiff(fromRes, nil))
+			continue
+		}
+		changes := resourceChanges(fromRes, from, toRes, to)
+		if len(changes) > 0 {
+			resourceDiff := newResourceDiff(fromRes, toRes)
+			resourceDiff.Changes = changes
+			diff.Changed = append(diff.Changed, resourceDiff)
+		}
+	}
+
+	for key, toRes := range toResources {
+		if _, ok := fromResources[key]; !ok {
+			diff.Added = append(diff.Added, newResourceDiff(nil, toRes))
+		}
+	}
+
+	sortResourceDiffs(diff.Added)
+	sortResourceDiffs(diff.Removed)
+	sortResourceDiffs(diff.Changed)
+	return diff
+}
+
+func resourcesByTypeAndName(ctx *Context) map[string]Resource {
+	resources := make(map[string]Resource)
+	if ctx == nil {
+		return resources
+	}
+	for _, res := range ctx.AllResources() {
+		resources[res.GetResourceType().String()+"/"+res.GetName()] = res
+	}
+	return resources
+}
+
+func newResourceDiff(from Resource, to Resource) *ResourceDiff {
+	resourceDiff := &ResourceDiff{}
+	if from != nil {
+		resourceDiff.Name = from.GetName()
+		resourceDiff.ResourceType = from.GetResourceType()
+		resourceDiff.FromID = from.GetID()
+	}
+	if to != nil {
+		resourceDiff.Name = to.GetName()
+		resourceDiff.ResourceType = to.GetResourceType()
+		resourceDiff.ToID = to.GetID()
+	}
+	return resourceDiff
+}
+
+func resourceChanges(from Resource, fromCtx *Context, to Resource, toCtx *Context) []string {
+	var changes []string
+
+	if resourceComputeStr(from) != resourceComputeStr(to) {
+		changes = append(changes, ChangeCompute)
+	}
+	if resourceInputsStr(from) != resourceInputsStr(to) {
+		changes = append(changes, ChangeInputs)
+	}
+	if resourceImplStr(from, fromCtx) != resourceImplStr(to, toCtx) {
+		changes = append(changes, ChangeImplementation)
+	}
+
+	if from.GetID() != to.GetID() && len(changes) == 0 {
+		// e.g. hyperparameters, or an upstream resource
+		changes = append(changes, ChangeConfiguration)
+	}
+
+	if from.GetIDWithTags() != to.GetIDWithTags() && from.GetID() == to.GetID() {
+		changes = append(changes, ChangeTags)
+	}
+
+	return changes
+}
+
+func resourceComputeStr(res Resource) string {
+	switch typedRes := res.(type) {
+	case RawColumn:
+		return sparkComputeStr(typedRes.GetCompute())
+	case *Aggregate:
+		return sparkComputeStr(typedRes.Compute)
+	case *TransformedColumn:
+		return sparkComputeStr(typedRes.Compute)
+	case *Model:
+		if typedRes.Compute == nil {
+			return ""
+		}
+		return typedRes.Compute.ID()
+	case *API:
+		if typedRes.Compute == nil {
+			return ""
+		}
+		return typedRes.Compute.ID()
+	}
+	return ""
+}
+
+func sparkComputeStr(sparkCompute *userconfig.SparkCompute) string {
+	if sparkCompute == nil {
+		return ""
+	}
+	return sparkCompute.ID()
+}
+
+func resourceInputsStr(res Resource) string {
+	switch typedRes := res.(type) {
+	case *Aggregate:
+		return s.Obj(typedRes.Aggregator) + s.Obj(typedRes.Inputs)
+	case *TransformedColumn:
+		return s.Obj(typedRes.Transformer) + s.Obj(typedRes.Inputs)
+	case *Model:
+		return s.Obj(typedRes.TargetColumn) + s.Obj(typedRes.FeatureColumns) + s.Obj(typedRes.TrainingColumns) + s.Obj(typedRes.Aggregates)
+	case *API:
+		return typedRes.ModelName
+	}
+	return ""
+}
+
+func resourceImplStr(res Resource, ctx *Context) string {
+	switch typedRes := res.(type) {
+	case *Aggregator:
+		return typedRes.ImplKey
+	case *Transformer:
+		return typedRes.ImplKey
+	case *Model:
+		return typedRes.ImplID
+	case *PythonPackage:
+		return typedRes.GetID()
+	case *Aggregate:
+		if aggregator, ok := ctx.Aggregators[typedRes.Aggregator]; ok {
+			return aggregator.ImplKey
+		}
+	case *TransformedColumn:
+		if transformer, ok := ctx.Transformers[typedRes.Transformer]; ok {
+			return transformer.ImplKey
+		}
+	}
+	return ""
+}
+
+func sortResourceDiffs(resourceDiffs []*ResourceDiff) {
+	sort.Slice(resourceDiffs, func(i, j int) bool {
+		if resourceDiffs[i].ResourceType != resourceDiffs[j].ResourceType {
+			return resourceDiffs[i].ResourceType < resourceDiffs[j].ResourceType
+		}
+		return resourceDiffs[i].Name < resourceDiffs[j].Name
+	})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+)
+
+func testAPI(name string, id string, idWithTags string, modelName string, replicas int32) *context.API {
+	return &context.API{
+		API: &userconfig.API{
+			ResourceConfigFields: userconfig.ResourceConfigFields{Name: name},
+			ModelName:            modelName,
+			Compute:              &userconfig.APICompute{Replicas: replicas},
+		},
+		ComputedResourceFields: &context.ComputedResourceFields{
+			ResourceFields: &context.ResourceFields{
+				ID:           id,
+				IDWithTags:   idWithTags,
+				ResourceType: resource.APIType,
+			},
+		},
+	}
+}
+
+func testTransformer(name string, id string, implKey string) *context.Transformer {
+	return &context.Transformer{
+		Transformer: &userconfig.Transformer{
+			ResourceConfigFields: userconfig.ResourceConfigFields{Name: name},
+		},
+		ResourceFields: &context.ResourceFields{
+			ID:           id,
+			IDWithTags:   id,
+			ResourceType: resource.TransformerType,
+		},
+		ImplKey: implKey,
+	}
+}
+
+func TestDiffContexts(t *testing.T) {
+	from := &context.Context{
+		APIs: context.APIs{
+			"same":     testAPI("same", "1", "1", "m", 1),
+			"removed":  testAPI("removed", "2", "2", "m", 1),
+			"replicas": testAPI("replicas", "3", "3", "m", 1),
+			"model":    testAPI("model", "4", "4", "m", 1),
+			"tags":     testAPI("tags", "5", "5", "m", 1),
+		},
+		Transformers: context.Transformers{
+			"t": testTransformer("t", "6", "transformers/a.py"),
+		},
+	}
+
+	to := &context.Context{
+		APIs: context.APIs{
+			"same":     testAPI("same", "1", "1", "m", 1),
+			"added":    testAPI("added", "7", "7", "m", 1),
+			"replicas": testAPI("replicas", "3", "3", "m", 2),
+			"model":    testAPI("model", "8", "8", "m2", 1),
+			"tags":     testAPI("tags", "5", "9", "m", 1),
+		},
+		Transformers: context.Transformers{
+			"t": testTransformer("t", "10", "transformers/b.py"),
+		},
+	}
+
+	diff := context.DiffContexts(from, to)
+	require.False(t, diff.IsEmpty())
+
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "added", diff.Added[0].Name)
+	require.Equal(t, "7", diff.Added[0].ToID)
+
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "removed", diff.Removed[0].Name)
+	require.Equal(t, "2", diff.Removed[0].FromID)
+
+	changes := make(map[string][]string)
+	for _, resourceDiff := range diff.Changed {
+		changes[resourceDiff.Name] = resourceDiff.Changes
+	}
+	require.Equal(t, map[string][]string{
+		"model":    {context.ChangeInputs},
+		"replicas": {context.ChangeCompute},
+		"tags":     {context.ChangeTags},
+		"t":        {context.ChangeImplementation},
+	}, changes)
+
+	// APIs sort before transformers
+	require.Equal(t, resource.TransformerType, diff.Changed[len(diff.Changed)-1].ResourceType)
+
+	require.True(t, context.DiffContexts(from, from).IsEmpty())
+	require.Len(t, context.DiffContexts(nil, to).Added, 6)
+}
@@ -44,10 +44,12 @@ type APISavedStatus struct {
 type DataExitCode string
 
 const (
-	ExitCodeDataSucceeded DataExitCode = "succeeded"
-	ExitCodeDataFailed    DataExitCode = "failed"
-	ExitCodeDataKilled    DataExitCode = "killed"
-	ExitCodeDataOOM       DataExitCode = "oom"
+	ExitCodeDataSucceeded     DataExitCode = "succeeded"
+	ExitCodeDataFailed        DataExitCode = "failed"
+	ExitCodeDataKilled        DataExitCode = "killed"
+	ExitCodeDataOOM           DataExitCode = "oom"
+	ExitCodeDataTimedOut      DataExitCode = "timed_out"
+	ExitCodeDataQuotaExceeded DataExitCode = "quota_exceeded"
 )
 
 func DataSavedStatusPtrsEqual(savedStatus *DataSavedStatus, savedStatus2 *DataSavedStatus) bool {
@@ -22,7 +22,9 @@ import (
 
 type DataStatus struct {
 	DataSavedStatus
-	Code StatusCode `json:"status_code"`
+	Code        StatusCode `json:"status_code"`
+	Attempt     int32      `json:"attempt"`      // the attempt of the resource's workload (starting at 1)
+	MaxAttempts int32      `json:"max_attempts"` // the number of attempts allowed by the workload's retry policy
 }
 
 type APIStatus struct {
@@ -111,6 +113,8 @@ const (
 	StatusAPIGroupUpdateSkipped
 
 	StatusDataKilledOOM
+	StatusDataTimedOut
+	StatusDataQuotaExceeded
 )
 
 var statusCodes = []string{
@@ -140,9 +144,11 @@ var statusCodes = []string{
 	"status_api_group_update_skipped",
 
 	"status_data_oom",
+	"status_data_timed_out",
+	"status_data_quota_exceeded",
 }
 
-var _ = [1]int{}[int(StatusDataKilledOOM)-(len(statusCodes)-1)] // Ensure list length matches
+var _ = [1]int{}[int(StatusDataQuotaExceeded)-(len(statusCodes)-1)] // Ensure list length matches
 
 var statusCodeMessages = []string{
 	"unknown", // StatusUnknown
@@ -171,9 +177,11 @@ var statusCodeMessages = []string{
 	"update skipped",       // StatusAPIGroupUpdateSkipped
 
 	"terminated (out of mem)", // StatusDataOOM
+	"timed out",               // StatusDataTimedOut
+	"exceeds quota",           // StatusDataQuotaExceeded
 }
 
-var _ = [1]int{}[int(StatusDataKilledOOM)-(len(statusCodeMessages)-1)] // Ensure list length matches
+var _ = [1]int{}[int(StatusDataQuotaExceeded)-(len(statusCodeMessages)-1)] // Ensure list length matches
 
 // StatusDataRunning aliases
 const (
@@ -211,9 +219,11 @@ var statusSortBuckets = []int{
 	2, // StatusAPIGroupUpdateSkipped
 
 	1, // StatusDataKilledOOM
+	1, // StatusDataTimedOut
+	1, // StatusDataQuotaExceeded
 }
 
-var _ = [1]int{}[int(StatusDataKilledOOM)-(len(statusSortBuckets)-1)] // Ensure list length matches
+var _ = [1]int{}[int(StatusDataQuotaExceeded)-(len(statusSortBuckets)-1)] // Ensure list length matches
 
 func (code StatusCode) String() string {
 	if int(code) < 0 || int(code) >= len(statusCodes) {
@@ -17,20 +17,114 @@ limitations under the License.
 package schema
 
 import (
+	"time"
+
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
 )
 
 type DeployResponse struct {
 	Message string `json:"message"`
 }
 
+type DeployPlanResponse struct {
+	Message          string             `json:"message"`
+	ContextID        string             `json:"context_id"`
+	StoppedWorkflow  string             `json:"stopped_workflow"`
+	StoppedContextID string             `json:"stopped_context_id"`
+	Resources        []*PlannedResource `json:"resources"`
+	Workloads        []*PlannedWorkload `json:"workloads"`
+}
+
+type PlannedResource struct {
+	Name         string        `json:"name"`
+	ResourceType resource.Type `json:"resource_type"`
+	Cached       bool          `json:"cached"`
+}
+
+type PlannedWorkload struct {
+	WorkloadID   string                   `json:"workload_id"`
+	WorkloadType string                   `json:"workload_type"`
+	Resources    []*PlannedResource       `json:"resources"`
+	SparkCompute *userconfig.SparkCompute `json:"spark_compute"`
+	TFCompute    *userconfig.TFCompute    `json:"tf_compute"`
+	APICompute   *userconfig.APICompute   `json:"api_compute"`
+}
+
 type DeleteResponse struct {
 	Message string `json:"message"`
 }
 
+type StopResponse struct {
+	Message string `json:"message"`
+}
+
+type GCResponse struct {
+	Message   string         `json:"message"`
+	DryRun    bool           `json:"dry_run"`
+	Reclaimed []*GCReclaimed `json:"reclaimed"`
+}
+
+// GCReclaimed summarizes the unreachable objects in a directory (e.g. "apps/iris/contexts")
+type GCReclaimed struct {
+	Prefix  string `json:"prefix"`
+	Objects int    `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type GetCacheResponse struct {
+	Resources       []*CachedResource `json:"resources"`
+	RawDatasetBytes *int64            `json:"raw_dataset_bytes"` // the size of the raw dataset, which all raw columns share (nil if the app has no raw columns)
+}
+
+type CachedResource struct {
+	Name         string        `json:"name"`
+	ResourceType resource.Type `json:"resource_type"`
+	ResourceID   string        `json:"resource_id"`
+	WorkloadID   string        `json:"workload_id"`
+	Cached       bool          `json:"cached"`
+	CachedAt     *time.Time    `json:"cached_at"` // when the resource's output was computed
+	Bytes        *int64        `json:"bytes"`     // the size of the resource's output (nil if it isn't stored separately, e.g. for raw columns)
+}
+
+type CacheRmResponse struct {
+	Message     string   `json:"message"`
+	ResourceIDs []string `json:"resource_ids"`
+}
+
+// The layout of an archive created by cortex export; objects are stored under ExportObjectsDir by storage key
+const (
+	ExportManifestFile = "manifest.json"
+	ExportContextFile  = "context.msgpack"
+	ExportObjectsDir   = "objects"
+)
+
+type ExportManifest struct {
+	AppName       string    `json:"app_name"`
+	ContextID     string    `json:"context_id"`
+	Environment   string    `json:"environment"`
+	CortexVersion string    `json:"cortex_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Resources     []string  `json:"resources"` // the names of the resources whose cached outputs are included
+}
+
+type ImportResponse struct {
+	Message   string `json:"message"`
+	ContextID string `json:"context_id"`
+}
+
+type RetryResponse struct {
+	Message     string   `json:"message"`
+	ResourceIDs []string `json:"resource_ids"`
+}
+
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error      string   `json:"error"`
+	Kind       string   `json:"kind"`
+	Message    string   `json:"message"`
+	Path       []string `json:"path"`
+	StatusCode int      `json:"status_code"`
 }
 
 type GetResourcesResponse struct {
@@ -41,6 +135,76 @@ type GetResourcesResponse struct {
 	APIsBaseURL      string                              `json:"apis_base_url"`
 }
 
+const (
+	AppStatusReady    = "ready"
+	AppStatusUpdating = "updating"
+	AppStatusError    = "error"
+)
+
+type AppSummary struct {
+	Name        string     `json:"name"`
+	Environment string     `json:"environment"`
+	ContextID   string     `json:"context_id"`
+	DeployedAt  *time.Time `json:"deployed_at"`
+	Running     bool       `json:"running"`
+	Status      string     `json:"status"`
+}
+
+type GetAppsResponse struct {
+	Apps []*AppSummary `json:"apps"`
+}
+
 type GetAggregateResponse struct {
 	Value []byte `json:"value"`
 }
+
+type Deployment struct {
+	ContextID   string    `json:"context_id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Environment string    `json:"environment"`
+	Identity    string    `json:"identity"`
+	Message     string    `json:"message"`
+}
+
+type GetHistoryResponse struct {
+	History []*Deployment `json:"history"`
+}
+
+const (
+	AuditActionDeploy   = "deploy"
+	AuditActionRollback = "rollback"
+	AuditActionDelete   = "delete"
+	AuditActionRetry    = "retry"
+	AuditActionStop     = "stop"
+	AuditActionGC       = "gc"
+	AuditActionCacheRm  = "cache_rm"
+	AuditActionImport   = "import"
+)
+
+type AuditRecord struct {
+	Timestamp   time.Time         `json:"timestamp"`
+	Action      string            `json:"action"`
+	Identity    string            `json:"identity"`
+	AppName     string            `json:"app_name"`
+	Environment string            `json:"environment"`
+	Flags       map[string]string `json:"flags"`
+	ContextID   string            `json:"context_id"`
+	StatusCode  int               `json:"status_code"`
+	Message     string            `json:"message"`
+	Duration    time.Duration     `json:"duration"`
+}
+
+type GetAuditResponse struct {
+	Records []*AuditRecord `json:"records"`
+}
+
+type DiffResponse struct {
+	FromContextID string        `json:"from_context_id"`
+	ToContextID   string        `json:"to_context_id"`
+	Diff          *context.Diff `json:"diff"`
+}
+
+type ReadyzResponse struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
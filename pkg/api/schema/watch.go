@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"reflect"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+)
+
+// ResourcesUpdate is a message from the resources watch endpoint. When Context is set (e.g. in the first
+// message, or after a deployment), the update is complete; otherwise it only contains the statuses which changed.
+type ResourcesUpdate struct {
+	Context          *context.Context                    `json:"context"`
+	DataStatuses     map[string]*resource.DataStatus     `json:"data_statuses"`
+	APIStatuses      map[string]*resource.APIStatus      `json:"api_statuses"`
+	APIGroupStatuses map[string]*resource.APIGroupStatus `json:"api_name_statuses"`
+	APIsBaseURL      string                              `json:"apis_base_url"`
+	Error            *ErrorResponse                      `json:"error"`
+}
+
+// NewResourcesUpdate returns the update which turns prev into next, or nil if they are the same
+func NewResourcesUpdate(prev *GetResourcesResponse, next *GetResourcesResponse) *ResourcesUpdate {
+	if prev == nil || prev.Context == nil || next.Context == nil || prev.Context.ID != next.Context.ID ||
+		prev.APIsBaseURL != next.APIsBaseURL ||
+		!sameKeys(prev.DataStatuses, next.DataStatuses) ||
+		!sameKeys(prev.APIStatuses, next.APIStatuses) ||
+		!sameKeys(prev.APIGroupStatuses, next.APIGroupStatuses) {
+
+		return &ResourcesUpdate{
+			Context:          next.Context,
+			DataStatuses:     next.DataStatuses,
+			APIStatuses:      next.APIStatuses,
+			APIGroupStatuses: next.APIGroupStatuses,
+			APIsBaseURL:      next.APIsBaseURL,
+		}
+	}
+
+	update := &ResourcesUpdate{
+		DataStatuses:     make(map[string]*resource.DataStatus),
+		APIStatuses:      make(map[string]*resource.APIStatus),
+		APIGroupStatuses: make(map[string]*resource.APIGroupStatus),
+		APIsBaseURL:      next.APIsBaseURL,
+	}
+	changed := false
+	for key, status := range next.DataStatuses {
+		if !reflect.DeepEqual(status, prev.DataStatuses[key]) {
+			update.DataStatuses[key] = status
+			changed = true
+		}
+	}
+	for key, status := range next.APIStatuses {
+		if !reflect.DeepEqual(status, prev.APIStatuses[key]) {
+			update.APIStatuses[key] = status
+			changed = true
+		}
+	}
+	for key, status := range next.APIGroupStatuses {
+		if !reflect.DeepEqual(status, prev.APIGroupStatuses[key]) {
+			update.APIGroupStatuses[key] = status
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return update
+}
+
+// Apply updates res in place
+func (res *GetResourcesResponse) Apply(update *ResourcesUpdate) {
+	if update.Context != nil {
+		res.Context = update.Context
+		res.DataStatuses = update.DataStatuses
+		res.APIStatuses = update.APIStatuses
+		res.APIGroupStatuses = update.APIGroupStatuses
+		res.APIsBaseURL = update.APIsBaseURL
+		return
+	}
+
+	for key, status := range update.DataStatuses {
+		res.DataStatuses[key] = status
+	}
+	for key, status := range update.APIStatuses {
+		res.APIStatuses[key] = status
+	}
+	for key, status := range update.APIGroupStatuses {
+		res.APIGroupStatuses[key] = status
+	}
+}
+
+func sameKeys(m1 interface{}, m2 interface{}) bool {
+	v1 := reflect.ValueOf(m1)
+	v2 := reflect.ValueOf(m2)
+	if v1.Len() != v2.Len() {
+		return false
+	}
+	for _, key := range v1.MapKeys() {
+		if !v2.MapIndex(key).IsValid() {
+			return false
+		}
+	}
+	return true
+}
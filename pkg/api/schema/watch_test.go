@@ -0,0 +1,99 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+)
+
+func resourcesResponse(ctxID string, dataCodes map[string]resource.StatusCode, apiCodes map[string]resource.StatusCode) *schema.GetResourcesResponse {
+	res := &schema.GetResourcesResponse{
+		Context:          &context.Context{ID: ctxID},
+		DataStatuses:     make(map[string]*resource.DataStatus),
+		APIStatuses:      make(map[string]*resource.APIStatus),
+		APIGroupStatuses: make(map[string]*resource.APIGroupStatus),
+		APIsBaseURL:      "https://apis",
+	}
+	for id, code := range dataCodes {
+		res.DataStatuses[id] = &resource.DataStatus{Code: code}
+	}
+	for id, code := range apiCodes {
+		res.APIStatuses[id] = &resource.APIStatus{Code: code}
+		res.APIGroupStatuses[id] = &resource.APIGroupStatus{APIName: id, Code: code}
+	}
+	return res
+}
+
+func TestResourcesUpdate(t *testing.T) {
+	prev := resourcesResponse("ctx1",
+		map[string]resource.StatusCode{"a": resource.StatusDataRunning, "b": resource.StatusPending},
+		map[string]resource.StatusCode{"api": resource.StatusPendingCompute},
+	)
+
+	// First update is complete
+	update := schema.NewResourcesUpdate(nil, prev)
+	require.NotNil(t, update.Context)
+	watched := &schema.GetResourcesResponse{}
+	watched.Apply(update)
+	require.Equal(t, prev, watched)
+
+	// No changes
+	same := resourcesResponse("ctx1",
+		map[string]resource.StatusCode{"a": resource.StatusDataRunning, "b": resource.StatusPending},
+		map[string]resource.StatusCode{"api": resource.StatusPendingCompute},
+	)
+	require.Nil(t, schema.NewResourcesUpdate(prev, same))
+
+	// Only changed statuses are sent
+	next := resourcesResponse("ctx1",
+		map[string]resource.StatusCode{"a": resource.StatusDataSucceeded, "b": resource.StatusPending},
+		map[string]resource.StatusCode{"api": resource.StatusAPIReady},
+	)
+	update = schema.NewResourcesUpdate(prev, next)
+	require.Nil(t, update.Context)
+	require.Len(t, update.DataStatuses, 1)
+	require.Contains(t, update.DataStatuses, "a")
+	require.Len(t, update.APIStatuses, 1)
+	require.Len(t, update.APIGroupStatuses, 1)
+	watched.Apply(update)
+	require.Equal(t, next, watched)
+
+	// A new context (or new resources) resends everything
+	redeployed := resourcesResponse("ctx2",
+		map[string]resource.StatusCode{"a": resource.StatusDataSucceeded},
+		map[string]resource.StatusCode{"api": resource.StatusAPIReady},
+	)
+	update = schema.NewResourcesUpdate(next, redeployed)
+	require.NotNil(t, update.Context)
+	watched.Apply(update)
+	require.Equal(t, redeployed, watched)
+
+	added := resourcesResponse("ctx2",
+		map[string]resource.StatusCode{"a": resource.StatusDataSucceeded, "c": resource.StatusPending},
+		map[string]resource.StatusCode{"api": resource.StatusAPIReady},
+	)
+	update = schema.NewResourcesUpdate(redeployed, added)
+	require.NotNil(t, update.Context)
+	watched.Apply(update)
+	require.Equal(t, added, watched)
+}
@@ -28,4 +28,6 @@ const (
 	ResDeploymentStoppedDeploymentStarted             = "Running deployment stopped, new deployment started"
 	ResDeploymentStoppedCacheDeletedDeploymentStarted = "Running deployment stopped, cached deleted, new deployment started"
 	ResDeploymentStoppedDeploymentUpToDate            = "Running deployment stopped, new deployment is up-to-date"
+	ResDeploymentStopped                              = "Deployment stopped, APIs are unchanged"
+	ResDeploymentNotRunning                           = "Deployment is not running"
 )
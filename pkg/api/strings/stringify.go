@@ -327,3 +327,18 @@ func UserStrs(val interface{}) []string {
 func Index(index int) string {
 	return fmt.Sprintf("index %d", index)
 }
+
+// ByteSize formats a number of bytes with a binary unit (e.g. "1.5 GiB")
+func ByteSize(bytes int64) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	for _, unit := range []string{"KiB", "MiB", "GiB", "TiB"} {
+		value /= 1024
+		if value < 1024 || unit == "TiB" {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+	}
+	return "" // unreachable
+}
@@ -257,3 +257,11 @@ func TestObj(t *testing.T) {
 	require.Equal(t, test3SubStrMultiline, s.Obj(&testInterface))
 
 }
+
+func TestByteSize(t *testing.T) {
+	require.Equal(t, "0 B", s.ByteSize(0))
+	require.Equal(t, "1023 B", s.ByteSize(1023))
+	require.Equal(t, "1.0 KiB", s.ByteSize(1024))
+	require.Equal(t, "1.5 MiB", s.ByteSize(1536*1024))
+	require.Equal(t, "2048.0 TiB", s.ByteSize(2048*1024*1024*1024*1024))
+}
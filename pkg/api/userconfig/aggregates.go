@@ -74,6 +74,10 @@ func (aggregate *Aggregate) GetResourceType() resource.Type {
 	return resource.AggregateType
 }
 
+func (aggregate *Aggregate) GetTags() Tags {
+	return aggregate.Tags
+}
+
 func (aggregates Aggregates) Names() []string {
 	names := make([]string, len(aggregates))
 	for i, aggregate := range aggregates {
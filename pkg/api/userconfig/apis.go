@@ -70,6 +70,10 @@ func (api *API) GetResourceType() resource.Type {
 	return resource.APIType
 }
 
+func (api *API) GetTags() Tags {
+	return api.Tags
+}
+
 func (apis APIs) Names() []string {
 	names := make([]string, len(apis))
 	for i, api := range apis {
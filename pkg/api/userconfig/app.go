@@ -21,7 +21,8 @@ import (
 )
 
 type App struct {
-	Name string `json:"name" yaml:"name"`
+	Name    string `json:"name" yaml:"name"`
+	Timeout *int64 `json:"timeout" yaml:"timeout"`
 }
 
 var appValidation = &cr.StructValidation{
@@ -34,6 +35,7 @@ var appValidation = &cr.StructValidation{
 				AlphaNumericDashUnderscore: true,
 			},
 		},
+		timeoutFieldValidation,
 		typeFieldValidation,
 	},
 }
@@ -25,6 +25,7 @@ package userconfig
 
 import (
 	"bytes"
+	"time"
 
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 
@@ -43,6 +44,8 @@ type SparkCompute struct {
 	ExecutorMem         Quantity  `json:"executor_mem" yaml:"executor_mem"`
 	ExecutorMemOverhead *Quantity `json:"executor_mem_overhead" yaml:"executor_mem_overhead"`
 	MemOverheadFactor   *float64  `json:"mem_overhead_factor" yaml:"mem_overhead_factor"`
+	Timeout             *int64    `json:"timeout" yaml:"timeout"`
+	Retry               *Retry    `json:"retry" yaml:"retry"`
 }
 
 var sparkComputeFieldValidation = &cr.StructFieldValidation{
@@ -119,6 +122,8 @@ var sparkComputeFieldValidation = &cr.StructFieldValidation{
 					LessThan:             pointer.Float64(1),
 				},
 			},
+			timeoutFieldValidation,
+			retryFieldValidation,
 		},
 	},
 }
@@ -141,9 +146,11 @@ func (sparkCompute *SparkCompute) ID() string {
 }
 
 type TFCompute struct {
-	CPU *Quantity `json:"cpu" yaml:"cpu"`
-	Mem *Quantity `json:"mem" yaml:"mem"`
-	GPU *int64    `json:"gpu" yaml:"gpu"`
+	CPU     *Quantity `json:"cpu" yaml:"cpu"`
+	Mem     *Quantity `json:"mem" yaml:"mem"`
+	GPU     *int64    `json:"gpu" yaml:"gpu"`
+	Timeout *int64    `json:"timeout" yaml:"timeout"`
+	Retry   *Retry    `json:"retry" yaml:"retry"`
 }
 
 var tfComputeFieldValidation = &cr.StructFieldValidation{
@@ -175,6 +182,8 @@ var tfComputeFieldValidation = &cr.StructFieldValidation{
 					GreaterThan: pointer.Int64(0),
 				},
 			},
+			timeoutFieldValidation,
+			retryFieldValidation,
 		},
 	},
 }
@@ -250,8 +259,60 @@ func (apiCompute *APICompute) IDWithoutReplicas() string {
 	return hash.Bytes(buf.Bytes())
 }
 
+// timeoutFieldValidation is shared by the app and the data processing / training compute configs (in seconds; nil means no timeout)
+var timeoutFieldValidation = &cr.StructFieldValidation{
+	StructField: "Timeout",
+	Int64PtrValidation: &cr.Int64PtrValidation{
+		Default:     nil,
+		GreaterThan: pointer.Int64(0),
+	},
+}
+
+// MaxTimeout returns the longest of the timeouts, or nil (i.e. no timeout) if any of them are nil
+func MaxTimeout(timeouts ...*int64) *int64 {
+	var maxTimeout *int64
+	for _, timeout := range timeouts {
+		if timeout == nil {
+			return nil
+		}
+		if maxTimeout == nil || *timeout > *maxTimeout {
+			maxTimeout = timeout
+		}
+	}
+	return maxTimeout
+}
+
+// WorkloadRun describes a run of a data processing, training, or python package workload, to check it against its timeout
+type WorkloadRun struct {
+	StartedAt        time.Time
+	FinishedAt       *time.Time // nil while the workload is running
+	Failed           bool
+	DeadlineExceeded bool // Kubernetes terminated the workload's job for running longer than its activeDeadlineSeconds
+}
+
+// TimedOut returns whether the run exceeded timeout (in seconds, or nil for no timeout) as of now. Runs which finished
+// only time out if they failed; those which exceeded their job's deadline have timed out regardless of their recorded run time
+// (which is measured from their workflow task rather than their job)
+func (run *WorkloadRun) TimedOut(timeout *int64, now time.Time) bool {
+	end := now
+	if run.FinishedAt != nil {
+		if !run.Failed {
+			return false
+		}
+		if run.DeadlineExceeded {
+			return true
+		}
+		end = *run.FinishedAt
+	}
+	if timeout == nil {
+		return false
+	}
+	return end.Sub(run.StartedAt) >= time.Duration(*timeout)*time.Second
+}
+
 func MaxSparkCompute(sparkComputes ...*SparkCompute) *SparkCompute {
 	aggregated := SparkCompute{}
+	var timeouts []*int64
 
 	for _, sparkCompute := range sparkComputes {
 		if sparkCompute.Executors > aggregated.Executors {
@@ -284,13 +345,17 @@ func MaxSparkCompute(sparkComputes ...*SparkCompute) *SparkCompute {
 				aggregated.MemOverheadFactor = sparkCompute.MemOverheadFactor
 			}
 		}
+		timeouts = append(timeouts, sparkCompute.Timeout)
+		aggregated.Retry = MaxRetry(aggregated.Retry, sparkCompute.Retry)
 	}
+	aggregated.Timeout = MaxTimeout(timeouts...)
 
 	return &aggregated
 }
 
 func MaxTFCompute(tfComputes ...*TFCompute) *TFCompute {
 	aggregated := TFCompute{}
+	var timeouts []*int64
 
 	for _, tfCompute := range tfComputes {
 		if tfCompute.CPU != nil {
@@ -308,11 +373,37 @@ func MaxTFCompute(tfComputes ...*TFCompute) *TFCompute {
 				aggregated.GPU = tfCompute.GPU
 			}
 		}
+		timeouts = append(timeouts, tfCompute.Timeout)
+		aggregated.Retry = MaxRetry(aggregated.Retry, tfCompute.Retry)
 	}
+	aggregated.Timeout = MaxTimeout(timeouts...)
 
 	return &aggregated
 }
 
+// ScaleMem returns a copy of sparkCompute whose driver and executor memory (including overhead) is multiplied by factor
+func (sparkCompute *SparkCompute) ScaleMem(factor float64) *SparkCompute {
+	scaled := *sparkCompute
+	if factor <= 1 {
+		return &scaled
+	}
+	scaled.DriverMem = scaleQuantity(sparkCompute.DriverMem, factor)
+	scaled.ExecutorMem = scaleQuantity(sparkCompute.ExecutorMem, factor)
+	scaled.DriverMemOverhead = scaleQuantityPtr(sparkCompute.DriverMemOverhead, factor)
+	scaled.ExecutorMemOverhead = scaleQuantityPtr(sparkCompute.ExecutorMemOverhead, factor)
+	return &scaled
+}
+
+// ScaleMem returns a copy of tfCompute whose memory request (if any) is multiplied by factor
+func (tfCompute *TFCompute) ScaleMem(factor float64) *TFCompute {
+	scaled := *tfCompute
+	if factor <= 1 {
+		return &scaled
+	}
+	scaled.Mem = scaleQuantityPtr(tfCompute.Mem, factor)
+	return &scaled
+}
+
 func (apiCompute *APICompute) Equal(apiCompute2 APICompute) bool {
 	if apiCompute.Replicas != apiCompute2.Replicas {
 		return false
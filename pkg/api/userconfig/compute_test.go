@@ -0,0 +1,81 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+func TestMaxTimeout(t *testing.T) {
+	require.Nil(t, userconfig.MaxTimeout())
+	require.Nil(t, userconfig.MaxTimeout(nil))
+	require.Nil(t, userconfig.MaxTimeout(pointer.Int64(60), nil))
+	require.Equal(t, int64(60), *userconfig.MaxTimeout(pointer.Int64(60)))
+	require.Equal(t, int64(600), *userconfig.MaxTimeout(pointer.Int64(60), pointer.Int64(600), pointer.Int64(300)))
+}
+
+func TestWorkloadRunTimedOut(t *testing.T) {
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour)
+	timeout := pointer.Int64(600)
+
+	// Running
+	require.True(t, (&userconfig.WorkloadRun{StartedAt: start}).TimedOut(timeout, now))
+	require.False(t, (&userconfig.WorkloadRun{StartedAt: start}).TimedOut(pointer.Int64(3601), now))
+	require.False(t, (&userconfig.WorkloadRun{StartedAt: start}).TimedOut(nil, now))
+
+	// Finished successfully
+	finishedAt := start.Add(20 * time.Minute)
+	require.False(t, (&userconfig.WorkloadRun{StartedAt: start, FinishedAt: &finishedAt}).TimedOut(timeout, now))
+
+	// Finished and failed: the run time is measured until it finished
+	run := &userconfig.WorkloadRun{StartedAt: start, FinishedAt: &finishedAt, Failed: true}
+	require.True(t, run.TimedOut(timeout, now))
+	require.False(t, run.TimedOut(pointer.Int64(1800), now))
+	require.False(t, run.TimedOut(nil, now))
+
+	// Finished and failed because the job exceeded its deadline, even though the recorded run is shorter than the timeout
+	run.DeadlineExceeded = true
+	require.True(t, run.TimedOut(pointer.Int64(1800), now))
+	require.True(t, run.TimedOut(nil, now))
+}
+
+func TestMaxComputeTimeout(t *testing.T) {
+	sparkCompute := userconfig.MaxSparkCompute(
+		&userconfig.SparkCompute{Timeout: pointer.Int64(60)},
+		&userconfig.SparkCompute{Timeout: pointer.Int64(120)},
+	)
+	require.Equal(t, int64(120), *sparkCompute.Timeout)
+
+	sparkCompute = userconfig.MaxSparkCompute(
+		&userconfig.SparkCompute{Timeout: pointer.Int64(60)},
+		&userconfig.SparkCompute{},
+	)
+	require.Nil(t, sparkCompute.Timeout)
+
+	tfCompute := userconfig.MaxTFCompute(
+		&userconfig.TFCompute{Timeout: pointer.Int64(3600)},
+		&userconfig.TFCompute{Timeout: pointer.Int64(60)},
+	)
+	require.Equal(t, int64(3600), *tfCompute.Timeout)
+}
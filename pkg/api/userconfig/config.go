@@ -19,6 +19,7 @@ package userconfig
 import (
 	"fmt"
 	"io/ioutil"
+	"sort"
 	"strings"
 
 	"github.com/cortexlabs/cortex/pkg/api/resource"
@@ -136,19 +137,24 @@ func (config *Config) ValidatePartial() error {
 }
 
 func (config *Config) Validate(envName string) error {
+	return errors.FirstError(config.validate(envName)...)
+}
+
+// validate returns all of the errors found by Validate (the first of which is the error which Validate returns)
+func (config *Config) validate(envName string) []error {
 	err := config.ValidatePartial()
 	if err != nil {
-		return err
+		return []error{err}
 	}
 
 	if config.App == nil {
-		return ErrorUndefinedConfig(resource.AppType)
+		return []error{ErrorUndefinedConfig(resource.AppType)}
 	}
 
-	err = config.ValidateColumns()
-	if err != nil {
-		return err
-	}
+	config.setDefaultTimeouts()
+
+	var errs []error
+	errs, _ = errors.AddError(errs, config.ValidateColumns())
 
 	// Check ingested columns match raw columns
 	rawColumnNames := config.RawColumns.Names()
@@ -156,11 +162,12 @@ func (config *Config) Validate(envName string) error {
 		ingestedColumnNames := env.Data.GetIngestedColumns()
 		missingColumns := slices.SubtractStrSlice(rawColumnNames, ingestedColumnNames)
 		if len(missingColumns) > 0 {
-			return errors.Wrap(ErrorRawColumnNotInEnv(env.Name), Identify(config.RawColumns.Get(missingColumns[0])))
+			errs = append(errs, errors.Wrap(ErrorRawColumnNotInEnv(env.Name), Identify(config.RawColumns.Get(missingColumns[0]))))
+			continue
 		}
 		extraColumns := slices.SubtractStrSlice(rawColumnNames, ingestedColumnNames)
 		if len(extraColumns) > 0 {
-			return errors.Wrap(ErrorUndefinedResource(extraColumns[0], resource.RawColumnType), Identify(env), DataKey, SchemaKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(extraColumns[0], resource.RawColumnType), Identify(env), DataKey, SchemaKey))
 		}
 	}
 
@@ -168,26 +175,26 @@ func (config *Config) Validate(envName string) error {
 	columnNames := config.ColumnNames()
 	for _, model := range config.Models {
 		if !slices.HasString(columnNames, model.TargetColumn) {
-			return errors.Wrap(ErrorUndefinedResource(model.TargetColumn, resource.RawColumnType, resource.TransformedColumnType),
-				Identify(model), TargetColumnKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(model.TargetColumn, resource.RawColumnType, resource.TransformedColumnType),
+				Identify(model), TargetColumnKey))
 		}
 		missingColumnNames := slices.SubtractStrSlice(model.FeatureColumns, columnNames)
 		if len(missingColumnNames) > 0 {
-			return errors.Wrap(ErrorUndefinedResource(missingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
-				Identify(model), FeatureColumnsKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(missingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
+				Identify(model), FeatureColumnsKey))
 		}
 
 		missingAggregateNames := slices.SubtractStrSlice(model.Aggregates, config.Aggregates.Names())
 		if len(missingAggregateNames) > 0 {
-			return errors.Wrap(ErrorUndefinedResource(missingAggregateNames[0], resource.AggregateType),
-				Identify(model), AggregatesKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(missingAggregateNames[0], resource.AggregateType),
+				Identify(model), AggregatesKey))
 		}
 
 		// check training columns
 		missingTrainingColumnNames := slices.SubtractStrSlice(model.TrainingColumns, columnNames)
 		if len(missingTrainingColumnNames) > 0 {
-			return errors.Wrap(ErrorUndefinedResource(missingTrainingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
-				Identify(model), TrainingColumnsKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(missingTrainingColumnNames[0], resource.RawColumnType, resource.TransformedColumnType),
+				Identify(model), TrainingColumnsKey))
 		}
 	}
 
@@ -195,8 +202,8 @@ func (config *Config) Validate(envName string) error {
 	modelNames := config.Models.Names()
 	for _, api := range config.APIs {
 		if !slices.HasString(modelNames, api.ModelName) {
-			return errors.Wrap(ErrorUndefinedResource(api.ModelName, resource.ModelType),
-				Identify(api), ModelNameKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(api.ModelName, resource.ModelType),
+				Identify(api), ModelNameKey))
 		}
 	}
 
@@ -204,7 +211,7 @@ func (config *Config) Validate(envName string) error {
 	aggregatorNames := config.Aggregators.Names()
 	for _, aggregate := range config.Aggregates {
 		if !strings.Contains(aggregate.Aggregator, ".") && !slices.HasString(aggregatorNames, aggregate.Aggregator) {
-			return errors.Wrap(ErrorUndefinedResource(aggregate.Aggregator, resource.AggregatorType), Identify(aggregate), AggregatorKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(aggregate.Aggregator, resource.AggregatorType), Identify(aggregate), AggregatorKey))
 		}
 	}
 
@@ -212,7 +219,7 @@ func (config *Config) Validate(envName string) error {
 	transformerNames := config.Transformers.Names()
 	for _, transformedColumn := range config.TransformedColumns {
 		if !strings.Contains(transformedColumn.Transformer, ".") && !slices.HasString(transformerNames, transformedColumn.Transformer) {
-			return errors.Wrap(ErrorUndefinedResource(transformedColumn.Transformer, resource.TransformerType), Identify(transformedColumn), TransformerKey)
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(transformedColumn.Transformer, resource.TransformerType), Identify(transformedColumn), TransformerKey))
 		}
 	}
 
@@ -222,51 +229,85 @@ func (config *Config) Validate(envName string) error {
 		}
 	}
 	if config.Environment == nil {
-		return ErrorUndefinedResource(envName, resource.EnvironmentType)
+		errs = append(errs, ErrorUndefinedResource(envName, resource.EnvironmentType))
 	}
 
-	return nil
+	return errs
+}
+
+// validateImplFiles checks that the implementation files which config references are in files (keyed by path relative to the app's root)
+func (config *Config) validateImplFiles(files map[string][]byte) []error {
+	var errs []error
+	for _, aggregator := range config.Aggregators {
+		if _, ok := files[aggregator.Path]; !ok {
+			errs = append(errs, errors.Wrap(ErrorImplDoesNotExist(aggregator.Path), Identify(aggregator), PathKey))
+		}
+	}
+	for _, transformer := range config.Transformers {
+		if _, ok := files[transformer.Path]; !ok {
+			errs = append(errs, errors.Wrap(ErrorImplDoesNotExist(transformer.Path), Identify(transformer), PathKey))
+		}
+	}
+	for _, model := range config.Models {
+		if _, ok := files[model.Path]; !ok {
+			errs = append(errs, errors.Wrap(ErrorImplDoesNotExist(model.Path), Identify(model), PathKey))
+		}
+	}
+	return errs
 }
 
 func (config *Config) MergeBytes(configBytes []byte, filePath string, emb *Embed, template *Template) (*Config, error) {
+	config, errs := config.mergeBytes(configBytes, filePath, emb, template)
+	if errors.HasErrors(errs) {
+		return nil, errors.FirstError(errs...)
+	}
+	return config, nil
+}
+
+// mergeBytes returns all of the errors found by MergeBytes (the first of which is the error which MergeBytes returns)
+func (config *Config) mergeBytes(configBytes []byte, filePath string, emb *Embed, template *Template) (*Config, []error) {
 	sliceData, err := cr.ReadYAMLBytes(configBytes)
 	if err != nil {
 		if emb == nil {
-			return nil, errors.Wrap(err, filePath)
+			return nil, []error{errors.Wrap(err, filePath)}
 		}
-		return nil, errors.Wrap(err, Identify(template), YAMLKey)
+		return nil, []error{errors.Wrap(err, Identify(template), YAMLKey)}
 	}
 
-	subConfig, err := newPartial(sliceData, filePath, emb, template)
-	if err != nil {
-		return nil, err
+	subConfig, errs := newPartial(sliceData, filePath, emb, template)
+	if errors.HasErrors(errs) {
+		return nil, errs
 	}
 
 	err = mergeConfigs(config, subConfig)
 	if err != nil {
-		return nil, err
+		return nil, []error{err}
 	}
 	return config, nil
 }
 
-func newPartial(configData interface{}, filePath string, emb *Embed, template *Template) (*Config, error) {
+// newPartial parses a config file; each resource's error is returned, in order
+func newPartial(configData interface{}, filePath string, emb *Embed, template *Template) (*Config, []error) {
 	configDataSlice, ok := cast.InterfaceToStrInterfaceMapSlice(configData)
 	if !ok {
 		if emb == nil {
-			return nil, errors.Wrap(ErrorMalformedConfig(), filePath)
+			return nil, []error{errors.Wrap(ErrorMalformedConfig(), filePath)}
 		}
-		return nil, errors.Wrap(ErrorMalformedConfig(), Identify(template), YAMLKey)
+		return nil, []error{errors.Wrap(ErrorMalformedConfig(), Identify(template), YAMLKey)}
 	}
 
 	config := &Config{}
+	var resourceErrs []error
 	for i, data := range configDataSlice {
 		kindInterface, ok := data[KindKey]
 		if !ok {
-			return nil, errors.Wrap(configreader.ErrorMustBeDefined(), identify(filePath, resource.UnknownType, "", i, emb), KindKey)
+			resourceErrs = append(resourceErrs, errors.Wrap(configreader.ErrorMustBeDefined(), identify(filePath, resource.UnknownType, "", i, emb), KindKey))
+			continue
 		}
 		kindStr, ok := kindInterface.(string)
 		if !ok {
-			return nil, errors.Wrap(configreader.ErrorInvalidPrimitiveType(kindInterface, s.PrimTypeString), identify(filePath, resource.UnknownType, "", i, emb), KindKey)
+			resourceErrs = append(resourceErrs, errors.Wrap(configreader.ErrorInvalidPrimitiveType(kindInterface, s.PrimTypeString), identify(filePath, resource.UnknownType, "", i, emb), KindKey))
+			continue
 		}
 
 		var errs []error
@@ -353,12 +394,14 @@ func newPartial(configData interface{}, filePath string, emb *Embed, template *T
 				}
 			}
 		default:
-			return nil, errors.Wrap(resource.ErrorUnknownKind(kindStr), identify(filePath, resource.UnknownType, "", i, emb))
+			resourceErrs = append(resourceErrs, errors.Wrap(resource.ErrorUnknownKind(kindStr), identify(filePath, resource.UnknownType, "", i, emb)))
+			continue
 		}
 
 		if errors.HasErrors(errs) {
 			name, _ := data[NameKey].(string)
-			return nil, errors.Wrap(errors.FirstError(errs...), identify(filePath, resourceType, name, i, emb))
+			resourceErrs = append(resourceErrs, errors.Wrap(errors.FirstError(errs...), identify(filePath, resourceType, name, i, emb)))
+			continue
 		}
 
 		if newResource != nil {
@@ -368,9 +411,13 @@ func newPartial(configData interface{}, filePath string, emb *Embed, template *T
 		}
 	}
 
+	if len(resourceErrs) > 0 {
+		return nil, resourceErrs
+	}
+
 	err := config.ValidatePartial()
 	if err != nil {
-		return nil, err
+		return nil, []error{err}
 	}
 
 	return config, nil
@@ -381,49 +428,124 @@ func NewPartialPath(filePath string) (*Config, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, filePath, ErrorReadConfig().Error())
 	}
+	return NewPartialBytes(configBytes, filePath)
+}
 
+func NewPartialBytes(configBytes []byte, filePath string) (*Config, error) {
 	configData, err := cr.ReadYAMLBytes(configBytes)
 	if err != nil {
 		return nil, errors.Wrap(err, filePath, ErrorParseConfig().Error())
 	}
-	return newPartial(configData, filePath, nil, nil)
+	config, errs := newPartial(configData, filePath, nil, nil)
+	if errors.HasErrors(errs) {
+		return nil, errors.FirstError(errs...)
+	}
+	return config, nil
+}
+
+// setDefaultTimeouts applies the app's timeout to the data processing and training computes which don't set their own
+func (config *Config) setDefaultTimeouts() {
+	if config.App.Timeout == nil {
+		return
+	}
+
+	var sparkComputes []*SparkCompute
+	for _, rawColumn := range config.RawColumns {
+		sparkComputes = append(sparkComputes, rawColumn.GetCompute())
+	}
+	for _, aggregate := range config.Aggregates {
+		sparkComputes = append(sparkComputes, aggregate.Compute)
+	}
+	for _, transformedColumn := range config.TransformedColumns {
+		sparkComputes = append(sparkComputes, transformedColumn.Compute)
+	}
+	for _, sparkCompute := range sparkComputes {
+		if sparkCompute != nil && sparkCompute.Timeout == nil {
+			sparkCompute.Timeout = config.App.Timeout
+		}
+	}
+
+	for _, model := range config.Models {
+		if model.Compute != nil && model.Compute.Timeout == nil {
+			model.Compute.Timeout = config.App.Timeout
+		}
+	}
 }
 
 func New(configs map[string][]byte, envName string) (*Config, error) {
-	var err error
+	config, errs := parse(configs, envName)
+	if errors.HasErrors(errs) {
+		return nil, errors.FirstError(errs...)
+	}
+	return config, nil
+}
+
+// Check parses and validates configs like New, and also checks that the implementation files which they reference
+// are in configs, and that resources' inputs match their aggregators and transformers (see CheckInputs). Rather than
+// stopping at the first error, it returns all of the errors it finds.
+func Check(configs map[string][]byte, envName string, builtinAggregators map[string]*Aggregator, builtinTransformers map[string]*Transformer) []error {
+	config, errs := parse(configs, envName)
+	if config == nil {
+		return errs
+	}
+	if len(errs) == 0 {
+		errs = config.CheckInputs(builtinAggregators, builtinTransformers)
+	}
+	return append(errs, config.validateImplFiles(configs)...)
+}
+
+// parse merges the config files in configs, populates their embedded templates, and validates the result;
+// each stage's errors are returned without running the stages which depend on it. The config is returned
+// (along with any validation errors) if it was parsed successfully.
+func parse(configs map[string][]byte, envName string) (*Config, []error) {
+	filePaths := make([]string, 0, len(configs))
+	for filePath := range configs {
+		if files.IsFilePathYAML(filePath) {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	sort.Strings(filePaths)
+
+	var errs []error
 	config := &Config{}
-	for filePath, configBytes := range configs {
-		if !files.IsFilePathYAML(filePath) {
+	for _, filePath := range filePaths {
+		mergedConfig, fileErrs := config.mergeBytes(configs[filePath], filePath, nil, nil)
+		if errors.HasErrors(fileErrs) {
+			errs = append(errs, fileErrs...)
 			continue
 		}
-		config, err = config.MergeBytes(configBytes, filePath, nil, nil)
-		if err != nil {
-			return nil, err
-		}
+		config = mergedConfig
+	}
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	templates := config.Templates.Map()
 	for _, emb := range config.Embeds {
 		template, ok := templates[emb.Template]
 		if !ok {
-			return nil, errors.Wrap(ErrorUndefinedResource(emb.Template, resource.TemplateType), Identify(emb))
+			errs = append(errs, errors.Wrap(ErrorUndefinedResource(emb.Template, resource.TemplateType), Identify(emb)))
+			continue
 		}
 
 		populatedTemplate, err := template.Populate(emb)
 		if err != nil {
-			return nil, errors.Wrap(err, Identify(emb))
+			errs = append(errs, errors.Wrap(err, Identify(emb)))
+			continue
 		}
 
-		config, err = config.MergeBytes([]byte(populatedTemplate), emb.FilePath, emb, template)
-		if err != nil {
-			return nil, err
+		mergedConfig, embErrs := config.mergeBytes([]byte(populatedTemplate), emb.FilePath, emb, template)
+		if errors.HasErrors(embErrs) {
+			errs = append(errs, embErrs...)
+			continue
 		}
+		config = mergedConfig
 	}
-
-	if err := config.Validate(envName); err != nil {
-		return nil, err
+	if len(errs) > 0 {
+		return nil, errs
 	}
-	return config, nil
+
+	return config, config.validate(envName)
 }
 
 func ReadAppName(filePath string, relativePath string) (string, error) {
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+)
+
+func testConfigs() map[string][]byte {
+	return map[string][]byte{
+		"app.yaml": []byte(`
+- kind: app
+  name: test
+`),
+		"resources/environments.yaml": []byte(`
+- kind: environment
+  name: dev
+  data:
+    type: csv
+    path: s3a://bucket/data.csv
+    schema: [feature, label]
+`),
+		"resources/raw_columns.yaml": []byte(`
+- kind: raw_column
+  name: feature
+  type: FLOAT_COLUMN
+
+- kind: raw_column
+  name: label
+  type: INT_COLUMN
+`),
+		"resources/models.yaml": []byte(`
+- kind: model
+  name: dnn
+  type: classification
+  target_column: label
+  feature_columns: [feature]
+`),
+		"resources/apis.yaml": []byte(`
+- kind: api
+  name: classifier
+  model_name: dnn
+`),
+		"implementations/models/dnn.py": []byte("def create_estimator(run_config, model_config):\n    pass\n"),
+	}
+}
+
+func TestCheck(t *testing.T) {
+	configs := testConfigs()
+	require.Empty(t, userconfig.Check(configs, "dev", nil, nil))
+
+	config, err := userconfig.New(configs, "dev")
+	require.NoError(t, err)
+	require.Equal(t, "test", config.App.Name)
+
+	errs := userconfig.Check(configs, "prod", nil, nil)
+	require.Len(t, errs, 1)
+
+	// Implementation files are only checked by Check
+	delete(configs, "implementations/models/dnn.py")
+	_, err = userconfig.New(configs, "dev")
+	require.NoError(t, err)
+	errs = userconfig.Check(configs, "dev", nil, nil)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "implementations/models/dnn.py")
+}
+
+func TestCheckAllErrors(t *testing.T) {
+	// Each file's parsing errors are reported
+	configs := testConfigs()
+	configs["resources/raw_columns.yaml"] = []byte(`
+- kind: raw_column
+  name: feature
+  type: FLOAT_COLUMN
+  unknown_key: 1
+
+- kind: raw_column
+  name: label
+  type: NOT_A_TYPE
+`)
+	configs["resources/apis.yaml"] = []byte(`
+- kind: not_a_kind
+  name: classifier
+`)
+	errs := userconfig.Check(configs, "dev", nil, nil)
+	require.Len(t, errs, 3)
+
+	_, err := userconfig.New(configs, "dev")
+	require.Equal(t, errs[0].Error(), err.Error())
+
+	// Cross-resource errors are reported once the files parse
+	configs = testConfigs()
+	configs["resources/apis.yaml"] = []byte(`
+- kind: api
+  name: classifier
+  model_name: missing
+
+- kind: api
+  name: other
+  model_name: also_missing
+`)
+	configs["resources/models.yaml"] = []byte(`
+- kind: model
+  name: dnn
+  type: classification
+  target_column: missing_label
+  feature_columns: [feature]
+`)
+	delete(configs, "implementations/models/dnn.py")
+	errs = userconfig.Check(configs, "dev", nil, nil)
+	require.Len(t, errs, 4)
+
+	_, err = userconfig.New(configs, "dev")
+	require.Equal(t, errs[0].Error(), err.Error())
+}
@@ -101,6 +101,10 @@ func (constant *Constant) GetResourceType() resource.Type {
 	return resource.ConstantType
 }
 
+func (constant *Constant) GetTags() Tags {
+	return constant.Tags
+}
+
 func (constants Constants) Names() []string {
 	names := make([]string, len(constants))
 	for i, constant := range constants {
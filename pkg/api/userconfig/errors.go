@@ -58,6 +58,7 @@ const (
 	ErrK8sQuantityMustBeInt
 	ErrRegressionTargetType
 	ErrClassificationTargetType
+	ErrImplDoesNotExist
 )
 
 var errorKinds = []string{
@@ -90,9 +91,10 @@ var errorKinds = []string{
 	"err_k8s_quantity_must_be_int",
 	"err_regression_target_type",
 	"err_classification_target_type",
+	"err_impl_does_not_exist",
 }
 
-var _ = [1]int{}[int(ErrClassificationTargetType)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrImplDoesNotExist)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -382,3 +384,10 @@ func ErrorClassificationTargetType() error {
 		message: "classification models can only predict integer target values (i.e. {0, 1, ..., num_classes-1})",
 	}
 }
+
+func ErrorImplDoesNotExist(path string) error {
+	return Error{
+		Kind:    ErrImplDoesNotExist,
+		message: fmt.Sprintf("%s: implementation file does not exist", path),
+	}
+}
@@ -17,7 +17,13 @@ limitations under the License.
 package userconfig
 
 import (
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/cast"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 )
 
 type Inputs struct {
@@ -82,3 +88,151 @@ var inputValuesFieldValidation = &cr.StructFieldValidation{
 		},
 	},
 }
+
+// CheckInputs checks that the columns and args of the config's aggregates and transformed columns match the
+// signatures of their aggregators and transformers. builtinAggregators and builtinTransformers are keyed by the
+// names which resources use for them (e.g. "cortex.mean").
+func (config *Config) CheckInputs(builtinAggregators map[string]*Aggregator, builtinTransformers map[string]*Transformer) []error {
+	var errs []error
+
+	aggregateTypes := make(map[string]interface{}, len(config.Aggregates))
+	for _, aggregate := range config.Aggregates {
+		aggregator := builtinAggregators[aggregate.Aggregator]
+		if aggregator == nil {
+			aggregator = config.Aggregators.Get(aggregate.Aggregator)
+		}
+		if aggregator == nil {
+			errs = append(errs, errors.Wrap(undefinedFunction(aggregate.Aggregator, resource.AggregatorType), Identify(aggregate), AggregatorKey))
+			continue
+		}
+		aggregateTypes[aggregate.Name] = aggregator.OutputType
+
+		if err := config.checkInputs(aggregate.Inputs, aggregator.Inputs, nil); err != nil {
+			errs = append(errs, errors.Wrap(err, Identify(aggregate), InputsKey))
+		}
+	}
+
+	for _, transformedColumn := range config.TransformedColumns {
+		transformer := builtinTransformers[transformedColumn.Transformer]
+		if transformer == nil {
+			transformer = config.Transformers.Get(transformedColumn.Transformer)
+		}
+		if transformer == nil {
+			errs = append(errs, errors.Wrap(undefinedFunction(transformedColumn.Transformer, resource.TransformerType), Identify(transformedColumn), TransformerKey))
+			continue
+		}
+
+		if err := config.checkInputs(transformedColumn.Inputs, transformer.Inputs, aggregateTypes); err != nil {
+			errs = append(errs, errors.Wrap(err, Identify(transformedColumn), InputsKey))
+		}
+	}
+
+	return errs
+}
+
+func undefinedFunction(name string, resourceType resource.Type) error {
+	if strings.HasPrefix(name, "cortex.") {
+		return ErrorUndefinedResourceBuiltin(name, resourceType)
+	}
+	return ErrorUndefinedResource(name, resourceType)
+}
+
+// checkInputs checks inputs against the signature's inputs; args may refer to constants, or to aggregates if
+// aggregateTypes (the aggregates' output types) isn't nil
+func (config *Config) checkInputs(inputs *Inputs, signature *Inputs, aggregateTypes map[string]interface{}) error {
+	columnRuntimeTypes, err := ColumnRuntimeTypes(inputs.Columns, func(name string) (ColumnType, bool) {
+		rawColumn := config.RawColumns.Get(name)
+		if rawColumn == nil {
+			return UnknownColumnType, false
+		}
+		return rawColumn.GetType(), true
+	})
+	if err != nil {
+		return errors.Wrap(err, ColumnsKey)
+	}
+	if err := CheckColumnRuntimeTypesMatch(columnRuntimeTypes, signature.Columns); err != nil {
+		return errors.Wrap(err, ColumnsKey)
+	}
+
+	argTypes := make(map[string]interface{}, len(inputs.Args))
+	for argName, argVal := range inputs.Args {
+		if argValStr, ok := argVal.(string); ok && !s.HasPrefixAndSuffix(argValStr, "\"") {
+			argType, err := config.valueResourceType(argValStr, aggregateTypes)
+			if err != nil {
+				return errors.Wrap(err, ArgsKey, argName)
+			}
+			argTypes[argName] = argType
+			continue
+		}
+
+		// Literal values become constants of their args' types when the context is built
+		argType, ok := signature.Args[argName]
+		if !ok {
+			return errors.Wrap(cr.ErrorUnsupportedKey(argName), ArgsKey)
+		}
+		if argValStr, ok := argVal.(string); ok {
+			argVal = s.TrimPrefixAndSuffix(argValStr, "\"")
+		}
+		if _, err := CastValue(argVal, argType); err != nil {
+			return errors.Wrap(err, ArgsKey, argName)
+		}
+		argTypes[argName] = argType
+	}
+
+	return errors.Wrap(CheckArgRuntimeTypesMatch(argTypes, signature.Args), ArgsKey)
+}
+
+// valueResourceType returns the type of the named constant, or of the named aggregate if aggregateTypes isn't nil
+func (config *Config) valueResourceType(name string, aggregateTypes map[string]interface{}) (interface{}, error) {
+	for _, constant := range config.Constants {
+		if constant.Name == name {
+			return constant.Type, nil
+		}
+	}
+	if aggregateTypes == nil {
+		return nil, ErrorUndefinedResource(name, resource.ConstantType)
+	}
+	if aggregateType, ok := aggregateTypes[name]; ok {
+		return aggregateType, nil
+	}
+	return nil, ErrorUndefinedResource(name, resource.ConstantType, resource.AggregateType)
+}
+
+// ColumnRuntimeTypes returns the types of the raw columns which columnInputValues refer to (keyed by input name);
+// getColumnType returns the type of the named raw column, or false if there is none
+func ColumnRuntimeTypes(columnInputValues map[string]interface{}, getColumnType func(string) (ColumnType, bool)) (map[string]interface{}, error) {
+	err := ValidateColumnInputValues(columnInputValues)
+	if err != nil {
+		return nil, err
+	}
+
+	columnRuntimeTypes := make(map[string]interface{}, len(columnInputValues))
+
+	for inputName, columnInputValue := range columnInputValues {
+		if rawColumnName, ok := columnInputValue.(string); ok {
+			columnType, ok := getColumnType(rawColumnName)
+			if !ok {
+				return nil, errors.Wrap(ErrorUndefinedResource(rawColumnName, resource.RawColumnType), inputName)
+			}
+			columnRuntimeTypes[inputName] = columnType
+			continue
+		}
+
+		if rawColumnNames, ok := cast.InterfaceToStrSlice(columnInputValue); ok {
+			rawColumnTypes := make([]ColumnType, len(rawColumnNames))
+			for i, rawColumnName := range rawColumnNames {
+				columnType, ok := getColumnType(rawColumnName)
+				if !ok {
+					return nil, errors.Wrap(ErrorUndefinedResource(rawColumnName, resource.RawColumnType), inputName, s.Index(i))
+				}
+				rawColumnTypes[i] = columnType
+			}
+			columnRuntimeTypes[inputName] = rawColumnTypes
+			continue
+		}
+
+		return nil, errors.Wrap(cr.ErrorInvalidPrimitiveType(columnInputValue, s.PrimTypeString, s.PrimTypeStringList), inputName) // unexpected
+	}
+
+	return columnRuntimeTypes, nil
+}
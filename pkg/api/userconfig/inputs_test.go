@@ -0,0 +1,194 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/aggregators"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/transformers"
+)
+
+func builtinSignatures(t *testing.T) (map[string]*userconfig.Aggregator, map[string]*userconfig.Transformer) {
+	aggregatorsConfig, err := userconfig.NewPartialBytes(aggregators.ConfigYAML, "aggregators.yaml")
+	require.NoError(t, err)
+	builtinAggregators := make(map[string]*userconfig.Aggregator)
+	for _, aggregator := range aggregatorsConfig.Aggregators {
+		builtinAggregators["cortex."+aggregator.Name] = aggregator
+	}
+
+	transformersConfig, err := userconfig.NewPartialBytes(transformers.ConfigYAML, "transformers.yaml")
+	require.NoError(t, err)
+	builtinTransformers := make(map[string]*userconfig.Transformer)
+	for _, transformer := range transformersConfig.Transformers {
+		builtinTransformers["cortex."+transformer.Name] = transformer
+	}
+
+	return builtinAggregators, builtinTransformers
+}
+
+func testInputsConfigs(resources string) map[string][]byte {
+	configs := testConfigs()
+	configs["resources/environments.yaml"] = []byte(`
+- kind: environment
+  name: dev
+  data:
+    type: csv
+    path: s3a://bucket/data.csv
+    schema: [feature, label, category]
+`)
+	configs["resources/raw_columns.yaml"] = []byte(`
+- kind: raw_column
+  name: feature
+  type: FLOAT_COLUMN
+
+- kind: raw_column
+  name: label
+  type: INT_COLUMN
+
+- kind: raw_column
+  name: category
+  type: STRING_COLUMN
+`)
+	configs["resources/resources.yaml"] = []byte(resources)
+	return configs
+}
+
+func TestCheckInputs(t *testing.T) {
+	builtinAggregators, builtinTransformers := builtinSignatures(t)
+
+	configs := testInputsConfigs(`
+- kind: constant
+  name: one
+  type: FLOAT
+  value: 1
+
+- kind: aggregate
+  name: feature_mean
+  aggregator: cortex.mean
+  inputs:
+    columns:
+      col: feature
+
+- kind: transformed_column
+  name: feature_normalized
+  transformer: cortex.normalize
+  inputs:
+    columns:
+      num: feature
+    args:
+      mean: feature_mean
+      stddev: one
+
+- kind: transformed_column
+  name: category_index
+  transformer: cortex.index_string
+  inputs:
+    columns:
+      text: category
+    args:
+      index: ["a", "b"]
+`)
+	require.Empty(t, userconfig.Check(configs, "dev", builtinAggregators, builtinTransformers))
+
+	for _, resources := range []string{
+		// Undefined built-in
+		`
+- kind: aggregate
+  name: feature_mean
+  aggregator: cortex.not_an_aggregator
+  inputs:
+    columns:
+      col: feature
+`,
+		// Column type mismatch
+		`
+- kind: aggregate
+  name: category_mean
+  aggregator: cortex.mean
+  inputs:
+    columns:
+      col: category
+`,
+		// Undefined raw column
+		`
+- kind: aggregate
+  name: feature_mean
+  aggregator: cortex.mean
+  inputs:
+    columns:
+      col: missing
+`,
+		// Missing arg
+		`
+- kind: transformed_column
+  name: feature_normalized
+  transformer: cortex.normalize
+  inputs:
+    columns:
+      num: feature
+    args:
+      mean: 0
+`,
+		// Literal arg of the wrong type
+		`
+- kind: transformed_column
+  name: category_index
+  transformer: cortex.index_string
+  inputs:
+    columns:
+      text: category
+    args:
+      index: 5
+`,
+		// Unsupported arg
+		`
+- kind: aggregate
+  name: feature_mean
+  aggregator: cortex.mean
+  inputs:
+    columns:
+      col: feature
+    args:
+      extra: 1
+`,
+		// Undefined constant (aggregates' args can't refer to other aggregates)
+		`
+- kind: aggregate
+  name: feature_mean
+  aggregator: cortex.mean
+  inputs:
+    columns:
+      col: feature
+
+- kind: aggregate
+  name: feature_count
+  aggregator: cortex.approx_count_distinct
+  inputs:
+    columns:
+      col: feature
+    args:
+      rsd: feature_mean
+`,
+	} {
+		errs := userconfig.Check(testInputsConfigs(resources), "dev", builtinAggregators, builtinTransformers)
+		require.Len(t, errs, 1, resources)
+	}
+}
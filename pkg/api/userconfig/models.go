@@ -372,6 +372,10 @@ func (model *Model) GetResourceType() resource.Type {
 	return resource.ModelType
 }
 
+func (model *Model) GetTags() Tags {
+	return model.Tags
+}
+
 func (models Models) Names() []string {
 	names := make([]string, len(models))
 	for i, model := range models {
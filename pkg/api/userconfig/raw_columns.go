@@ -246,14 +246,26 @@ func (column *RawIntColumn) GetResourceType() resource.Type {
 	return resource.RawColumnType
 }
 
+func (column *RawIntColumn) GetTags() Tags {
+	return column.Tags
+}
+
 func (column *RawFloatColumn) GetResourceType() resource.Type {
 	return resource.RawColumnType
 }
 
+func (column *RawFloatColumn) GetTags() Tags {
+	return column.Tags
+}
+
 func (column *RawStringColumn) GetResourceType() resource.Type {
 	return resource.RawColumnType
 }
 
+func (column *RawStringColumn) GetTags() Tags {
+	return column.Tags
+}
+
 func (column *RawIntColumn) IsRaw() bool {
 	return true
 }
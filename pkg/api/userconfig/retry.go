@@ -0,0 +1,122 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig
+
+import (
+	"math"
+
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+type Retry struct {
+	MaxAttempts  int32   `json:"max_attempts" yaml:"max_attempts"`
+	Backoff      int32   `json:"backoff" yaml:"backoff"`
+	RetryOOM     bool    `json:"retry_oom" yaml:"retry_oom"`
+	OOMMemFactor float64 `json:"oom_mem_factor" yaml:"oom_mem_factor"`
+}
+
+var retryFieldValidation = &cr.StructFieldValidation{
+	StructField: "Retry",
+	StructValidation: &cr.StructValidation{
+		StructFieldValidations: []*cr.StructFieldValidation{
+			{
+				StructField: "MaxAttempts",
+				Int32Validation: &cr.Int32Validation{
+					Default:     1,
+					GreaterThan: pointer.Int32(0),
+				},
+			},
+			{
+				StructField: "Backoff",
+				Int32Validation: &cr.Int32Validation{
+					Default:              30,
+					GreaterThanOrEqualTo: pointer.Int32(0),
+				},
+			},
+			{
+				StructField: "RetryOOM",
+				BoolValidation: &cr.BoolValidation{
+					Default: true,
+				},
+			},
+			{
+				StructField: "OOMMemFactor",
+				Float64Validation: &cr.Float64Validation{
+					Default:              1.5,
+					GreaterThanOrEqualTo: pointer.Float64(1),
+				},
+			},
+		},
+	},
+}
+
+// BackoffSeconds returns how long to wait before retrying after the given (1-indexed) attempt failed;
+// the backoff doubles after each attempt
+func (retry *Retry) BackoffSeconds(attempt int32) int64 {
+	if attempt < 1 {
+		attempt = 1
+	}
+	return int64(retry.Backoff) << uint(attempt-1)
+}
+
+// MaxRetry merges retry policies, using the most permissive value of each field
+func MaxRetry(retries ...*Retry) *Retry {
+	var aggregated *Retry
+
+	for _, retry := range retries {
+		if retry == nil {
+			continue
+		}
+		if aggregated == nil {
+			retryCopy := *retry
+			aggregated = &retryCopy
+			continue
+		}
+		if retry.MaxAttempts > aggregated.MaxAttempts {
+			aggregated.MaxAttempts = retry.MaxAttempts
+		}
+		if retry.Backoff > aggregated.Backoff {
+			aggregated.Backoff = retry.Backoff
+		}
+		if retry.RetryOOM {
+			aggregated.RetryOOM = true
+		}
+		if retry.OOMMemFactor > aggregated.OOMMemFactor {
+			aggregated.OOMMemFactor = retry.OOMMemFactor
+		}
+	}
+
+	return aggregated
+}
+
+func scaleQuantity(quantity Quantity, factor float64) Quantity {
+	scaled := k8sresource.NewQuantity(int64(math.Ceil(float64(quantity.Value())*factor)), quantity.Format)
+	return Quantity{
+		Quantity: *scaled,
+	}
+}
+
+func scaleQuantityPtr(quantity *Quantity, factor float64) *Quantity {
+	if quantity == nil {
+		return nil
+	}
+	scaled := scaleQuantity(*quantity, factor)
+	return &scaled
+}
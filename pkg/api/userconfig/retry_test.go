@@ -0,0 +1,77 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userconfig_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+)
+
+func TestMaxRetry(t *testing.T) {
+	require.Nil(t, userconfig.MaxRetry())
+	require.Nil(t, userconfig.MaxRetry(nil, nil))
+
+	retry1 := &userconfig.Retry{MaxAttempts: 3, Backoff: 10, RetryOOM: false, OOMMemFactor: 2}
+	retry2 := &userconfig.Retry{MaxAttempts: 1, Backoff: 60, RetryOOM: true, OOMMemFactor: 1.5}
+
+	require.Equal(t, retry1, userconfig.MaxRetry(nil, retry1))
+	require.Equal(t, &userconfig.Retry{MaxAttempts: 3, Backoff: 60, RetryOOM: true, OOMMemFactor: 2}, userconfig.MaxRetry(retry1, retry2))
+
+	// The inputs are not modified
+	require.Equal(t, int32(10), retry1.Backoff)
+	require.Equal(t, int32(1), retry2.MaxAttempts)
+}
+
+func TestBackoffSeconds(t *testing.T) {
+	retry := &userconfig.Retry{Backoff: 30}
+	require.Equal(t, int64(30), retry.BackoffSeconds(0))
+	require.Equal(t, int64(30), retry.BackoffSeconds(1))
+	require.Equal(t, int64(60), retry.BackoffSeconds(2))
+	require.Equal(t, int64(120), retry.BackoffSeconds(3))
+}
+
+func TestScaleMem(t *testing.T) {
+	quantity := func(str string) userconfig.Quantity {
+		return userconfig.Quantity{Quantity: k8sresource.MustParse(str), UserString: str}
+	}
+
+	overhead := quantity("100Mi")
+	sparkCompute := &userconfig.SparkCompute{
+		DriverMem:         quantity("1Gi"),
+		ExecutorMem:       quantity("500Mi"),
+		DriverMemOverhead: &overhead,
+	}
+
+	scaled := sparkCompute.ScaleMem(2)
+	require.Equal(t, 0, scaled.DriverMem.Cmp(k8sresource.MustParse("2Gi")))
+	require.Equal(t, 0, scaled.ExecutorMem.Cmp(k8sresource.MustParse("1000Mi")))
+	require.Equal(t, 0, scaled.DriverMemOverhead.Cmp(k8sresource.MustParse("200Mi")))
+	require.Nil(t, scaled.ExecutorMemOverhead)
+	require.Equal(t, 0, sparkCompute.DriverMem.Cmp(k8sresource.MustParse("1Gi")))
+
+	unscaled := sparkCompute.ScaleMem(1)
+	require.Equal(t, "1Gi", unscaled.DriverMem.String())
+
+	mem := quantity("1G")
+	tfCompute := &userconfig.TFCompute{Mem: &mem}
+	require.Equal(t, 0, tfCompute.ScaleMem(1.5).Mem.Cmp(k8sresource.MustParse("1.5G")))
+	require.Nil(t, (&userconfig.TFCompute{}).ScaleMem(1.5).Mem)
+}
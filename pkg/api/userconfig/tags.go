@@ -23,6 +23,11 @@ import (
 
 type Tags map[string]interface{}
 
+// Tagged is implemented by the resources which support tags
+type Tagged interface {
+	GetTags() Tags
+}
+
 var tagsFieldValidation = &cr.StructFieldValidation{
 	Key:         "tags",
 	StructField: "Tags",
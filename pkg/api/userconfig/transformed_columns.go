@@ -79,6 +79,10 @@ func (column *TransformedColumn) GetResourceType() resource.Type {
 	return resource.TransformedColumnType
 }
 
+func (column *TransformedColumn) GetTags() Tags {
+	return column.Tags
+}
+
 func (columns TransformedColumns) Names() []string {
 	names := make([]string, len(columns))
 	for i, transformedColumn := range columns {
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"strings"
+)
+
+const (
+	TypeAWS   = "aws"
+	TypeToken = "token"
+	TypeHMAC  = "hmac"
+)
+
+var Types = []string{
+	TypeAWS,
+	TypeToken,
+	TypeHMAC,
+}
+
+const (
+	AWSScheme    = "CortexAWS"
+	BearerScheme = "Bearer"
+)
+
+// Authenticator verifies the credentials in an Authorization header and returns the caller's identity
+type Authenticator interface {
+	Authenticate(authHeader string) (string, error)
+}
+
+// parseAuthHeader returns the credentials from a header of the form "<scheme> <credentials>"
+func parseAuthHeader(authHeader string, scheme string) (string, error) {
+	if authHeader == "" {
+		return "", ErrorAuthHeaderMissing()
+	}
+	if !strings.HasPrefix(authHeader, scheme+" ") {
+		return "", ErrorAuthHeaderMalformed(scheme)
+	}
+	credentials := strings.TrimSpace(authHeader[len(scheme)+1:])
+	if credentials == "" {
+		return "", ErrorAuthHeaderMalformed(scheme)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/auth"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+func TestAWSAuthenticator(t *testing.T) {
+	authenticator := auth.NewAWSAuthenticator(func(accessKeyID string, secretAccessKey string) (bool, error) {
+		return accessKeyID == "key" && secretAccessKey == "secret", nil
+	})
+
+	identity, err := authenticator.Authenticate("CortexAWS key|secret")
+	require.NoError(t, err)
+	require.Equal(t, "key", identity)
+
+	_, err = authenticator.Authenticate("CortexAWS key|wrong")
+	require.Equal(t, "err_aws_auth_forbidden", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("")
+	require.Equal(t, "err_auth_header_missing", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("CortexAWS key")
+	require.Equal(t, "err_auth_header_malformed", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("Bearer token")
+	require.Equal(t, "err_auth_header_malformed", errors.Kind(err))
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = auth.NewTokenAuthenticator(dir)
+	require.Equal(t, "err_no_tokens", errors.Kind(err))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "alice"), []byte("alice-token\n"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bob"), []byte("bob-token"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden-token"), 0600))
+
+	authenticator, err := auth.NewTokenAuthenticator(dir)
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate("Bearer alice-token")
+	require.NoError(t, err)
+	require.Equal(t, "alice", identity)
+
+	identity, err = authenticator.Authenticate("Bearer bob-token")
+	require.NoError(t, err)
+	require.Equal(t, "bob", identity)
+
+	_, err = authenticator.Authenticate("Bearer hidden-token")
+	require.Equal(t, "err_invalid_token", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("Bearer ")
+	require.Equal(t, "err_auth_header_malformed", errors.Kind(err))
+}
+
+func TestHMACAuthenticator(t *testing.T) {
+	key := []byte("signing-key")
+	authenticator, err := auth.NewHMACAuthenticator(key)
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate("Bearer " + auth.SignHMACToken(key, "alice", nil))
+	require.NoError(t, err)
+	require.Equal(t, "alice", identity)
+
+	future := time.Now().Add(time.Hour)
+	identity, err = authenticator.Authenticate("Bearer " + auth.SignHMACToken(key, "bob", &future))
+	require.NoError(t, err)
+	require.Equal(t, "bob", identity)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = authenticator.Authenticate("Bearer " + auth.SignHMACToken(key, "bob", &past))
+	require.Equal(t, "err_token_expired", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("Bearer " + auth.SignHMACToken([]byte("other-key"), "alice", nil))
+	require.Equal(t, "err_invalid_token", errors.Kind(err))
+
+	_, err = authenticator.Authenticate("Bearer not-a-token")
+	require.Equal(t, "err_invalid_token", errors.Kind(err))
+
+	_, err = auth.NewHMACAuthenticator(nil)
+	require.Equal(t, "err_empty_signing_key", errors.Kind(err))
+
+	require.NoError(t, auth.ValidateHMACIdentity("alice"))
+	require.Equal(t, "err_invalid_token_identity", errors.Kind(auth.ValidateHMACIdentity("")))
+	require.Equal(t, "err_invalid_token_identity", errors.Kind(auth.ValidateHMACIdentity("alice|0")))
+}
+
+func TestReadSigningKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keyPath := filepath.Join(dir, "signing_key")
+	require.NoError(t, ioutil.WriteFile(keyPath, []byte("signing-key\n"), 0600))
+	key, err := auth.ReadSigningKey(keyPath)
+	require.NoError(t, err)
+	require.Equal(t, []byte("signing-key"), key)
+
+	authenticator, err := auth.NewHMACAuthenticatorFromFile(keyPath)
+	require.NoError(t, err)
+	identity, err := authenticator.Authenticate("Bearer " + auth.SignHMACToken(key, "alice", nil))
+	require.NoError(t, err)
+	require.Equal(t, "alice", identity)
+
+	require.NoError(t, ioutil.WriteFile(keyPath, []byte(" \n"), 0600))
+	_, err = auth.ReadSigningKey(keyPath)
+	require.Equal(t, "err_empty_signing_key", errors.Kind(err))
+
+	_, err = auth.ReadSigningKey(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"strings"
+)
+
+// AWSAuthenticator accepts "CortexAWS <access key ID>|<secret access key>" headers
+type AWSAuthenticator struct {
+	// authUser returns whether the credentials belong to the operator's AWS account
+	authUser func(accessKeyID string, secretAccessKey string) (bool, error)
+}
+
+func NewAWSAuthenticator(authUser func(accessKeyID string, secretAccessKey string) (bool, error)) *AWSAuthenticator {
+	return &AWSAuthenticator{authUser: authUser}
+}
+
+// Authenticate returns the caller's access key ID
+func (a *AWSAuthenticator) Authenticate(authHeader string) (string, error) {
+	credentials, err := parseAuthHeader(authHeader, AWSScheme)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(credentials, "|")
+	if len(parts) != 2 {
+		return "", ErrorAuthHeaderMalformed(AWSScheme)
+	}
+	accessKeyID, secretAccessKey := parts[0], parts[1]
+
+	authed, err := a.authUser(accessKeyID, secretAccessKey)
+	if err != nil {
+		return "", ErrorAWSAuthAPIError()
+	}
+	if !authed {
+		return "", ErrorAWSAuthForbidden()
+	}
+
+	return accessKeyID, nil
+}
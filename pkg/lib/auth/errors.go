@@ -0,0 +1,159 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"fmt"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+)
+
+type ErrorKind int
+
+const (
+	ErrUnknown ErrorKind = iota
+	ErrAuthHeaderMissing
+	ErrAuthHeaderMalformed
+	ErrAWSAuthAPIError
+	ErrAWSAuthForbidden
+	ErrInvalidToken
+	ErrTokenExpired
+	ErrNoTokens
+	ErrEmptySigningKey
+	ErrInvalidTokenIdentity
+)
+
+var errorKinds = []string{
+	"err_unknown",
+	"err_auth_header_missing",
+	"err_auth_header_malformed",
+	"err_aws_auth_api_error",
+	"err_aws_auth_forbidden",
+	"err_invalid_token",
+	"err_token_expired",
+	"err_no_tokens",
+	"err_empty_signing_key",
+	"err_invalid_token_identity",
+}
+
+var _ = [1]int{}[int(ErrInvalidTokenIdentity)-(len(errorKinds)-1)] // Ensure list length matches
+
+func (t ErrorKind) String() string {
+	return errorKinds[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t ErrorKind) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *ErrorKind) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(errorKinds); i++ {
+		if enum == errorKinds[i] {
+			*t = ErrorKind(i)
+			return nil
+		}
+	}
+
+	*t = ErrUnknown
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *ErrorKind) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t ErrorKind) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+type Error struct {
+	Kind    ErrorKind
+	message string
+}
+
+func (e Error) Error() string {
+	return e.message
+}
+
+func ErrorAuthHeaderMissing() error {
+	return Error{
+		Kind:    ErrAuthHeaderMissing,
+		message: "auth header missing",
+	}
+}
+
+func ErrorAuthHeaderMalformed(scheme string) error {
+	return Error{
+		Kind:    ErrAuthHeaderMalformed,
+		message: fmt.Sprintf("auth header malformed (expected %s)", s.UserStr(scheme+" <credentials>")),
+	}
+}
+
+func ErrorAWSAuthAPIError() error {
+	return Error{
+		Kind:    ErrAWSAuthAPIError,
+		message: "the operator is unable to verify user's credentials using AWS STS; export AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, and run `./cortex-installer.sh update operator` to update the operator's AWS credentials",
+	}
+}
+
+func ErrorAWSAuthForbidden() error {
+	return Error{
+		Kind:    ErrAWSAuthForbidden,
+		message: "invalid AWS credentials; run `cortex configure` to configure your CLI with credentials for any IAM user in the same AWS account as the operator",
+	}
+}
+
+func ErrorInvalidToken() error {
+	return Error{
+		Kind:    ErrInvalidToken,
+		message: "invalid token; run `cortex configure` to configure your CLI with a token issued by your cluster administrator",
+	}
+}
+
+func ErrorTokenExpired(identity string) error {
+	return Error{
+		Kind:    ErrTokenExpired,
+		message: fmt.Sprintf("the token for %s has expired; ask your cluster administrator for a new token, and run `cortex configure`", s.UserStr(identity)),
+	}
+}
+
+func ErrorNoTokens(dir string) error {
+	return Error{
+		Kind:    ErrNoTokens,
+		message: fmt.Sprintf("%s does not contain any tokens", dir),
+	}
+}
+
+func ErrorEmptySigningKey() error {
+	return Error{
+		Kind:    ErrEmptySigningKey,
+		message: "the token signing key is empty",
+	}
+}
+
+func ErrorInvalidTokenIdentity(identity string) error {
+	return Error{
+		Kind:    ErrInvalidTokenIdentity,
+		message: fmt.Sprintf("invalid identity %s: identities must not be empty or contain \"|\"", s.UserStr(identity)),
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// HMACAuthenticator accepts "Bearer <token>" headers for tokens signed with a shared key.
+// A token is "<payload>.<signature>", where payload is the base64url encoding of "<identity>|<expiration unix time>"
+// (an expiration of 0 never expires) and signature is the base64url encoding of HMAC-SHA256(key, payload).
+type HMACAuthenticator struct {
+	key []byte
+}
+
+func NewHMACAuthenticator(key []byte) (*HMACAuthenticator, error) {
+	if len(key) == 0 {
+		return nil, ErrorEmptySigningKey()
+	}
+	return &HMACAuthenticator{key: key}, nil
+}
+
+// NewHMACAuthenticatorFromFile reads the signing key from keyPath (e.g. a mounted secret)
+func NewHMACAuthenticatorFromFile(keyPath string) (*HMACAuthenticator, error) {
+	key, err := ReadSigningKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewHMACAuthenticator(key)
+}
+
+// ReadSigningKey reads a signing key from keyPath, ignoring surrounding whitespace
+func ReadSigningKey(keyPath string) ([]byte, error) {
+	keyBytes, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, keyPath)
+	}
+	key := []byte(strings.TrimSpace(string(keyBytes)))
+	if len(key) == 0 {
+		return nil, errors.Wrap(ErrorEmptySigningKey(), keyPath)
+	}
+	return key, nil
+}
+
+// Authenticate returns the identity that the token was issued to
+func (a *HMACAuthenticator) Authenticate(authHeader string) (string, error) {
+	token, err := parseAuthHeader(authHeader, BearerScheme)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return "", ErrorInvalidToken()
+	}
+	payload, signature := parts[0], parts[1]
+
+	expectedSignature := signHMAC(a.key, payload)
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return "", ErrorInvalidToken()
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrorInvalidToken()
+	}
+	payloadParts := strings.Split(string(payloadBytes), "|")
+	if len(payloadParts) != 2 || payloadParts[0] == "" {
+		return "", ErrorInvalidToken()
+	}
+	identity := payloadParts[0]
+	expiration, err := strconv.ParseInt(payloadParts[1], 10, 64)
+	if err != nil {
+		return "", ErrorInvalidToken()
+	}
+	if expiration != 0 && time.Now().Unix() > expiration {
+		return "", ErrorTokenExpired(identity)
+	}
+
+	return identity, nil
+}
+
+// ValidateHMACIdentity checks that identity can be encoded in a token's payload
+func ValidateHMACIdentity(identity string) error {
+	if identity == "" || strings.Contains(identity, "|") {
+		return ErrorInvalidTokenIdentity(identity)
+	}
+	return nil
+}
+
+// SignHMACToken creates a token for identity (which must satisfy ValidateHMACIdentity); a nil expiration never expires
+func SignHMACToken(key []byte, identity string, expiration *time.Time) string {
+	var expirationUnix int64
+	if expiration != nil {
+		expirationUnix = expiration.Unix()
+	}
+	payload := base64.RawURLEncoding.EncodeToString([]byte(identity + "|" + strconv.FormatInt(expirationUnix, 10)))
+	return payload + "." + signHMAC(key, payload)
+}
+
+func signHMAC(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/subtle"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// TokenAuthenticator accepts "Bearer <token>" headers for a fixed set of tokens
+type TokenAuthenticator struct {
+	tokens map[string]string // token -> identity
+}
+
+// NewTokenAuthenticator reads one token per file in dir (e.g. a mounted secret), using the file name as the identity
+func NewTokenAuthenticator(dir string) (*TokenAuthenticator, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, dir)
+	}
+
+	tokens := make(map[string]string)
+	for _, fileInfo := range fileInfos {
+		// Secret volumes contain hidden ..data directories and symlinks
+		if fileInfo.IsDir() || strings.HasPrefix(fileInfo.Name(), ".") {
+			continue
+		}
+		tokenBytes, err := ioutil.ReadFile(filepath.Join(dir, fileInfo.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, dir, fileInfo.Name())
+		}
+		token := strings.TrimSpace(string(tokenBytes))
+		if token == "" {
+			continue
+		}
+		tokens[token] = fileInfo.Name()
+	}
+
+	if len(tokens) == 0 {
+		return nil, ErrorNoTokens(dir)
+	}
+
+	return &TokenAuthenticator{tokens: tokens}, nil
+}
+
+// Authenticate returns the identity that the token was issued to
+func (a *TokenAuthenticator) Authenticate(authHeader string) (string, error) {
+	token, err := parseAuthHeader(authHeader, BearerScheme)
+	if err != nil {
+		return "", err
+	}
+
+	for validToken, identity := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(validToken)) == 1 {
+			return identity, nil
+		}
+	}
+	return "", ErrorInvalidToken()
+}
@@ -19,6 +19,7 @@ package errors
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 
 	pkgerrors "github.com/pkg/errors"
@@ -41,7 +42,35 @@ func Wrap(err error, strs ...string) error {
 		return pkgerrors.WithStack(err)
 	}
 	errStr := strings.Join(strs, ": ")
-	return pkgerrors.Wrap(err, errStr)
+	return &wrappedError{
+		err:   pkgerrors.Wrap(err, errStr),
+		cause: err,
+		path:  strs,
+	}
+}
+
+// wrappedError records the strings an error was wrapped with, so that they can be returned by Path
+type wrappedError struct {
+	err   error // the error wrapped with the joined path, which records the stack trace
+	cause error
+	path  []string
+}
+
+func (e *wrappedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *wrappedError) Cause() error {
+	return e.cause
+}
+
+// Format satisfies fmt.Formatter, so that "%+v" prints the stack trace
+func (e *wrappedError) Format(state fmt.State, verb rune) {
+	if formatter, ok := e.err.(fmt.Formatter); ok {
+		formatter.Format(state, verb)
+		return
+	}
+	fmt.Fprintf(state, "%s", e.err.Error())
 }
 
 func WithStack(err error) error {
@@ -52,6 +81,59 @@ func Cause(err error) error {
 	return pkgerrors.Cause(err)
 }
 
+// Kind returns the string value of the Kind field of err's cause (e.g. "err_app_not_deployed"), or "" if it has none
+func Kind(err error) string {
+	cause := Cause(err)
+	if cause == nil {
+		return ""
+	}
+
+	val := reflect.ValueOf(cause)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ""
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	kind := val.FieldByName("Kind")
+	if !kind.IsValid() || !kind.CanInterface() {
+		return ""
+	}
+	if stringer, ok := kind.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return ""
+}
+
+// Message returns the message of err's cause, without the strings it was wrapped with
+func Message(err error) string {
+	cause := Cause(err)
+	if cause == nil {
+		return ""
+	}
+	return cause.Error()
+}
+
+// Path returns the strings that err was wrapped with (e.g. file, resource, and key), outermost first
+func Path(err error) []string {
+	var path []string
+	for err != nil {
+		if wrapped, ok := err.(*wrappedError); ok {
+			path = append(path, wrapped.path...)
+		}
+		causer, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = causer.Cause()
+	}
+	return path
+}
+
 func AddError(errs []error, err error, strs ...string) ([]error, bool) {
 	ok := false
 	if err != nil {
@@ -0,0 +1,71 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+type testErrorKind int
+
+func (t testErrorKind) String() string {
+	return "err_test"
+}
+
+type testError struct {
+	Kind    testErrorKind
+	message string
+}
+
+func (e testError) Error() string {
+	return e.message
+}
+
+func TestKindMessagePath(t *testing.T) {
+	var err error = testError{message: "something went wrong"}
+	require.Equal(t, "err_test", errors.Kind(err))
+	require.Equal(t, "something went wrong", errors.Message(err))
+	require.Empty(t, errors.Path(err))
+
+	err = errors.Wrap(err, "resource", "key")
+	err = errors.WithStack(err)
+	err = errors.Wrap(err, "app.yaml")
+	require.Equal(t, "app.yaml: resource: key: something went wrong", err.Error())
+	require.Equal(t, "err_test", errors.Kind(err))
+	require.Equal(t, "something went wrong", errors.Message(err))
+	require.Equal(t, []string{"app.yaml", "resource", "key"}, errors.Path(err))
+
+	// Path elements and messages may contain the separator
+	err = errors.Wrap(testError{message: "expected: an int"}, "column: a", "key")
+	require.Equal(t, "column: a: key: expected: an int", err.Error())
+	require.Equal(t, "expected: an int", errors.Message(err))
+	require.Equal(t, []string{"column: a", "key"}, errors.Path(err))
+	require.Contains(t, fmt.Sprintf("%+v", err), "errors_test.go")
+
+	err = errors.New("plain", "error")
+	require.Equal(t, "", errors.Kind(err))
+	require.Equal(t, "plain: error", errors.Message(err))
+	require.Empty(t, errors.Path(err))
+
+	require.Equal(t, "", errors.Kind(nil))
+	require.Empty(t, errors.Path(nil))
+}
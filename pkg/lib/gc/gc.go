@@ -0,0 +1,137 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/lib/storage"
+)
+
+// GracePeriod protects recently uploaded objects, which may belong to a deployment that is still in progress
+const GracePeriod = time.Hour
+
+// Reachable holds the keys, and the key prefixes, which garbage collection keeps
+type Reachable struct {
+	keys     strset.Set
+	prefixes []string
+}
+
+func NewReachable() *Reachable {
+	return &Reachable{keys: strset.New()}
+}
+
+func (reachable *Reachable) AddKey(key string) {
+	reachable.keys.Add(key)
+}
+
+func (reachable *Reachable) AddPrefix(prefix string) {
+	reachable.prefixes = append(reachable.prefixes, prefix)
+}
+
+func (reachable *Reachable) Has(key string) bool {
+	if reachable.keys.Has(key) {
+		return true
+	}
+	for _, prefix := range reachable.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// App holds what garbage collection needs to know about an app's stored objects
+type App struct {
+	// Objects are the objects in the app's collected directories
+	Objects []*storage.Object
+	// Contexts are the app's current context and the contexts of its kept deployments
+	Contexts []*context.Context
+	// StatusPrefix is the prefix of the app's resource statuses
+	StatusPrefix string
+	// WorkloadKeys returns the keys which belong to a workload (e.g. its spec)
+	WorkloadKeys func(workloadID string) []string
+	// DatasetPrefix is the prefix of the app's current dataset version, which may not have been deployed yet
+	DatasetPrefix string
+}
+
+// Unreachable returns the app's objects which are not reachable from its kept contexts, and adds the python packages
+// which the contexts use to pythonPackagesReachable. If the app has no kept contexts (e.g. it was deleted with
+// --keep-cache), which of its objects are still used is unknown, so nothing is returned and ok is false
+// (unless it has no objects to collect, e.g. when only its audit log remains).
+func (app *App) Unreachable(pythonPackagesReachable *Reachable, now time.Time) ([]*storage.Object, bool) {
+	if len(app.Objects) == 0 {
+		return nil, true
+	}
+	if len(app.Contexts) == 0 {
+		return nil, false
+	}
+
+	reachable := NewReachable()
+	workloadIDs := strset.New()
+	for _, ctx := range app.Contexts {
+		reachable.AddKey(ctx.Key)
+		reachable.AddPrefix(ctx.Root + "/")
+		for _, res := range ctx.ComputedResources() {
+			reachable.AddPrefix(filepath.Join(ctx.StatusPrefix, res.GetID()) + "/")
+			if res.GetWorkloadID() != "" {
+				workloadIDs.Add(res.GetWorkloadID())
+			}
+		}
+		for _, pythonPackage := range ctx.PythonPackages {
+			pythonPackagesReachable.AddPrefix(filepath.Dir(pythonPackage.SrcKey) + "/")
+		}
+	}
+
+	// The workload IDs of the kept resources' statuses identify the workloads which are still used
+	for _, object := range app.Objects {
+		if reachable.Has(object.Key) && strings.HasPrefix(object.Key, app.StatusPrefix+"/") {
+			if workloadID := filepath.Base(object.Key); workloadID != "latest" {
+				workloadIDs.Add(workloadID)
+			}
+		}
+	}
+	for workloadID := range workloadIDs {
+		for _, key := range app.WorkloadKeys(workloadID) {
+			reachable.AddKey(key)
+		}
+	}
+
+	if app.DatasetPrefix != "" {
+		reachable.AddPrefix(app.DatasetPrefix)
+	}
+
+	return Unreachable(app.Objects, reachable, now), true
+}
+
+// Unreachable returns the objects which are not reachable and were last modified more than GracePeriod before now
+func Unreachable(objects []*storage.Object, reachable *Reachable, now time.Time) []*storage.Object {
+	cutoff := now.Add(-GracePeriod)
+
+	var unreachable []*storage.Object
+	for _, object := range objects {
+		if reachable.Has(object.Key) || object.LastModified.After(cutoff) {
+			continue
+		}
+		unreachable = append(unreachable, object)
+	}
+	return unreachable
+}
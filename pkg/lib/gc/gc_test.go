@@ -0,0 +1,132 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/gc"
+	"github.com/cortexlabs/cortex/pkg/lib/storage"
+)
+
+var now = time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func object(key string, age time.Duration) *storage.Object {
+	return &storage.Object{Key: key, Size: 10, LastModified: now.Add(-age)}
+}
+
+func keys(objects []*storage.Object) []string {
+	var keys []string
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	return keys
+}
+
+func testApp(ctxs ...*context.Context) *gc.App {
+	return &gc.App{
+		Objects: []*storage.Object{
+			object("apps/iris/contexts/ctx1.msgpack", 48*time.Hour),
+			object("apps/iris/contexts/ctx2.msgpack", 48*time.Hour),
+			object("apps/iris/contexts/ctx3.msgpack", time.Minute),
+			object("apps/iris/data/v1/env1/aggregates/agg1", 48*time.Hour),
+			object("apps/iris/data/v1/env2/aggregates/agg1", 48*time.Hour),
+			object("apps/iris/data/v2/env1/raw.parquet", 48*time.Hour),
+			object("apps/iris/resource_statuses/agg1/wid1", 48*time.Hour),
+			object("apps/iris/resource_statuses/agg1/latest", 48*time.Hour),
+			object("apps/iris/resource_statuses/agg2/wid2", 48*time.Hour),
+			object("apps/iris/workload_specs/wid1", 48*time.Hour),
+			object("apps/iris/workload_specs/wid2", 48*time.Hour),
+		},
+		Contexts:     ctxs,
+		StatusPrefix: "apps/iris/resource_statuses",
+		WorkloadKeys: func(workloadID string) []string {
+			return []string{"apps/iris/workload_specs/" + workloadID}
+		},
+		DatasetPrefix: "apps/iris/data/v2/",
+	}
+}
+
+func testContext() *context.Context {
+	return &context.Context{
+		Key:          "apps/iris/contexts/ctx1.msgpack",
+		Root:         "apps/iris/data/v1/env1",
+		StatusPrefix: "apps/iris/resource_statuses",
+		Aggregates: context.Aggregates{
+			"agg1": &context.Aggregate{
+				Aggregate: &userconfig.Aggregate{
+					ResourceConfigFields: userconfig.ResourceConfigFields{Name: "agg1"},
+				},
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           "agg1",
+						ResourceType: resource.AggregateType,
+					},
+				},
+			},
+		},
+		PythonPackages: context.PythonPackages{
+			"pkg1": &context.PythonPackage{
+				ComputedResourceFields: &context.ComputedResourceFields{
+					ResourceFields: &context.ResourceFields{
+						ID:           "pkg1",
+						ResourceType: resource.PythonPackageType,
+					},
+				},
+				SrcKey: "python_packages/pkg1/src.zip",
+			},
+		},
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	pythonPackagesReachable := gc.NewReachable()
+	unreachable, ok := testApp(testContext()).Unreachable(pythonPackagesReachable, now)
+	require.True(t, ok)
+	require.Equal(t, []string{
+		"apps/iris/contexts/ctx2.msgpack",
+		"apps/iris/data/v1/env2/aggregates/agg1",
+		"apps/iris/resource_statuses/agg2/wid2",
+		"apps/iris/workload_specs/wid2",
+	}, keys(unreachable))
+
+	require.True(t, pythonPackagesReachable.Has("python_packages/pkg1/package.zip"))
+	require.False(t, pythonPackagesReachable.Has("python_packages/pkg2/package.zip"))
+}
+
+func TestUnreachableWithoutContexts(t *testing.T) {
+	// e.g. a dry run for an app which was deleted with --keep-cache
+	pythonPackagesReachable := gc.NewReachable()
+	unreachable, ok := testApp().Unreachable(pythonPackagesReachable, now)
+	require.False(t, ok)
+	require.Empty(t, unreachable)
+
+	require.False(t, pythonPackagesReachable.Has("python_packages/pkg1/package.zip"))
+
+	// e.g. an app whose audit log is all that remains
+	app := testApp()
+	app.Objects = nil
+	unreachable, ok = app.Unreachable(pythonPackagesReachable, now)
+	require.True(t, ok)
+	require.Empty(t, unreachable)
+}
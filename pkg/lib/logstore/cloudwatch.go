@@ -0,0 +1,89 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+type CloudWatchClient struct {
+	LogGroup             string
+	cloudWatchLogsClient *cloudwatchlogs.CloudWatchLogs
+}
+
+func NewCloudWatchClient(logGroup string, region string) (*CloudWatchClient, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:     aws.String(region),
+		DisableSSL: aws.Bool(false),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &CloudWatchClient{
+		LogGroup:             logGroup,
+		cloudWatchLogsClient: cloudwatchlogs.New(sess),
+	}, nil
+}
+
+func (c *CloudWatchClient) GetLogs(prefix string) (string, error) {
+	logStreamsOut, err := c.cloudWatchLogsClient.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
+		Limit:               aws.Int64(50),
+		LogGroupName:        aws.String(c.LogGroup),
+		LogStreamNamePrefix: aws.String(streamNamePrefix + prefix),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "cloudwatch logs", prefix)
+	}
+
+	var streams []*logStream
+
+	for _, cwLogStream := range logStreamsOut.LogStreams {
+		if shouldIgnoreStream(*cwLogStream.LogStreamName) {
+			continue
+		}
+
+		stream := &logStream{name: *cwLogStream.LogStreamName}
+		getLogEventsInput := &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(c.LogGroup),
+			LogStreamName: cwLogStream.LogStreamName,
+			StartFromHead: aws.Bool(true),
+		}
+
+		err := c.cloudWatchLogsClient.GetLogEventsPages(getLogEventsInput, func(logEventsOut *cloudwatchlogs.GetLogEventsOutput, lastPage bool) bool {
+			for _, logEvent := range logEventsOut.Events {
+				var log FluentdLog
+				json.Unmarshal([]byte(*logEvent.Message), &log)
+				stream.logs = append(stream.logs, log.Log)
+			}
+			return true
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "cloudwatch logs", prefix)
+		}
+
+		streams = append(streams, stream)
+	}
+
+	return joinStreams(streams), nil
+}
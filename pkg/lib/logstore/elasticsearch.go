@@ -0,0 +1,92 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	"strings"
+
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+)
+
+const elasticsearchMaxHits = 10000
+
+// ElasticsearchClient searches the records written by fluentd's elasticsearch output.
+// Endpoint includes the index (pattern), e.g. http://elasticsearch:9200/fluentd-*
+type ElasticsearchClient struct {
+	Endpoint string
+}
+
+func NewElasticsearchClient(endpoint string) (*ElasticsearchClient, error) {
+	return &ElasticsearchClient{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+	}, nil
+}
+
+type elasticsearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source FluentdLog `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (c *ElasticsearchClient) GetLogs(prefix string) (string, error) {
+	// pod_name may be mapped as text (with a keyword sub-field) or as a keyword
+	query := map[string]interface{}{
+		"size": elasticsearchMaxHits,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should": []interface{}{
+					map[string]interface{}{"prefix": map[string]interface{}{"kubernetes.pod_name": prefix}},
+					map[string]interface{}{"prefix": map[string]interface{}{"kubernetes.pod_name.keyword": prefix}},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"@timestamp": map[string]interface{}{"order": "asc", "unmapped_type": "date"}},
+		},
+	}
+	queryBytes, err := libjson.Marshal(query)
+	if err != nil {
+		return "", err
+	}
+
+	var response elasticsearchResponse
+	if err := httpJSON("POST", c.Endpoint+"/_search", queryBytes, &response); err != nil {
+		return "", err
+	}
+
+	var streams []*logStream
+	streamsByName := make(map[string]*logStream)
+	for _, hit := range response.Hits.Hits {
+		log := hit.Source
+		if !strings.HasPrefix(log.Kubernetes.PodName, prefix) {
+			continue
+		}
+		name := streamName(log.Kubernetes.PodName, log.Kubernetes.NamespaceName, log.Kubernetes.ContainerName)
+		stream, ok := streamsByName[name]
+		if !ok {
+			stream = &logStream{name: name}
+			streamsByName[name] = stream
+			streams = append(streams, stream)
+		}
+		stream.logs = append(stream.logs, log.Log)
+	}
+
+	return joinStreams(streams), nil
+}
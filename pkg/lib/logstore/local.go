@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	"bufio"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+)
+
+// LocalClient reads logs written by fluentd's file output: Dir contains files named
+// after the fluentd tag (possibly split into chunks), with one JSON record per line
+type LocalClient struct {
+	Dir string
+}
+
+func NewLocalClient(dir string) (*LocalClient, error) {
+	if err := files.CheckDir(dir); err != nil {
+		return nil, err
+	}
+
+	return &LocalClient{
+		Dir: dir,
+	}, nil
+}
+
+func (c *LocalClient) GetLogs(prefix string) (string, error) {
+	fileNames, err := files.ListDir(c.Dir, true)
+	if err != nil {
+		return "", errors.Wrap(err, "local logs", prefix)
+	}
+	sort.Strings(fileNames)
+
+	var streams []*logStream
+	for _, fileName := range fileNames {
+		if !strings.HasPrefix(fileName, streamNamePrefix+prefix) || shouldIgnoreStream(fileName) {
+			continue
+		}
+
+		logs, err := readLocalLogs(filepath.Join(c.Dir, fileName))
+		if err != nil {
+			return "", errors.Wrap(err, "local logs", prefix)
+		}
+
+		// Chunks of the same stream are sorted next to each other
+		name := localStreamName(fileName)
+		if len(streams) > 0 && streams[len(streams)-1].name == name {
+			streams[len(streams)-1].logs = append(streams[len(streams)-1].logs, logs...)
+			continue
+		}
+		streams = append(streams, &logStream{name: name, logs: logs})
+	}
+
+	return joinStreams(streams), nil
+}
+
+// e.g. var.log.containers.<pod>_<namespace>_<container>-<id>.log.20190101.log -> var.log.containers.<pod>_<namespace>_<container>-<id>.log
+func localStreamName(fileName string) string {
+	tag := strings.TrimPrefix(fileName, streamNamePrefix)
+	if i := strings.Index(tag, ".log"); i >= 0 {
+		tag = tag[:i+len(".log")]
+	}
+	return streamNamePrefix + tag
+}
+
+func readLocalLogs(path string) ([]string, error) {
+	file, err := files.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var logs []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		logs = append(logs, extractLog(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	return logs, nil
+}
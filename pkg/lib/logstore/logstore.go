@@ -0,0 +1,113 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/regex"
+)
+
+const (
+	TypeCloudWatch    = "cloudwatch"
+	TypeLocal         = "local"
+	TypeElasticsearch = "elasticsearch"
+	TypeLoki          = "loki"
+)
+
+var Types = []string{
+	TypeCloudWatch,
+	TypeLocal,
+	TypeElasticsearch,
+	TypeLoki,
+}
+
+// Client retrieves the logs which fluentd shipped for pods whose names start with prefix
+type Client interface {
+	GetLogs(prefix string) (string, error)
+}
+
+type FluentdLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Docker struct {
+		ContainerID string `json:"container_id"`
+	} `json:"docker"`
+	Kubernetes struct {
+		ContainerName string `json:"container_name"`
+		NamespaceName string `json:"namespace_name"`
+		PodName       string `json:"pod_name"`
+		OrphanedName  string `json:"orphaned_namespace"`
+		NamespaceID   string `json:"namespace_id"`
+	} `json:"kubernetes"`
+}
+
+// fluentd tags container logs with the log file path, e.g. var.log.containers.<pod>_<namespace>_<container>-<id>.log
+const streamNamePrefix = "var.log.containers."
+
+var ignoreStreamNameRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`-exec-[0-9]+`),
+	regexp.MustCompile(`_spark-init-`),
+	regexp.MustCompile(`_cortex_serve-`),
+}
+
+func streamName(podName string, namespace string, containerName string) string {
+	return streamNamePrefix + podName + "_" + namespace + "_" + containerName + "-"
+}
+
+func shouldIgnoreStream(streamName string) bool {
+	return regex.MatchAnyRegex(streamName, ignoreStreamNameRegexes)
+}
+
+type logStream struct {
+	name string
+	logs []string
+}
+
+func joinStreams(streams []*logStream) string {
+	var allLogsBuf bytes.Buffer
+	var nonEmpty []*logStream
+	for _, stream := range streams {
+		if !shouldIgnoreStream(stream.name) {
+			nonEmpty = append(nonEmpty, stream)
+		}
+	}
+
+	for i, stream := range nonEmpty {
+		for _, log := range stream.logs {
+			allLogsBuf.WriteString(log)
+		}
+		if i < len(nonEmpty)-1 {
+			allLogsBuf.WriteString("\n----------\n\n")
+		}
+	}
+
+	return allLogsBuf.String()
+}
+
+// fluentd records store the raw line (including its trailing newline) in "log";
+// fall back to the line itself for records which aren't fluentd JSON
+func extractLog(line string) string {
+	var log FluentdLog
+	if err := json.Unmarshal([]byte(line), &log); err == nil && log.Log != "" {
+		return log.Log
+	}
+	return strings.TrimSuffix(line, "\n") + "\n"
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/logstore"
+)
+
+func TestLocalClient(t *testing.T) {
+	tmpDir, err := files.TmpDir()
+	defer os.RemoveAll(tmpDir)
+	require.NoError(t, err)
+
+	writeFile := func(name string, content string) {
+		require.NoError(t, files.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	writeFile("var.log.containers.abc-driver_cortex_spark-kubernetes-driver-1.log.1.log",
+		`{"log":"line 1\n","stream":"stdout"}`+"\n"+`{"log":"line 2\n","stream":"stdout"}`+"\n")
+	writeFile("var.log.containers.abc-driver_cortex_spark-kubernetes-driver-1.log.2.log",
+		`{"log":"line 3\n","stream":"stdout"}`+"\n")
+	writeFile("var.log.containers.abc-exec-1_cortex_executor-2.log", `{"log":"executor\n"}`+"\n")
+	writeFile("var.log.containers.abc-other_cortex_main-3.log", "not json\n")
+	writeFile("var.log.containers.xyz_cortex_main-4.log", `{"log":"xyz\n"}`+"\n")
+
+	client, err := logstore.NewLocalClient(tmpDir)
+	require.NoError(t, err)
+
+	logs, err := client.GetLogs("abc")
+	require.NoError(t, err)
+	require.Equal(t, "line 1\nline 2\nline 3\n\n----------\n\nnot json\n", logs)
+
+	logs, err = client.GetLogs("xyz")
+	require.NoError(t, err)
+	require.Equal(t, "xyz\n", logs)
+
+	logs, err = client.GetLogs("missing")
+	require.NoError(t, err)
+	require.Equal(t, "", logs)
+
+	_, err = logstore.NewLocalClient(filepath.Join(tmpDir, "missing"))
+	require.Error(t, err)
+}
+
+func TestElasticsearchClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/fluentd/_search", r.URL.Path)
+		w.Write([]byte(`{"hits": {"hits": [
+			{"_source": {"log": "a1\n", "kubernetes": {"pod_name": "abc-1", "namespace_name": "cortex", "container_name": "main"}}},
+			{"_source": {"log": "b1\n", "kubernetes": {"pod_name": "abc-2", "namespace_name": "cortex", "container_name": "main"}}},
+			{"_source": {"log": "a2\n", "kubernetes": {"pod_name": "abc-1", "namespace_name": "cortex", "container_name": "main"}}},
+			{"_source": {"log": "x\n", "kubernetes": {"pod_name": "ab", "namespace_name": "cortex", "container_name": "main"}}}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client, err := logstore.NewElasticsearchClient(server.URL + "/fluentd/")
+	require.NoError(t, err)
+
+	logs, err := client.GetLogs("abc")
+	require.NoError(t, err)
+	require.Equal(t, "a1\na2\n\n----------\n\nb1\n", logs)
+}
+
+func TestLokiClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("query") != `{pod=~"abc.*"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("bad query"))
+			return
+		}
+		w.Write([]byte(`{"status": "success", "data": {"resultType": "streams", "result": [
+			{"stream": {"pod": "abc-2", "namespace": "cortex", "container": "main"}, "values": [["1", "b1"]]},
+			{"stream": {"pod": "abc-1", "namespace": "cortex", "container": "main"}, "values": [["1", "{\"log\":\"a1\\n\"}"], ["2", "a2"]]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client, err := logstore.NewLokiClient(server.URL)
+	require.NoError(t, err)
+
+	logs, err := client.GetLogs("abc")
+	require.NoError(t, err)
+	require.Equal(t, "a1\na2\n\n----------\n\nb1\n", logs)
+
+	_, err = client.GetLogs("xyz")
+	require.Error(t, err)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	lokiMaxLines = 5000
+	// Loki rejects queries which span more than its max_query_length (721h by default)
+	lokiQueryRange = 30 * 24 * time.Hour
+)
+
+// LokiClient queries Loki for streams labeled by promtail's kubernetes service discovery
+// (i.e. with "pod", "namespace", and "container" labels), e.g. http://loki:3100
+type LokiClient struct {
+	Endpoint string
+}
+
+func NewLokiClient(endpoint string) (*LokiClient, error) {
+	return &LokiClient{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+	}, nil
+}
+
+type lokiResponse struct {
+	Data struct {
+		Result []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (c *LokiClient) GetLogs(prefix string) (string, error) {
+	now := time.Now()
+	params := url.Values{}
+	params.Set("query", `{pod=~"`+regexp.QuoteMeta(prefix)+`.*"}`)
+	params.Set("direction", "forward")
+	params.Set("limit", strconv.Itoa(lokiMaxLines))
+	params.Set("start", strconv.FormatInt(now.Add(-lokiQueryRange).UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(now.UnixNano(), 10))
+
+	var response lokiResponse
+	if err := httpJSON("GET", c.Endpoint+"/loki/api/v1/query_range?"+params.Encode(), nil, &response); err != nil {
+		return "", err
+	}
+
+	var streams []*logStream
+	for _, result := range response.Data.Result {
+		stream := &logStream{
+			name: streamName(result.Stream["pod"], result.Stream["namespace"], result.Stream["container"]),
+		}
+		for _, value := range result.Values {
+			if len(value) < 2 {
+				continue
+			}
+			stream.logs = append(stream.logs, extractLog(value[1]))
+		}
+		streams = append(streams, stream)
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].name < streams[j].name
+	})
+
+	return joinStreams(streams), nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// DefaultBuckets are histogram buckets (in seconds) for typical request latencies
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry holds metrics and writes them in the Prometheus text exposition format
+type Registry struct {
+	mutex        sync.Mutex
+	metrics      []metric
+	collectHooks []func()
+}
+
+type metric interface {
+	write(buf *bytes.Buffer)
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(m metric) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// OnCollect registers a function which is called before metrics are written (e.g. to set gauges from other state)
+func (r *Registry) OnCollect(hook func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.collectHooks = append(r.collectHooks, hook)
+}
+
+// Write runs the collect hooks, and then writes all metrics to w
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.Lock()
+	hooks := append([]func(){}, r.collectHooks...)
+	metrics := append([]metric{}, r.metrics...)
+	r.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+
+	var buf bytes.Buffer
+	for _, m := range metrics {
+		m.write(&buf)
+	}
+	_, err := w.Write(buf.Bytes())
+	return errors.WithStack(err)
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+// vec is a set of float values, one for each combination of label values
+type vec struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	mutex      sync.Mutex
+	series     map[string]*series
+}
+
+func newVec(name string, help string, metricType string, labelNames []string) *vec {
+	return &vec{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		series:     make(map[string]*series),
+	}
+}
+
+func (v *vec) update(labelValues []string, fn func(float64) float64) {
+	if len(labelValues) != len(v.labelNames) {
+		errors.Panic(ErrorLabelCount(v.name, len(v.labelNames), len(labelValues)))
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string{}, labelValues...)}
+		v.series[key] = s
+	}
+	s.value = fn(s.value)
+}
+
+func (v *vec) write(buf *bytes.Buffer) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	writeHeader(buf, v.name, v.help, v.metricType)
+	for _, key := range sortedKeys(v.series) {
+		s := v.series[key]
+		writeSample(buf, v.name, labelsStr(v.labelNames, s.labelValues), s.value)
+	}
+}
+
+// Counter is a value which only increases (e.g. the number of requests)
+type Counter struct {
+	*vec
+}
+
+func (r *Registry) NewCounter(name string, help string, labelNames ...string) *Counter {
+	c := &Counter{newVec(name, help, "counter", labelNames)}
+	r.register(c)
+	return c
+}
+
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	c.update(labelValues, func(value float64) float64 { return value + delta })
+}
+
+// Gauge is a value which can go up and down (e.g. the number of open connections)
+type Gauge struct {
+	*vec
+}
+
+func (r *Registry) NewGauge(name string, help string, labelNames ...string) *Gauge {
+	g := &Gauge{newVec(name, help, "gauge", labelNames)}
+	r.register(g)
+	return g
+}
+
+func (g *Gauge) Set(value float64, labelValues ...string) {
+	g.update(labelValues, func(float64) float64 { return value })
+}
+
+func (g *Gauge) Inc(labelValues ...string) {
+	g.update(labelValues, func(value float64) float64 { return value + 1 })
+}
+
+func (g *Gauge) Dec(labelValues ...string) {
+	g.update(labelValues, func(value float64) float64 { return value - 1 })
+}
+
+// Reset removes all values (e.g. before setting the gauge for the current set of apps)
+func (g *Gauge) Reset() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.series = make(map[string]*series)
+}
+
+// Histogram counts observations (e.g. request durations) in cumulative buckets
+type Histogram struct {
+	name       string
+	help       string
+	buckets    []float64
+	labelNames []string
+	mutex      sync.Mutex
+	series     map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues  []string
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+func (r *Registry) NewHistogram(name string, help string, buckets []float64, labelNames ...string) *Histogram {
+	h := &Histogram{
+		name:       name,
+		help:       help,
+		buckets:    append([]float64{}, buckets...),
+		labelNames: labelNames,
+		series:     make(map[string]*histogramSeries),
+	}
+	sort.Float64s(h.buckets)
+	r.register(h)
+	return h
+}
+
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labelNames) {
+		errors.Panic(ErrorLabelCount(h.name, len(h.labelNames), len(labelValues)))
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues:  append([]string{}, labelValues...),
+			bucketCounts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			s.bucketCounts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+func (h *Histogram) write(buf *bytes.Buffer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	writeHeader(buf, h.name, h.help, "histogram")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		bucketLabelNames := append(append([]string{}, h.labelNames...), "le")
+		for i, upperBound := range h.buckets {
+			labels := labelsStr(bucketLabelNames, append(append([]string{}, s.labelValues...), formatValue(upperBound)))
+			writeSample(buf, h.name+"_bucket", labels, float64(s.bucketCounts[i]))
+		}
+		labels := labelsStr(bucketLabelNames, append(append([]string{}, s.labelValues...), "+Inf"))
+		writeSample(buf, h.name+"_bucket", labels, float64(s.count))
+		writeSample(buf, h.name+"_sum", labelsStr(h.labelNames, s.labelValues), s.sum)
+		writeSample(buf, h.name+"_count", labelsStr(h.labelNames, s.labelValues), float64(s.count))
+	}
+}
+
+func writeHeader(buf *bytes.Buffer, name string, help string, metricType string) {
+	help = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, metricType)
+}
+
+func writeSample(buf *bytes.Buffer, name string, labels string, value float64) {
+	fmt.Fprintf(buf, "%s%s %s\n", name, labels, formatValue(value))
+}
+
+func labelsStr(labelNames []string, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(labelNames))
+	for i, labelName := range labelNames {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, labelName, escaper.Replace(labelValues[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch typed := m.(type) {
+	case map[string]*series:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]*histogramSeries:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
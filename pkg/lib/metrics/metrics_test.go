@@ -0,0 +1,81 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/metrics"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := metrics.NewRegistry()
+
+	requests := registry.NewCounter("requests_total", "Number of requests.", "action", "code")
+	requests.Inc("deploy", "200")
+	requests.Inc("deploy", "200")
+	requests.Add(3, "delete", "404")
+
+	sessions := registry.NewGauge("sessions", "Open sessions.")
+	sessions.Inc()
+	sessions.Inc()
+	sessions.Dec()
+
+	apps := registry.NewGauge("app_resources", "Resources by status.", "app_name")
+	registry.OnCollect(func() {
+		apps.Reset()
+		apps.Set(4, `my"app`)
+	})
+	apps.Set(1, "deleted-app")
+
+	duration := registry.NewHistogram("duration_seconds", "Duration.", []float64{1, 0.1})
+	duration.Observe(0.05)
+	duration.Observe(0.5)
+	duration.Observe(5)
+
+	var buf bytes.Buffer
+	require.NoError(t, registry.Write(&buf))
+	require.Equal(t, `# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total{action="delete",code="404"} 3
+requests_total{action="deploy",code="200"} 2
+# HELP sessions Open sessions.
+# TYPE sessions gauge
+sessions 1
+# HELP app_resources Resources by status.
+# TYPE app_resources gauge
+app_resources{app_name="my\"app"} 4
+# HELP duration_seconds Duration.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.1"} 1
+duration_seconds_bucket{le="1"} 2
+duration_seconds_bucket{le="+Inf"} 3
+duration_seconds_sum 5.55
+duration_seconds_count 3
+`, buf.String())
+}
+
+func TestLabelCount(t *testing.T) {
+	registry := metrics.NewRegistry()
+	requests := registry.NewCounter("requests_total", "Number of requests.", "action")
+	require.Panics(t, func() {
+		requests.Inc()
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"strings"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+)
+
+type ErrorKind int
+
+const (
+	ErrUnknown ErrorKind = iota
+	ErrQuotaExceeded
+	ErrInvalidPattern
+	ErrInvalidQuantity
+)
+
+var errorKinds = []string{
+	"err_unknown",
+	"err_quota_exceeded",
+	"err_invalid_pattern",
+	"err_invalid_quantity",
+}
+
+var _ = [1]int{}[int(ErrInvalidQuantity)-(len(errorKinds)-1)] // Ensure list length matches
+
+func (t ErrorKind) String() string {
+	return errorKinds[t]
+}
+
+// MarshalText satisfies TextMarshaler
+func (t ErrorKind) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText satisfies TextUnmarshaler
+func (t *ErrorKind) UnmarshalText(text []byte) error {
+	enum := string(text)
+	for i := 0; i < len(errorKinds); i++ {
+		if enum == errorKinds[i] {
+			*t = ErrorKind(i)
+			return nil
+		}
+	}
+
+	*t = ErrUnknown
+	return nil
+}
+
+// UnmarshalBinary satisfies BinaryUnmarshaler
+// Needed for msgpack
+func (t *ErrorKind) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalText(data)
+}
+
+// MarshalBinary satisfies BinaryMarshaler
+func (t ErrorKind) MarshalBinary() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+type Error struct {
+	Kind    ErrorKind
+	message string
+}
+
+func (e Error) Error() string {
+	return e.message
+}
+
+func ErrorQuotaExceeded(appName string, violations []string) error {
+	return Error{
+		Kind:    ErrQuotaExceeded,
+		message: fmt.Sprintf("deployment exceeds the compute quota for app %s; reduce the compute requested by your resources or ask your cluster administrator to raise the quota:\n  %s", s.UserStr(appName), strings.Join(violations, "\n  ")),
+	}
+}
+
+func ErrorInvalidPattern(pattern string) error {
+	return Error{
+		Kind:    ErrInvalidPattern,
+		message: fmt.Sprintf("%s is not a valid pattern", s.UserStr(pattern)),
+	}
+}
+
+func ErrorInvalidQuantity(quantity string) error {
+	return Error{
+		Kind:    ErrInvalidQuantity,
+		message: fmt.Sprintf("%s is not a valid quantity (e.g. \"4\", \"500m\", or \"16Gi\")", s.UserStr(quantity)),
+	}
+}
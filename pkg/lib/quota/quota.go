@@ -0,0 +1,280 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+)
+
+// Rule limits the total compute that each matching app may request; Apps may contain glob patterns (e.g. "team-a-*").
+// If Tags is set, only workloads and APIs which include a resource with all of the tags count towards the limits.
+// Nil limits are unlimited.
+type Rule struct {
+	Apps        []string              `json:"apps" yaml:"apps"`
+	Tags        map[string]string     `json:"tags" yaml:"tags"`
+	CPU         *k8sresource.Quantity `json:"cpu" yaml:"cpu"`
+	Mem         *k8sresource.Quantity `json:"mem" yaml:"mem"`
+	GPU         *int64                `json:"gpu" yaml:"gpu"`
+	APIReplicas *int32                `json:"api_replicas" yaml:"api_replicas"`
+}
+
+// Policy is a list of rules; a deployment must satisfy every rule which matches its app
+type Policy struct {
+	Rules []*Rule
+}
+
+// Usage is the compute requested by a workload or an API (or the total of several)
+type Usage struct {
+	CPU         k8sresource.Quantity
+	Mem         k8sresource.Quantity
+	GPU         int64
+	APIReplicas int32
+}
+
+func (usage *Usage) Add(usage2 *Usage) {
+	usage.CPU.Add(usage2.CPU)
+	usage.Mem.Add(usage2.Mem)
+	usage.GPU += usage2.GPU
+	usage.APIReplicas += usage2.APIReplicas
+}
+
+// Item is a workload or API in a deployment; Tags holds the tags of each of its resources
+type Item struct {
+	Name  string
+	Tags  []map[string]string
+	Usage *Usage
+}
+
+func (rule *Rule) MatchesApp(appName string) bool {
+	return matchesAny(rule.Apps, appName)
+}
+
+// MatchesItem returns true if any of item's resources has all of the rule's tags
+func (rule *Rule) MatchesItem(item *Item) bool {
+	if len(rule.Tags) == 0 {
+		return true
+	}
+	for _, tags := range item.Tags {
+		if hasTags(tags, rule.Tags) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTags(tags map[string]string, required map[string]string) bool {
+	for key, value := range required {
+		if tagValue, ok := tags[key]; !ok || tagValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(patterns []string, str string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, str); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns an error describing every limit which items exceed, across all of the rules which match appName
+func (p *Policy) Check(appName string, items []*Item) error {
+	var violations []string
+	for _, rule := range p.Rules {
+		if !rule.MatchesApp(appName) {
+			continue
+		}
+		violations = append(violations, rule.violations(items)...)
+	}
+
+	if len(violations) > 0 {
+		return ErrorQuotaExceeded(appName, violations)
+	}
+	return nil
+}
+
+func (rule *Rule) violations(items []*Item) []string {
+	var matchedItems []*Item
+	total := &Usage{}
+	for _, item := range items {
+		if rule.MatchesItem(item) {
+			matchedItems = append(matchedItems, item)
+			total.Add(item.Usage)
+		}
+	}
+
+	var violations []string
+	if rule.CPU != nil && total.CPU.Cmp(*rule.CPU) > 0 {
+		violations = append(violations, rule.violation("cpu", total.CPU.String(), rule.CPU.String(), matchedItems, func(usage *Usage) (string, bool) {
+			return usage.CPU.String(), !usage.CPU.IsZero()
+		}))
+	}
+	if rule.Mem != nil && total.Mem.Cmp(*rule.Mem) > 0 {
+		violations = append(violations, rule.violation("mem", total.Mem.String(), rule.Mem.String(), matchedItems, func(usage *Usage) (string, bool) {
+			return usage.Mem.String(), !usage.Mem.IsZero()
+		}))
+	}
+	if rule.GPU != nil && total.GPU > *rule.GPU {
+		violations = append(violations, rule.violation("gpu", s.Int64(total.GPU), s.Int64(*rule.GPU), matchedItems, func(usage *Usage) (string, bool) {
+			return s.Int64(usage.GPU), usage.GPU != 0
+		}))
+	}
+	if rule.APIReplicas != nil && total.APIReplicas > *rule.APIReplicas {
+		violations = append(violations, rule.violation("api_replicas", s.Int32(total.APIReplicas), s.Int32(*rule.APIReplicas), matchedItems, func(usage *Usage) (string, bool) {
+			return s.Int32(usage.APIReplicas), usage.APIReplicas != 0
+		}))
+	}
+	return violations
+}
+
+func (rule *Rule) violation(limitName string, requested string, limit string, items []*Item, itemUsage func(*Usage) (string, bool)) string {
+	var breakdown []string
+	for _, item := range items {
+		if usageStr, ok := itemUsage(item.Usage); ok {
+			breakdown = append(breakdown, fmt.Sprintf("%s: %s", item.Name, usageStr))
+		}
+	}
+	sort.Strings(breakdown)
+
+	violation := fmt.Sprintf("%s: %s requested, %s allowed", limitName, requested, limit)
+	if len(rule.Tags) > 0 {
+		violation += fmt.Sprintf(" for resources tagged %s", tagsStr(rule.Tags))
+	}
+	return violation + fmt.Sprintf(" (%s)", strings.Join(breakdown, ", "))
+}
+
+func tagsStr(tags map[string]string) string {
+	var tagStrs []string
+	for key, value := range tags {
+		tagStrs = append(tagStrs, key+"="+value)
+	}
+	sort.Strings(tagStrs)
+	return strings.Join(tagStrs, ",")
+}
+
+func validatePatterns(patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, ErrorInvalidPattern(pattern)
+		}
+	}
+	return patterns, nil
+}
+
+func quantityParser(str string) (interface{}, error) {
+	quantity, err := k8sresource.ParseQuantity(str)
+	if err != nil {
+		return nil, ErrorInvalidQuantity(str)
+	}
+	if quantity.Sign() < 0 {
+		return nil, cr.ErrorMustBeGreaterThanOrEqualTo(str, 0)
+	}
+	return quantity, nil
+}
+
+var ruleValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Apps",
+			StringListValidation: &cr.StringListValidation{
+				Default:   []string{"*"},
+				Validator: validatePatterns,
+			},
+		},
+		{
+			StructField: "Tags",
+			StringMapValidation: &cr.StringMapValidation{
+				Default:    map[string]string{},
+				AllowEmpty: true,
+			},
+		},
+		{
+			StructField: "CPU",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Default: nil,
+			},
+			Parser: quantityParser,
+		},
+		{
+			StructField: "Mem",
+			StringPtrValidation: &cr.StringPtrValidation{
+				Default: nil,
+			},
+			Parser: quantityParser,
+		},
+		{
+			StructField: "GPU",
+			Int64PtrValidation: &cr.Int64PtrValidation{
+				Default:              nil,
+				GreaterThanOrEqualTo: pointer.Int64(0),
+			},
+		},
+		{
+			StructField: "APIReplicas",
+			Int32PtrValidation: &cr.Int32PtrValidation{
+				Default:              nil,
+				GreaterThanOrEqualTo: pointer.Int32(0),
+			},
+		},
+	},
+}
+
+// NewPolicy parses a YAML list of rules
+func NewPolicy(policyBytes []byte) (*Policy, error) {
+	policyData, err := cr.ReadYAMLBytes(policyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*Rule{}
+	rulesInter, errs := cr.StructList(rules, policyData, &cr.StructListValidation{
+		StructValidation: ruleValidation,
+	})
+	if errors.HasErrors(errs) {
+		return nil, errors.FirstError(errs...)
+	}
+
+	return &Policy{Rules: rulesInter.([]*Rule)}, nil
+}
+
+// NewPolicyFromFile reads a YAML list of rules from filePath
+func NewPolicyFromFile(filePath string) (*Policy, error) {
+	policyBytes, err := files.ReadFileBytes(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := NewPolicy(policyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, filePath)
+	}
+	return policy, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/quota"
+)
+
+func item(name string, cpu string, mem string, gpu int64, apiReplicas int32, tags ...map[string]string) *quota.Item {
+	return &quota.Item{
+		Name: name,
+		Tags: tags,
+		Usage: &quota.Usage{
+			CPU:         k8sresource.MustParse(cpu),
+			Mem:         k8sresource.MustParse(mem),
+			GPU:         gpu,
+			APIReplicas: apiReplicas,
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	policy, err := quota.NewPolicy([]byte(`
+- apps: [team-a-*]
+  cpu: "10"
+  mem: 20Gi
+  gpu: 2
+  api_replicas: 4
+- tags: {env: experiment}
+  gpu: 1
+`))
+	require.NoError(t, err)
+
+	items := []*quota.Item{
+		item("data job", "5", "10Gi", 0, 0),
+		item("model dnn", "4", "8Gi", 1, 0, map[string]string{"env": "experiment"}),
+		item("api classifier", "1", "1Gi", 1, 2, map[string]string{"env": "prod"}),
+	}
+	require.NoError(t, policy.Check("team-a-dev", items))
+
+	items = append(items, item("api classifier-2", "2", "1Gi", 0, 3))
+	err = policy.Check("team-a-dev", items)
+	require.Equal(t, "err_quota_exceeded", errors.Kind(err))
+	require.True(t, strings.Contains(err.Error(), "cpu: 12 requested, 10 allowed"))
+	require.True(t, strings.Contains(err.Error(), "api_replicas: 5 requested, 4 allowed (api classifier-2: 3, api classifier: 2)"))
+	require.False(t, strings.Contains(err.Error(), "mem:"))
+	require.False(t, strings.Contains(err.Error(), "gpu:"))
+
+	// Only the tag rule applies to other apps
+	require.NoError(t, policy.Check("team-b-dev", items))
+	items = append(items, item("model cnn", "1", "1Gi", 1, 0, map[string]string{"env": "experiment", "owner": "alice"}))
+	err = policy.Check("team-b-dev", items)
+	require.Equal(t, "err_quota_exceeded", errors.Kind(err))
+	require.True(t, strings.Contains(err.Error(), "gpu: 2 requested, 1 allowed for resources tagged env=experiment (model cnn: 1, model dnn: 1)"))
+}
+
+func TestCheckNoRules(t *testing.T) {
+	policy, err := quota.NewPolicy([]byte(`[]`))
+	require.NoError(t, err)
+	require.NoError(t, policy.Check("iris", []*quota.Item{item("data job", "100", "1Ti", 8, 100)}))
+}
+
+func TestPolicyInvalid(t *testing.T) {
+	_, err := quota.NewPolicy([]byte(`
+- apps: [iris]
+  cpu: lots
+`))
+	require.Error(t, err)
+
+	_, err = quota.NewPolicy([]byte(`
+- apps: ["team-["]
+  cpu: "4"
+`))
+	require.Equal(t, "err_invalid_pattern", errors.Kind(err))
+
+	_, err = quota.NewPolicy([]byte(`
+- apps: [iris]
+  gpu: -1
+`))
+	require.Error(t, err)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac
+
+import (
+	"path"
+
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+)
+
+// Role grants access to apps; each role includes the access of the roles before it
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleViewer
+	RoleDeployer
+	RoleAdmin
+)
+
+var roles = []string{
+	"none",
+	"viewer",
+	"deployer",
+	"admin",
+}
+
+var _ = [1]int{}[int(RoleAdmin)-(len(roles)-1)] // Ensure list length matches
+
+// RoleStrings are the roles which can be granted in a policy
+var RoleStrings = roles[1:]
+
+func RoleFromString(s string) Role {
+	for i := 0; i < len(roles); i++ {
+		if s == roles[i] {
+			return Role(i)
+		}
+	}
+	return RoleNone
+}
+
+func (r Role) String() string {
+	return roles[r]
+}
+
+// Rule grants Role to the Identities for the Apps; both may contain glob patterns (e.g. "team-a-*")
+type Rule struct {
+	Identities []string `json:"identities" yaml:"identities"`
+	Apps       []string `json:"apps" yaml:"apps"`
+	Role       string   `json:"role" yaml:"role"`
+}
+
+// Policy is a list of rules; a caller's role for an app is the highest role granted by any matching rule
+type Policy struct {
+	Rules []*Rule
+}
+
+func (rule *Rule) Matches(identity string, appName string) bool {
+	return matchesAny(rule.Identities, identity) && matchesAny(rule.Apps, appName)
+}
+
+func matchesAny(patterns []string, str string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, str); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Role returns the highest role that the policy grants identity for appName
+func (p *Policy) Role(identity string, appName string) Role {
+	role := RoleNone
+	for _, rule := range p.Rules {
+		if !rule.Matches(identity, appName) {
+			continue
+		}
+		if ruleRole := RoleFromString(rule.Role); ruleRole > role {
+			role = ruleRole
+		}
+	}
+	return role
+}
+
+// Authorize returns an error if identity does not have at least the required role for appName
+func (p *Policy) Authorize(identity string, appName string, required Role) error {
+	if p.Role(identity, appName) < required {
+		return ErrorForbidden(identity, appName, required)
+	}
+	return nil
+}
+
+func validatePatterns(patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, ErrorInvalidPattern(pattern)
+		}
+	}
+	return patterns, nil
+}
+
+var ruleValidation = &cr.StructValidation{
+	StructFieldValidations: []*cr.StructFieldValidation{
+		{
+			StructField: "Identities",
+			StringListValidation: &cr.StringListValidation{
+				Required:  true,
+				Validator: validatePatterns,
+			},
+		},
+		{
+			StructField: "Apps",
+			StringListValidation: &cr.StringListValidation{
+				Required:  true,
+				Validator: validatePatterns,
+			},
+		},
+		{
+			StructField: "Role",
+			StringValidation: &cr.StringValidation{
+				Required:      true,
+				AllowedValues: RoleStrings,
+			},
+		},
+	},
+}
+
+// NewPolicy parses a YAML list of rules
+func NewPolicy(policyBytes []byte) (*Policy, error) {
+	policyData, err := cr.ReadYAMLBytes(policyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*Rule{}
+	rulesInter, errs := cr.StructList(rules, policyData, &cr.StructListValidation{
+		StructValidation: ruleValidation,
+	})
+	if errors.HasErrors(errs) {
+		return nil, errors.FirstError(errs...)
+	}
+
+	return &Policy{Rules: rulesInter.([]*Rule)}, nil
+}
+
+// NewPolicyFromFile reads a YAML list of rules from filePath
+func NewPolicyFromFile(filePath string) (*Policy, error) {
+	policyBytes, err := files.ReadFileBytes(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := NewPolicy(policyBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, filePath)
+	}
+	return policy, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/rbac"
+)
+
+func TestPolicy(t *testing.T) {
+	policy, err := rbac.NewPolicy([]byte(`
+- identities: [alice, bob]
+  apps: [team-a-*]
+  role: deployer
+- identities: [alice]
+  apps: [team-a-prod]
+  role: admin
+- identities: ["*"]
+  apps: ["*"]
+  role: viewer
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, rbac.RoleAdmin, policy.Role("alice", "team-a-prod"))
+	require.Equal(t, rbac.RoleDeployer, policy.Role("alice", "team-a-dev"))
+	require.Equal(t, rbac.RoleDeployer, policy.Role("bob", "team-a-prod"))
+	require.Equal(t, rbac.RoleViewer, policy.Role("bob", "team-b-prod"))
+	require.Equal(t, rbac.RoleViewer, policy.Role("carol", "team-a-prod"))
+
+	require.NoError(t, policy.Authorize("alice", "team-a-prod", rbac.RoleAdmin))
+	require.NoError(t, policy.Authorize("bob", "team-a-dev", rbac.RoleViewer))
+	err = policy.Authorize("bob", "team-a-prod", rbac.RoleAdmin)
+	require.Equal(t, "err_forbidden", errors.Kind(err))
+	err = policy.Authorize("carol", "team-b-prod", rbac.RoleDeployer)
+	require.Equal(t, "err_forbidden", errors.Kind(err))
+}
+
+func TestPolicyNoMatch(t *testing.T) {
+	policy, err := rbac.NewPolicy([]byte(`
+- identities: [alice]
+  apps: [iris]
+  role: admin
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, rbac.RoleNone, policy.Role("bob", "iris"))
+	require.Equal(t, rbac.RoleNone, policy.Role("alice", "mnist"))
+	err = policy.Authorize("bob", "iris", rbac.RoleViewer)
+	require.Equal(t, "err_forbidden", errors.Kind(err))
+}
+
+func TestPolicyInvalid(t *testing.T) {
+	_, err := rbac.NewPolicy([]byte(`
+- identities: [alice]
+  apps: [iris]
+  role: owner
+`))
+	require.Error(t, err)
+
+	_, err = rbac.NewPolicy([]byte(`
+- identities: [alice]
+  apps: ["team-["]
+  role: admin
+`))
+	require.Equal(t, "err_invalid_pattern", errors.Kind(err))
+
+	_, err = rbac.NewPolicy([]byte(`
+- identities: [alice]
+  role: admin
+`))
+	require.Error(t, err)
+}
@@ -40,3 +40,9 @@ var alphaNumericDashUnderscoreRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
 func CheckAlphaNumericDashUnderscore(s string) bool {
 	return alphaNumericDashUnderscoreRegex.MatchString(s)
 }
+
+var lowercaseHexRegex = regexp.MustCompile(`^[0-9a-f]+$`)
+
+func CheckLowercaseHex(s string) bool {
+	return lowercaseHexRegex.MatchString(s)
+}
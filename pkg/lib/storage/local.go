@@ -0,0 +1,242 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+)
+
+// LocalClient stores objects as files under <Dir>/<Bucket>/<key>. Other buckets
+// (e.g. for external data) are read from <Dir>/<bucket>. Workload pods can't read
+// the operator's filesystem, so it is only for testing an operator which runs locally.
+type LocalClient struct {
+	Dir    string
+	Bucket string
+}
+
+func NewLocalClient(dir string, bucket string) (*LocalClient, error) {
+	if err := files.MkdirAll(filepath.Join(dir, bucket), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	return &LocalClient{
+		Dir:    dir,
+		Bucket: bucket,
+	}, nil
+}
+
+func (c *LocalClient) Path(key string) string {
+	return filepath.Join(c.Dir, c.Bucket, key)
+}
+
+func (c *LocalClient) IsFile(key string) (bool, error) {
+	fileInfo, err := os.Stat(c.Path(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, key)
+	}
+
+	return !fileInfo.IsDir(), nil
+}
+
+func (c *LocalClient) IsPrefix(prefix string) (bool, error) {
+	return c.IsPrefixExternal(prefix, c.Bucket)
+}
+
+func (c *LocalClient) IsPrefixExternal(prefix string, bucket string) (bool, error) {
+	paths, err := listPathsWithPrefix(filepath.Join(c.Dir, bucket), prefix)
+	if err != nil {
+		return false, errors.Wrap(err, prefix)
+	}
+	return len(paths) > 0, nil
+}
+
+func (c *LocalClient) UploadBytes(data []byte, key string) error {
+	return c.UploadReader(bytes.NewReader(data), key)
+}
+
+func (c *LocalClient) UploadReader(reader io.Reader, key string) error {
+	path := c.Path(key)
+	if err := files.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.Wrap(err, key)
+	}
+
+	// Write to a temporary file first so that readers never see a partial object
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return errors.Wrap(err, key)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := io.Copy(tmpFile, reader); err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, key)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return errors.Wrap(err, key)
+	}
+
+	return errors.Wrap(os.Rename(tmpFile.Name(), path), key)
+}
+
+func (c *LocalClient) ReadBytes(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(c.Path(key))
+	if os.IsNotExist(err) {
+		return nil, errors.WithStack(ErrorNotFound(key))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, key)
+	}
+	return data, nil
+}
+
+func (c *LocalClient) ReadToWriter(key string, writer io.Writer) error {
+	file, err := os.Open(c.Path(key))
+	if os.IsNotExist(err) {
+		return errors.WithStack(ErrorNotFound(key))
+	}
+	if err != nil {
+		return errors.Wrap(err, key)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(writer, file)
+	return errors.Wrap(err, key)
+}
+
+func (c *LocalClient) ListKeys(prefix string) ([]string, error) {
+	bucketDir := filepath.Join(c.Dir, c.Bucket)
+	paths, err := listPathsWithPrefix(bucketDir, prefix)
+	if err != nil {
+		return nil, errors.Wrap(err, prefix)
+	}
+
+	var keys []string
+	for _, path := range paths {
+		// Skip uploads which are in progress
+		if strings.HasPrefix(filepath.Base(path), ".tmp-") {
+			continue
+		}
+		key, err := filepath.Rel(bucketDir, path)
+		if err != nil {
+			return nil, errors.Wrap(err, prefix)
+		}
+		keys = append(keys, filepath.ToSlash(key))
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *LocalClient) ListObjects(prefix string) ([]*Object, error) {
+	keys, err := c.ListKeys(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*Object
+	for _, key := range keys {
+		fileInfo, err := os.Stat(c.Path(key))
+		if os.IsNotExist(err) {
+			continue // Deleted since it was listed
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, key)
+		}
+		objects = append(objects, &Object{
+			Key:          key,
+			Size:         fileInfo.Size(),
+			LastModified: fileInfo.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+func (c *LocalClient) DeleteByPrefix(prefix string, continueIfFailure bool) error {
+	paths, err := listPathsWithPrefix(filepath.Join(c.Dir, c.Bucket), prefix)
+	if err != nil {
+		return errors.Wrap(err, prefix)
+	}
+
+	var subErr error
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			subErr = err
+			if !continueIfFailure {
+				break
+			}
+		}
+	}
+
+	if subErr != nil {
+		return errors.Wrap(subErr, prefix)
+	}
+	return nil
+}
+
+func (c *LocalClient) DeleteKeys(keys []string) error {
+	for _, key := range keys {
+		if err := os.Remove(c.Path(key)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, key)
+		}
+	}
+	return nil
+}
+
+// listPathsWithPrefix mimics S3 prefix matching: it returns the paths of all files
+// under bucketDir whose key (path relative to bucketDir) starts with prefix
+func listPathsWithPrefix(bucketDir string, prefix string) ([]string, error) {
+	fullPrefix := filepath.Join(bucketDir, prefix)
+	if strings.HasSuffix(prefix, "/") {
+		fullPrefix += "/"
+	}
+
+	searchDir := fullPrefix
+	if !strings.HasSuffix(fullPrefix, "/") {
+		searchDir = filepath.Dir(fullPrefix)
+	}
+
+	var paths []string
+	err := filepath.Walk(searchDir, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !fileInfo.IsDir() && strings.HasPrefix(path, fullPrefix) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/storage"
+)
+
+func TestLocalClient(t *testing.T) {
+	tmpDir, err := files.TmpDir()
+	defer os.RemoveAll(tmpDir)
+	require.NoError(t, err)
+
+	client, err := storage.NewLocalClient(tmpDir, "bucket")
+	require.NoError(t, err)
+
+	_, err = client.ReadBytes("apps/app/key")
+	require.True(t, storage.IsNotFoundErr(err))
+
+	isFile, err := client.IsFile("apps/app/key")
+	require.NoError(t, err)
+	require.False(t, isFile)
+
+	require.NoError(t, client.UploadBytes([]byte("data"), "apps/app/key"))
+	require.NoError(t, client.UploadBytes([]byte("data2"), "apps/app/dir/key"))
+	require.NoError(t, client.UploadBytes([]byte("data3"), "apps/app2/key"))
+
+	data, err := client.ReadBytes("apps/app/key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), data)
+
+	require.NoError(t, client.UploadBytes([]byte("updated"), "apps/app/key"))
+	data, err = client.ReadBytes("apps/app/key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated"), data)
+
+	require.NoError(t, client.UploadReader(strings.NewReader("streamed"), "apps/app/stream"))
+	buf := new(bytes.Buffer)
+	require.NoError(t, client.ReadToWriter("apps/app/stream", buf))
+	require.Equal(t, "streamed", buf.String())
+	require.True(t, storage.IsNotFoundErr(client.ReadToWriter("apps/app/missing", buf)))
+	require.NoError(t, client.DeleteKeys([]string{"apps/app/stream"}))
+
+	isFile, err = client.IsFile("apps/app/key")
+	require.NoError(t, err)
+	require.True(t, isFile)
+
+	isFile, err = client.IsFile("apps/app/dir")
+	require.NoError(t, err)
+	require.False(t, isFile)
+
+	for prefix, expected := range map[string]bool{
+		"apps/app/":     true,
+		"apps/app":      true,
+		"apps/ap":       true,
+		"apps/app/k":    true,
+		"apps/app/dir/": true,
+		"apps/app3":     false,
+		"apps/app/x":    false,
+		"other/":        false,
+	} {
+		isPrefix, err := client.IsPrefix(prefix)
+		require.NoError(t, err)
+		require.Equal(t, expected, isPrefix, prefix)
+	}
+
+	keys, err := client.ListKeys("apps/app/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"apps/app/dir/key", "apps/app/key"}, keys)
+
+	keys, err = client.ListKeys("apps/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"apps/app/dir/key", "apps/app/key", "apps/app2/key"}, keys)
+
+	keys, err = client.ListKeys("other/")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	objects, err := client.ListObjects("apps/app/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	require.Equal(t, "apps/app/dir/key", objects[0].Key)
+	require.Equal(t, int64(5), objects[0].Size)
+	require.Equal(t, "apps/app/key", objects[1].Key)
+	require.Equal(t, int64(7), objects[1].Size)
+	require.False(t, objects[1].LastModified.IsZero())
+
+	isPrefix, err := client.IsPrefixExternal("app/", "bucket2")
+	require.NoError(t, err)
+	require.False(t, isPrefix)
+
+	require.NoError(t, client.DeleteByPrefix("apps/app/", false))
+
+	isPrefix, err = client.IsPrefix("apps/app/")
+	require.NoError(t, err)
+	require.False(t, isPrefix)
+
+	isFile, err = client.IsFile("apps/app2/key")
+	require.NoError(t, err)
+	require.True(t, isFile)
+
+	require.NoError(t, client.DeleteByPrefix("missing/", false))
+
+	require.NoError(t, client.UploadBytes([]byte("data4"), "apps/app2/key2"))
+	require.NoError(t, client.DeleteKeys([]string{"apps/app2/key", "apps/app2/missing"}))
+	keys, err = client.ListKeys("apps/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"apps/app2/key2"}, keys)
+}
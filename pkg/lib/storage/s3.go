@@ -0,0 +1,303 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+type S3Client struct {
+	Bucket   string
+	s3Client *s3.S3
+	// S3-compatible stores often don't support server-side encryption
+	encrypt bool
+}
+
+func NewS3Client(bucket string, region string) (*S3Client, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:     aws.String(region),
+		DisableSSL: aws.Bool(false),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &S3Client{
+		Bucket:   bucket,
+		s3Client: s3.New(sess),
+		encrypt:  true,
+	}, nil
+}
+
+// NewS3CompatibleClient connects to a store which implements the S3 API at endpoint (e.g. MinIO)
+func NewS3CompatibleClient(bucket string, region string, endpoint string) (*S3Client, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(region),
+		Endpoint:         aws.String(endpoint),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &S3Client{
+		Bucket:   bucket,
+		s3Client: s3.New(sess),
+		encrypt:  false,
+	}, nil
+}
+
+func (c *S3Client) Path(key string) string {
+	return "s3://" + filepath.Join(c.Bucket, key)
+}
+
+func (c *S3Client) IsFile(key string) (bool, error) {
+	_, err := c.s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(c.Bucket),
+		Key:    aws.String(key),
+	})
+
+	if isS3NotFoundErr(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, key)
+	}
+
+	return true, nil
+}
+
+func (c *S3Client) IsPrefix(prefix string) (bool, error) {
+	return c.IsPrefixExternal(prefix, c.Bucket)
+}
+
+func (c *S3Client) IsPrefixExternal(prefix string, bucket string) (bool, error) {
+	out, err := c.s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+
+	if err != nil {
+		return false, errors.Wrap(err, prefix)
+	}
+
+	hasPrefix := *out.KeyCount > 0
+	return hasPrefix, nil
+}
+
+func (c *S3Client) UploadBytes(data []byte, key string) error {
+	input := &s3.PutObjectInput{
+		Body:               bytes.NewReader(data),
+		Key:                aws.String(key),
+		Bucket:             aws.String(c.Bucket),
+		ACL:                aws.String("private"),
+		ContentDisposition: aws.String("attachment"),
+	}
+	if c.encrypt {
+		input.ServerSideEncryption = aws.String("AES256")
+	}
+
+	_, err := c.s3Client.PutObject(input)
+	return errors.Wrap(err, key)
+}
+
+// UploadReader uploads reader's contents in parts, since S3 requires the size of single-part uploads in advance
+func (c *S3Client) UploadReader(reader io.Reader, key string) error {
+	input := &s3manager.UploadInput{
+		Body:               reader,
+		Key:                aws.String(key),
+		Bucket:             aws.String(c.Bucket),
+		ACL:                aws.String("private"),
+		ContentDisposition: aws.String("attachment"),
+	}
+	if c.encrypt {
+		input.ServerSideEncryption = aws.String("AES256")
+	}
+
+	_, err := s3manager.NewUploaderWithClient(c.s3Client).Upload(input)
+	return errors.Wrap(err, key)
+}
+
+func (c *S3Client) ReadBytes(key string) ([]byte, error) {
+	response, err := c.s3Client.GetObject(&s3.GetObjectInput{
+		Key:    aws.String(key),
+		Bucket: aws.String(c.Bucket),
+	})
+
+	if isS3NotFoundErr(err) {
+		return nil, errors.WithStack(ErrorNotFound(key))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, key)
+	}
+
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(response.Body)
+	return buf.Bytes(), nil
+}
+
+func (c *S3Client) ReadToWriter(key string, writer io.Writer) error {
+	response, err := c.s3Client.GetObject(&s3.GetObjectInput{
+		Key:    aws.String(key),
+		Bucket: aws.String(c.Bucket),
+	})
+
+	if isS3NotFoundErr(err) {
+		return errors.WithStack(ErrorNotFound(key))
+	}
+	if err != nil {
+		return errors.Wrap(err, key)
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(writer, response.Body)
+	return errors.Wrap(err, key)
+}
+
+func (c *S3Client) ListKeys(prefix string) ([]string, error) {
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(c.Bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int64(1000),
+	}
+
+	var keys []string
+	err := c.s3Client.ListObjectsV2Pages(listObjectsInput,
+		func(listObjectsOutput *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range listObjectsOutput.Contents {
+				keys = append(keys, *object.Key)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, prefix)
+	}
+
+	return keys, nil
+}
+
+func (c *S3Client) ListObjects(prefix string) ([]*Object, error) {
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(c.Bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int64(1000),
+	}
+
+	var objects []*Object
+	err := c.s3Client.ListObjectsV2Pages(listObjectsInput,
+		func(listObjectsOutput *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range listObjectsOutput.Contents {
+				objects = append(objects, &Object{
+					Key:          *object.Key,
+					Size:         aws.Int64Value(object.Size),
+					LastModified: aws.TimeValue(object.LastModified),
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, errors.Wrap(err, prefix)
+	}
+
+	return objects, nil
+}
+
+func (c *S3Client) DeleteByPrefix(prefix string, continueIfFailure bool) error {
+	listObjectsInput := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(c.Bucket),
+		Prefix:  aws.String(prefix),
+		MaxKeys: aws.Int64(1000),
+	}
+
+	var subErr error
+
+	err := c.s3Client.ListObjectsV2Pages(listObjectsInput,
+		func(listObjectsOutput *s3.ListObjectsV2Output, lastPage bool) bool {
+			deleteObjects := make([]*s3.ObjectIdentifier, len(listObjectsOutput.Contents))
+			for i, object := range listObjectsOutput.Contents {
+				deleteObjects[i] = &s3.ObjectIdentifier{Key: object.Key}
+			}
+			deleteObjectsInput := &s3.DeleteObjectsInput{
+				Bucket: aws.String(c.Bucket),
+				Delete: &s3.Delete{
+					Objects: deleteObjects,
+					Quiet:   aws.Bool(true),
+				},
+			}
+			_, newSubErr := c.s3Client.DeleteObjects(deleteObjectsInput)
+			if newSubErr != nil {
+				subErr = newSubErr
+				if !continueIfFailure {
+					return false
+				}
+			}
+			return true
+		})
+
+	if subErr != nil {
+		return errors.Wrap(subErr, prefix)
+	}
+	return errors.Wrap(err, prefix)
+}
+
+func (c *S3Client) DeleteKeys(keys []string) error {
+	// DeleteObjects accepts up to 1000 keys per request
+	for start := 0; start < len(keys); start += 1000 {
+		end := start + 1000
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		deleteObjects := make([]*s3.ObjectIdentifier, end-start)
+		for i, key := range keys[start:end] {
+			deleteObjects[i] = &s3.ObjectIdentifier{Key: aws.String(key)}
+		}
+		deleteObjectsOutput, err := c.s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(c.Bucket),
+			Delete: &s3.Delete{
+				Objects: deleteObjects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, keys[start])
+		}
+		if len(deleteObjectsOutput.Errors) > 0 {
+			deleteErr := deleteObjectsOutput.Errors[0]
+			return errors.Wrap(errors.New(aws.StringValue(deleteErr.Message)), aws.StringValue(deleteErr.Key))
+		}
+	}
+	return nil
+}
+
+func isS3NotFoundErr(err error) bool {
+	awsErr, ok := errors.Cause(err).(awserr.Error)
+	if !ok {
+		return false
+	}
+	return awsErr.Code() == "NoSuchKey" || awsErr.Code() == "NotFound"
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io"
+	"time"
+)
+
+const (
+	TypeS3           = "s3"
+	TypeS3Compatible = "s3_compatible"
+	TypeLocal        = "local"
+)
+
+var Types = []string{
+	TypeS3,
+	TypeS3Compatible,
+	TypeLocal,
+}
+
+// Client reads and writes objects in a single bucket. Implementations must return
+// an error which satisfies IsNotFoundErr() when reading a key that does not exist.
+type Client interface {
+	// Path returns the full path of key (e.g. s3://bucket/key) for use by workloads
+	Path(key string) string
+	IsFile(key string) (bool, error)
+	IsPrefix(prefix string) (bool, error)
+	// IsPrefixExternal checks for a prefix in a bucket other than the client's own
+	IsPrefixExternal(prefix string, bucket string) (bool, error)
+	UploadBytes(data []byte, key string) error
+	// UploadReader uploads reader's contents without buffering them in memory
+	UploadReader(reader io.Reader, key string) error
+	ReadBytes(key string) ([]byte, error)
+	// ReadToWriter copies the object's contents to writer without buffering them in memory
+	ReadToWriter(key string, writer io.Writer) error
+	// ListKeys returns the keys of all objects which start with prefix, in lexicographic order
+	ListKeys(prefix string) ([]string, error)
+	// ListObjects is like ListKeys, but includes each object's size and modification time
+	ListObjects(prefix string) ([]*Object, error)
+	DeleteByPrefix(prefix string, continueIfFailure bool) error
+	// DeleteKeys deletes the objects with the given keys; keys which don't exist are ignored
+	DeleteKeys(keys []string) error
+}
+
+type Object struct {
+	Key          string
+	Size         int64 // bytes
+	LastModified time.Time
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// objectLabels implements labels.Labels over an object's fields, so that success and failure conditions
+// (e.g. "status.succeeded > 0") can be evaluated with label selectors, as Argo does
+type objectLabels struct {
+	obj map[string]interface{}
+}
+
+// Has returns whether the field at path exists
+func (l objectLabels) Has(path string) bool {
+	_, ok := lookup(l.obj, path)
+	return ok
+}
+
+// Get returns the value of the field at path, or "" if it does not exist
+func (l objectLabels) Get(path string) string {
+	value, ok := lookup(l.obj, path)
+	if !ok {
+		return ""
+	}
+
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		valueJSON, err := json.Marshal(typed)
+		if err != nil {
+			return ""
+		}
+		return string(valueJSON)
+	}
+}
+
+// lookup finds the field at a dot-separated path; list elements are selected by index
+func lookup(obj map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = obj
+	for _, field := range strings.Split(path, ".") {
+		switch typed := current.(type) {
+		case map[string]interface{}:
+			value, ok := typed[field]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 0 || index >= len(typed) {
+				return nil, false
+			}
+			current = typed[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func parseCondition(condition string) (labels.Requirements, error) {
+	if condition == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(condition)
+	if err != nil {
+		return nil, ErrorInvalidCondition(condition, err)
+	}
+	requirements, _ := selector.Requirements()
+	return requirements, nil
+}
+
+// EvaluateConditions returns whether obj satisfies successCondition, or an error if it satisfies
+// failureCondition. As with Argo, an empty success condition is satisfied by any object.
+func EvaluateConditions(obj map[string]interface{}, successCondition string, failureCondition string) (bool, error) {
+	failureReqs, err := parseCondition(failureCondition)
+	if err != nil {
+		return false, err
+	}
+	successReqs, err := parseCondition(successCondition)
+	if err != nil {
+		return false, err
+	}
+
+	ls := objectLabels{obj: obj}
+	for _, req := range failureReqs {
+		if req.Matches(ls) {
+			return false, ErrorFailureCondition(req.String())
+		}
+	}
+	for _, req := range successReqs {
+		if !req.Matches(ls) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/workflow"
+)
+
+const (
+	sparkSuccessCondition = "status.applicationState.state in (COMPLETED)"
+	sparkFailureCondition = "status.applicationState.state in (FAILED,SUBMISSION_FAILED,UNKNOWN)"
+	jobSuccessCondition   = "status.succeeded > 0"
+	jobFailureCondition   = "status.failed > 0"
+)
+
+func sparkApplication(state string) map[string]interface{} {
+	return map[string]interface{}{
+		"kind": "SparkApplication",
+		"status": map[string]interface{}{
+			"applicationState": map[string]interface{}{
+				"state": state,
+			},
+		},
+	}
+}
+
+func job(status map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":   "Job",
+		"status": status,
+	}
+}
+
+func TestEvaluateConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		obj              map[string]interface{}
+		successCondition string
+		failureCondition string
+		succeeded        bool
+		failed           bool
+	}{
+		{"spark submitted", sparkApplication("SUBMITTED"), sparkSuccessCondition, sparkFailureCondition, false, false},
+		{"spark running", sparkApplication("RUNNING"), sparkSuccessCondition, sparkFailureCondition, false, false},
+		{"spark completed", sparkApplication("COMPLETED"), sparkSuccessCondition, sparkFailureCondition, true, false},
+		{"spark failed", sparkApplication("FAILED"), sparkSuccessCondition, sparkFailureCondition, false, true},
+		{"spark submission failed", sparkApplication("SUBMISSION_FAILED"), sparkSuccessCondition, sparkFailureCondition, false, true},
+		{"spark unknown", sparkApplication("UNKNOWN"), sparkSuccessCondition, sparkFailureCondition, false, true},
+		{"spark missing status", map[string]interface{}{"kind": "SparkApplication"}, sparkSuccessCondition, sparkFailureCondition, false, false},
+		{"spark missing state", job(map[string]interface{}{}), sparkSuccessCondition, sparkFailureCondition, false, false},
+
+		{"job active", job(map[string]interface{}{"active": int64(1)}), jobSuccessCondition, jobFailureCondition, false, false},
+		{"job succeeded", job(map[string]interface{}{"succeeded": int64(1)}), jobSuccessCondition, jobFailureCondition, true, false},
+		{"job succeeded (float)", job(map[string]interface{}{"succeeded": float64(2)}), jobSuccessCondition, jobFailureCondition, true, false},
+		{"job failed", job(map[string]interface{}{"failed": int64(1)}), jobSuccessCondition, jobFailureCondition, false, true},
+		{"job succeeded and failed", job(map[string]interface{}{"succeeded": int64(1), "failed": int64(1)}), jobSuccessCondition, jobFailureCondition, false, true},
+		{"job zero counts", job(map[string]interface{}{"succeeded": int64(0), "failed": int64(0)}), jobSuccessCondition, jobFailureCondition, false, false},
+		{"job missing status", map[string]interface{}{"kind": "Job"}, jobSuccessCondition, jobFailureCondition, false, false},
+		{"job null status", job(nil), jobSuccessCondition, jobFailureCondition, false, false},
+
+		{"deployment available", map[string]interface{}{"status": map[string]interface{}{"replicas": int64(1)}}, "!status.unavailableReplicas", "", true, false},
+		{"deployment unavailable", map[string]interface{}{"status": map[string]interface{}{"unavailableReplicas": int64(1)}}, "!status.unavailableReplicas", "", false, false},
+
+		{"no conditions", job(nil), "", "", true, false},
+		{"only failure condition", job(map[string]interface{}{"active": int64(1)}), "", jobFailureCondition, true, false},
+		{"bool field", map[string]interface{}{"spec": map[string]interface{}{"suspend": true}}, "spec.suspend=true", "", true, false},
+	} {
+		succeeded, err := workflow.EvaluateConditions(tc.obj, tc.successCondition, tc.failureCondition)
+		if tc.failed {
+			require.Error(t, err, tc.name)
+			require.Equal(t, workflow.ErrFailureCondition, errors.Cause(err).(workflow.Error).Kind, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+		require.Equal(t, tc.succeeded, succeeded, tc.name)
+	}
+}
+
+func TestEvaluateConditionsListIndex(t *testing.T) {
+	pod := map[string]interface{}{
+		"status": map[string]interface{}{
+			"containerStatuses": []interface{}{
+				map[string]interface{}{"name": "main", "ready": true},
+				map[string]interface{}{"name": "sidecar", "ready": false},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		condition string
+		succeeded bool
+	}{
+		{"status.containerStatuses.0.ready=true", true},
+		{"status.containerStatuses.1.ready=true", false},
+		{"status.containerStatuses.1.name=sidecar", true},
+		{"status.containerStatuses.2", false},
+		{"!status.containerStatuses.2", true},
+		{"status.containerStatuses.-1", false},
+		{"status.containerStatuses.first", false},
+		{"status.containerStatuses.0.ready.value", false},
+	} {
+		succeeded, err := workflow.EvaluateConditions(pod, tc.condition, "")
+		require.NoError(t, err, tc.condition)
+		require.Equal(t, tc.succeeded, succeeded, tc.condition)
+	}
+}
+
+func TestEvaluateConditionsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		successCondition string
+		failureCondition string
+	}{
+		{"status.succeeded >", ""},
+		{"", "status.failed in (FAILED"},
+	} {
+		_, err := workflow.EvaluateConditions(job(nil), tc.successCondition, tc.failureCondition)
+		require.Error(t, err)
+		require.Equal(t, workflow.ErrInvalidCondition, errors.Cause(err).(workflow.Error).Kind)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+)
+
+// StartFunc starts a task whose dependencies have succeeded, and returns its node
+type StartFunc func(task awfv1.DAGTask, template *awfv1.Template) awfv1.NodeStatus
+
+// CheckFunc checks a running node (updating its phase), and returns whether its phase changed
+type CheckFunc func(node *awfv1.NodeStatus, template *awfv1.Template) (bool, error)
+
+// Advance starts wf's tasks whose dependencies have succeeded, checks its running nodes, and completes the
+// workflow once no more tasks can run. It returns whether wf was updated.
+func Advance(wf *awfv1.Workflow, start StartFunc, check CheckFunc) (bool, error) {
+	if wf.Status.Nodes == nil {
+		wf.Status.Nodes = map[string]awfv1.NodeStatus{}
+	}
+
+	templates := make(map[string]*awfv1.Template)
+	for i := range wf.Spec.Templates {
+		templates[wf.Spec.Templates[i].Name] = &wf.Spec.Templates[i]
+	}
+
+	updated := false
+	for _, task := range wf.Spec.Templates[0].DAG.Tasks {
+		node, started := wf.Status.Nodes[task.Name]
+		if !started {
+			if !DependenciesSucceeded(wf, task) {
+				continue
+			}
+			node = start(task, templates[task.Template])
+			updated = true
+		}
+
+		if node.Phase == awfv1.NodeRunning {
+			nodeUpdated, err := check(&node, templates[task.Template])
+			if err != nil {
+				return false, err
+			}
+			updated = updated || nodeUpdated
+		}
+
+		wf.Status.Nodes[task.Name] = node
+	}
+
+	if IsComplete(wf) {
+		complete(wf)
+		updated = true
+	}
+
+	return updated, nil
+}
+
+func DependenciesSucceeded(wf *awfv1.Workflow, task awfv1.DAGTask) bool {
+	for _, dependency := range task.Dependencies {
+		if wf.Status.Nodes[dependency].Phase != awfv1.NodeSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// IsComplete returns whether no tasks are running and none can be started
+func IsComplete(wf *awfv1.Workflow) bool {
+	for _, task := range wf.Spec.Templates[0].DAG.Tasks {
+		node, started := wf.Status.Nodes[task.Name]
+		if started && node.Phase == awfv1.NodeRunning {
+			return false
+		}
+		if !started && DependenciesSucceeded(wf, task) {
+			return false
+		}
+	}
+	return true
+}
+
+func complete(wf *awfv1.Workflow) {
+	wf.Status.Phase = awfv1.NodeSucceeded
+	wf.Status.FinishedAt = metav1.Now()
+
+	for _, task := range wf.Spec.Templates[0].DAG.Tasks {
+		if wf.Status.Nodes[task.Name].Phase == awfv1.NodeFailed {
+			wf.Status.Phase = awfv1.NodeFailed
+			wf.Status.Message = fmt.Sprintf("child '%s' failed", task.Name)
+			return
+		}
+	}
+}
+
+// ParseManifest substitutes the workflow's name and UID (used in owner references) into manifest, and parses it
+func ParseManifest(wf *awfv1.Workflow, manifest string) (*unstructured.Unstructured, error) {
+	manifest = strings.Replace(manifest, "{{workflow.name}}", wf.Name, -1)
+	manifest = strings.Replace(manifest, "{{workflow.uid}}", string(wf.UID), -1)
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON([]byte(manifest)); err != nil {
+		return nil, errors.Wrap(err, "workflow", wf.Name, "manifest")
+	}
+	return obj, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow_test
+
+import (
+	"testing"
+
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cortexlabs/cortex/pkg/lib/workflow"
+)
+
+func TestParseManifest(t *testing.T) {
+	wf := &awfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf-abc", UID: "1234"}}
+
+	obj, err := workflow.ParseManifest(wf, `{
+		"apiVersion": "batch/v1",
+		"kind": "Job",
+		"metadata": {
+			"name": "job",
+			"ownerReferences": [{"name": "{{workflow.name}}", "uid": "{{workflow.uid}}"}]
+		}
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, "Job", obj.GetKind())
+	require.Equal(t, "batch/v1", obj.GetAPIVersion())
+	require.Equal(t, "job", obj.GetName())
+	require.Len(t, obj.GetOwnerReferences(), 1)
+	require.Equal(t, "wf-abc", obj.GetOwnerReferences()[0].Name)
+	require.Equal(t, "1234", string(obj.GetOwnerReferences()[0].UID))
+
+	_, err = workflow.ParseManifest(wf, `{"kind": "Job"`)
+	require.Error(t, err)
+
+	_, err = workflow.ParseManifest(wf, `{"metadata": {"name": "job"}}`)
+	require.Error(t, err)
+}
+
+func testWorkflow(dependencies map[string][]string, order []string) *awfv1.Workflow {
+	wf := &awfv1.Workflow{
+		Spec: awfv1.WorkflowSpec{
+			Templates: []awfv1.Template{{Name: "dag", DAG: &awfv1.DAGTemplate{}}},
+		},
+	}
+	for _, name := range order {
+		wf.Spec.Templates[0].DAG.Tasks = append(wf.Spec.Templates[0].DAG.Tasks, awfv1.DAGTask{
+			Name:         name,
+			Template:     name,
+			Dependencies: dependencies[name],
+		})
+		wf.Spec.Templates = append(wf.Spec.Templates, awfv1.Template{Name: name})
+	}
+	return wf
+}
+
+// advance runs one pass of workflow.Advance, in which the tasks in results finish with the given phase,
+// and returns the tasks which were started
+func advance(t *testing.T, wf *awfv1.Workflow, results map[string]awfv1.NodePhase) []string {
+	var started []string
+	updated, err := workflow.Advance(wf,
+		func(task awfv1.DAGTask, template *awfv1.Template) awfv1.NodeStatus {
+			require.Equal(t, task.Name, template.Name)
+			started = append(started, task.Name)
+			return awfv1.NodeStatus{Name: task.Name, TemplateName: template.Name, Phase: awfv1.NodeRunning}
+		},
+		func(node *awfv1.NodeStatus, template *awfv1.Template) (bool, error) {
+			phase, ok := results[node.TemplateName]
+			if !ok {
+				return false, nil
+			}
+			node.Phase = phase
+			return true, nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, len(started) > 0 || len(results) > 0 || wf.Status.Phase != "", updated)
+	return started
+}
+
+func TestAdvance(t *testing.T) {
+	// a -> (b, c) -> d, and e, which has no dependencies
+	dependencies := map[string][]string{
+		"b": {"a"},
+		"c": {"a"},
+		"d": {"c", "b"},
+	}
+	wf := testWorkflow(dependencies, []string{"d", "c", "b", "a", "e"})
+
+	require.ElementsMatch(t, []string{"a", "e"}, advance(t, wf, nil))
+	require.False(t, workflow.IsComplete(wf))
+
+	require.Empty(t, advance(t, wf, nil))
+
+	// b and c precede a in the task list, so they are started in the pass after a succeeds; d waits for both
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"e": awfv1.NodeSucceeded}))
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"a": awfv1.NodeSucceeded}))
+	require.ElementsMatch(t, []string{"b", "c"}, advance(t, wf, nil))
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"b": awfv1.NodeSucceeded}))
+	require.Equal(t, awfv1.NodeRunning, wf.Status.Nodes["c"].Phase)
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"c": awfv1.NodeSucceeded}))
+	require.False(t, workflow.IsComplete(wf))
+	require.Equal(t, []string{"d"}, advance(t, wf, nil))
+	require.Equal(t, awfv1.NodePhase(""), wf.Status.Phase)
+
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"d": awfv1.NodeSucceeded}))
+	require.True(t, workflow.IsComplete(wf))
+	require.Equal(t, awfv1.NodeSucceeded, wf.Status.Phase)
+	require.False(t, wf.Status.FinishedAt.IsZero())
+}
+
+func TestAdvanceFailure(t *testing.T) {
+	dependencies := map[string][]string{
+		"b": {"a"},
+		"c": {"b"},
+	}
+	wf := testWorkflow(dependencies, []string{"a", "b", "c", "d"})
+
+	require.Equal(t, []string{"a", "d"}, advance(t, wf, nil))
+
+	// b fails while d is running, so c is never started, and the workflow completes once d finishes
+	require.Equal(t, []string{"b"}, advance(t, wf, map[string]awfv1.NodePhase{"a": awfv1.NodeSucceeded}))
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"b": awfv1.NodeFailed}))
+	require.False(t, workflow.IsComplete(wf))
+
+	require.Empty(t, advance(t, wf, map[string]awfv1.NodePhase{"d": awfv1.NodeSucceeded}))
+	require.True(t, workflow.IsComplete(wf))
+	require.NotContains(t, wf.Status.Nodes, "c")
+	require.Equal(t, awfv1.NodeFailed, wf.Status.Phase)
+	require.Equal(t, "child 'b' failed", wf.Status.Message)
+}
@@ -23,8 +23,12 @@ import (
 	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	wfclientset "github.com/argoproj/argo/pkg/client/clientset/versioned"
 	twfv1 "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	wfinformers "github.com/argoproj/argo/pkg/client/informers/externalversions"
+	wflisters "github.com/argoproj/argo/pkg/client/listers/workflow/v1alpha1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/maps"
@@ -32,11 +36,15 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/dag"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
 )
 
 var (
-	workflowClient twfv1.WorkflowInterface
+	workflowClient   twfv1.WorkflowInterface
+	informerFactory  wfinformers.SharedInformerFactory
+	workflowInformer cache.SharedIndexInformer
+	workflowLister   wflisters.WorkflowLister
 )
 
 var doneStates = []string{
@@ -53,6 +61,50 @@ var runningStates = []string{
 func init() {
 	wfcs := wfclientset.NewForConfigOrDie(k8s.Config)
 	workflowClient = wfcs.ArgoprojV1alpha1().Workflows(cc.Namespace)
+
+	informerFactory = wfinformers.NewFilteredSharedInformerFactory(wfcs, k8s.InformerResyncPeriod, cc.Namespace, nil)
+	workflowInformer = informerFactory.Argoproj().V1alpha1().Workflows().Informer()
+	workflowLister = informerFactory.Argoproj().V1alpha1().Workflows().Lister()
+}
+
+// IsNative returns whether workflows are run by the operator's DAG executor instead of by Argo
+func IsNative() bool {
+	return cc.WorkflowEngine == cc.WorkflowEngineNative
+}
+
+// StartInformers starts watching workflows, and waits until the cache is populated
+func StartInformers(stopCh <-chan struct{}) error {
+	if IsNative() {
+		return dag.StartInformers(stopCh)
+	}
+	informerFactory.Start(stopCh)
+	return k8s.WaitForInformers("argo", stopCh, workflowInformer.HasSynced)
+}
+
+func OnWorkflowChange(onChange func()) {
+	if IsNative() {
+		dag.OnWorkflowChange(onChange)
+		return
+	}
+	workflowInformer.AddEventHandler(k8s.ChangeHandler(onChange))
+}
+
+// ListCached lists workflows from the informer's cache instead of the API server
+func ListCached() ([]awfv1.Workflow, error) {
+	if IsNative() {
+		return dag.ListCached()
+	}
+	wfPtrs, err := workflowLister.Workflows(cc.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	wfs := make([]awfv1.Workflow, len(wfPtrs))
+	for i, wfPtr := range wfPtrs {
+		// Objects in the cache are shared, so they must not be modified
+		wfs[i] = *wfPtr.DeepCopy()
+	}
+	return wfs, nil
 }
 
 type WorkflowTask struct {
@@ -128,11 +180,18 @@ func AddTask(wf *awfv1.Workflow, task *WorkflowTask) *awfv1.Workflow {
 	return wf
 }
 
+// EnableGC makes the workflow the owner of spec, so that it is deleted along with the workflow
 func EnableGC(spec metav1.Object) {
+	apiVersion, kind := "argoproj.io/v1alpha1", "Workflow"
+	if IsNative() {
+		// Native workflows are stored in config maps
+		apiVersion, kind = "v1", "ConfigMap"
+	}
+
 	ownerReferences := spec.GetOwnerReferences()
 	ownerReferences = append(ownerReferences, metav1.OwnerReference{
-		APIVersion:         "argoproj.io/v1alpha1",
-		Kind:               "Workflow",
+		APIVersion:         apiVersion,
+		Kind:               kind,
 		Name:               "{{workflow.name}}",
 		UID:                "{{workflow.uid}}",
 		BlockOwnerDeletion: pointer.Bool(false),
@@ -148,6 +207,9 @@ func NumTasks(wf *awfv1.Workflow) int {
 }
 
 func Run(wf *awfv1.Workflow) error {
+	if IsNative() {
+		return dag.Run(wf)
+	}
 	_, err := workflowClient.Create(wf)
 	if err != nil {
 		return errors.WithStack(err)
@@ -156,6 +218,9 @@ func Run(wf *awfv1.Workflow) error {
 }
 
 func List(opts *metav1.ListOptions) ([]awfv1.Workflow, error) {
+	if IsNative() {
+		return dag.List(opts)
+	}
 	if opts == nil {
 		opts = &metav1.ListOptions{}
 	}
@@ -166,6 +231,15 @@ func List(opts *metav1.ListOptions) ([]awfv1.Workflow, error) {
 	return wfList.Items, nil
 }
 
+// Ping checks that Argo's workflow API is reachable
+func Ping() error {
+	if IsNative() {
+		return dag.Ping()
+	}
+	_, err := workflowClient.List(metav1.ListOptions{Limit: 1})
+	return errors.WithStack(err)
+}
+
 func ListByLabels(labels map[string]string) ([]awfv1.Workflow, error) {
 	opts := &metav1.ListOptions{
 		LabelSelector: k8s.LabelSelector(labels),
@@ -206,6 +280,9 @@ func ListDone(labels ...map[string]string) ([]awfv1.Workflow, error) {
 }
 
 func Delete(wfName string) (bool, error) {
+	if IsNative() {
+		return dag.Delete(wfName)
+	}
 	err := workflowClient.Delete(wfName, &metav1.DeleteOptions{})
 	if k8serrors.IsNotFound(err) {
 		return false, nil
@@ -304,6 +381,13 @@ func (wfItem *WorkflowItem) Phase() *awfv1.NodePhase {
 	return nil
 }
 
+func (wfItem *WorkflowItem) Message() string {
+	if wfItem.NodeStatus != nil {
+		return wfItem.NodeStatus.Message
+	}
+	return ""
+}
+
 func (wfItem *WorkflowItem) Dependencies() strset.Set {
 	if wfItem.Task != nil && wfItem.Task.Dependencies != nil {
 		return strset.New(wfItem.Task.Dependencies...)
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"path/filepath"
+
+	"github.com/cortexlabs/cortex/pkg/lib/auth"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/rbac"
+	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+// HMACKeyFile is the name of the signing key file in the auth secret (when using HMAC tokens)
+const HMACKeyFile = "signing_key"
+
+var authenticator auth.Authenticator
+
+// policy is nil when no RBAC policy is configured, in which case every authenticated caller has full access
+var policy *rbac.Policy
+
+func init() {
+	var err error
+
+	switch cc.AuthType {
+	case auth.TypeAWS:
+		if _, err := aws.AccountID(); err != nil {
+			errors.Exit(err, aws.ErrorAuth())
+		}
+		authenticator = auth.NewAWSAuthenticator(aws.AuthUser)
+	case auth.TypeToken:
+		authenticator, err = auth.NewTokenAuthenticator(cc.AuthSecretDir)
+	case auth.TypeHMAC:
+		authenticator, err = auth.NewHMACAuthenticatorFromFile(filepath.Join(cc.AuthSecretDir, HMACKeyFile))
+	}
+
+	if err != nil {
+		errors.Exit(err, "auth")
+	}
+
+	if err := files.CheckFile(cc.RBACPolicyPath); err == nil {
+		policy, err = rbac.NewPolicyFromFile(cc.RBACPolicyPath)
+		if err != nil {
+			errors.Exit(err, "rbac")
+		}
+	}
+}
+
+// Authenticate verifies the request's Authorization header and returns the caller's identity
+func Authenticate(authHeader string) (string, error) {
+	return authenticator.Authenticate(authHeader)
+}
+
+// Authorize returns an error if identity does not have at least the required role for appName
+func Authorize(identity string, appName string, required rbac.Role) error {
+	if policy == nil {
+		return nil
+	}
+	return policy.Authorize(identity, appName, required)
+}
@@ -16,11 +16,6 @@ limitations under the License.
 
 package aws
 
-import (
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/cortexlabs/cortex/pkg/lib/errors"
-)
-
 type ErrorKind int
 
 const (
@@ -69,25 +64,6 @@ func (t ErrorKind) MarshalBinary() ([]byte, error) {
 	return []byte(t.String()), nil
 }
 
-func IsNoSuchKeyErr(err error) bool {
-	return checkErrCode(err, "NoSuchKey")
-}
-
-func IsNotFoundErr(err error) bool {
-	return checkErrCode(err, "NotFound")
-}
-
-func checkErrCode(err error, errorCode string) bool {
-	awsErr, ok := errors.Cause(err).(awserr.Error)
-	if !ok {
-		return false
-	}
-	if awsErr.Code() == errorCode {
-		return true
-	}
-	return false
-}
-
 type Error struct {
 	Kind    ErrorKind
 	message string
@@ -17,10 +17,10 @@ limitations under the License.
 package aws
 
 import (
+	"sync"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sts"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
@@ -28,26 +28,40 @@ import (
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 )
 
-var awsAccountID string
-var s3Client *s3.S3
-var stsClient *sts.STS
-var cloudWatchLogsClient *cloudwatchlogs.CloudWatchLogs
-var HashedAccountID string
+var (
+	accountIDOnce sync.Once
+	awsAccountID  string
+	accountIDErr  error
+)
 
-func init() {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region:     aws.String(cc.Region),
-		DisableSSL: aws.Bool(false),
-	}))
+// AccountID returns the ID of the AWS account whose credentials the operator has. It is only looked up (once) when it
+// is first needed, so that operators which don't use S3 or AWS authentication can run without AWS credentials.
+func AccountID() (string, error) {
+	accountIDOnce.Do(func() {
+		sess, err := session.NewSession(&aws.Config{
+			Region:     aws.String(cc.Region),
+			DisableSSL: aws.Bool(false),
+		})
+		if err != nil {
+			accountIDErr = errors.WithStack(err)
+			return
+		}
 
-	s3Client = s3.New(sess)
-	cloudWatchLogsClient = cloudwatchlogs.New(sess)
-	stsClient = sts.New(sess)
+		response, err := sts.New(sess).GetCallerIdentity(nil)
+		if err != nil {
+			accountIDErr = errors.WithStack(err)
+			return
+		}
+		awsAccountID = *response.Account
+	})
+	return awsAccountID, accountIDErr
+}
 
-	response, err := stsClient.GetCallerIdentity(nil)
+// HashedAccountID returns the hash of AccountID(), or "" if it can't be looked up
+func HashedAccountID() string {
+	accountID, err := AccountID()
 	if err != nil {
-		errors.Exit(err, ErrorAuth())
+		return ""
 	}
-	awsAccountID = *response.Account
-	HashedAccountID = hash.String(awsAccountID)
+	return hash.String(accountID)
 }
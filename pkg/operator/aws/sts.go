@@ -28,6 +28,11 @@ import (
 )
 
 func AuthUser(accessKeyID string, secretAccessKey string) (bool, error) {
+	accountID, err := AccountID()
+	if err != nil {
+		return false, errors.Wrap(err, ErrorAuth().Error())
+	}
+
 	sess, err := session.NewSession(&aws.Config{
 		Region:      aws.String(cc.Region),
 		DisableSSL:  aws.Bool(false),
@@ -48,5 +53,5 @@ func AuthUser(accessKeyID string, secretAccessKey string) (bool, error) {
 		return false, errors.WithStack(err)
 	}
 
-	return *response.Account == awsAccountID, nil
+	return *response.Account == accountID, nil
 }
@@ -49,11 +49,6 @@ func getAggregates(
 			return nil, errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.AggregatorKey)
 		}
 
-		err = validateAggregateInputs(aggregateConfig, constants, rawColumns, aggregator)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-
 		constantIDMap := make(map[string]string, len(aggregateConfig.Inputs.Args))
 		constantIDWithTagsMap := make(map[string]string, len(aggregateConfig.Inputs.Args))
 		for argName, constantName := range aggregateConfig.Inputs.Args {
@@ -102,52 +97,3 @@ func getAggregates(
 
 	return aggregates, nil
 }
-
-func validateAggregateInputs(
-	aggregateConfig *userconfig.Aggregate,
-	constants context.Constants,
-	rawColumns context.RawColumns,
-	aggregator *context.Aggregator,
-) error {
-
-	columnRuntimeTypes, err := context.GetColumnRuntimeTypes(aggregateConfig.Inputs.Columns, rawColumns)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.InputsKey, userconfig.ColumnsKey)
-	}
-	err = userconfig.CheckColumnRuntimeTypesMatch(columnRuntimeTypes, aggregator.Inputs.Columns)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.InputsKey, userconfig.ColumnsKey)
-	}
-
-	argTypes, err := getAggregateArgTypes(aggregateConfig.Inputs.Args, constants)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.InputsKey, userconfig.ArgsKey)
-	}
-	err = userconfig.CheckArgRuntimeTypesMatch(argTypes, aggregator.Inputs.Args)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(aggregateConfig), userconfig.InputsKey, userconfig.ArgsKey)
-	}
-
-	return nil
-}
-
-func getAggregateArgTypes(
-	args map[string]interface{},
-	constants context.Constants,
-) (map[string]interface{}, error) {
-
-	if len(args) == 0 {
-		return nil, nil
-	}
-
-	argTypes := make(map[string]interface{}, len(args))
-	for argName, constantName := range args {
-		constantNameStr := constantName.(string)
-		constant, ok := constants[constantNameStr]
-		if !ok {
-			return nil, errors.Wrap(userconfig.ErrorUndefinedResource(constantNameStr, resource.ConstantType), argName)
-		}
-		argTypes[argName] = constant.Type
-	}
-	return argTypes, nil
-}
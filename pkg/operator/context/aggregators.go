@@ -29,7 +29,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 )
 
@@ -53,7 +53,7 @@ func init() {
 			telemetry.ReportErrorBlocking(err)
 			errors.Exit(err)
 		}
-		aggregator, err := newAggregator(*aggregatorConfig, impl, pointer.String("cortex"), nil)
+		aggregator, err := newAggregator(*aggregatorConfig, impl, pointer.String("cortex"), nil, false)
 		if err != nil {
 			telemetry.ReportErrorBlocking(err)
 			errors.Exit(err)
@@ -62,10 +62,19 @@ func init() {
 	}
 }
 
+func builtinAggregatorSignatures() map[string]*userconfig.Aggregator {
+	signatures := make(map[string]*userconfig.Aggregator, len(builtinAggregators))
+	for name, aggregator := range builtinAggregators {
+		signatures[name] = aggregator.Aggregator
+	}
+	return signatures
+}
+
 func loadUserAggregators(
 	aggregatorConfigs userconfig.Aggregators,
 	impls map[string][]byte,
 	pythonPackages context.PythonPackages,
+	dryRun bool,
 ) (map[string]*context.Aggregator, error) {
 
 	userAggregators := make(map[string]*context.Aggregator)
@@ -74,7 +83,7 @@ func loadUserAggregators(
 		if !ok {
 			return nil, errors.Wrap(ErrorImplDoesNotExist(aggregatorConfig.Path), userconfig.Identify(aggregatorConfig))
 		}
-		aggregator, err := newAggregator(*aggregatorConfig, impl, nil, pythonPackages)
+		aggregator, err := newAggregator(*aggregatorConfig, impl, nil, pythonPackages, dryRun)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +98,7 @@ func newAggregator(
 	impl []byte,
 	namespace *string,
 	pythonPackages context.PythonPackages,
+	dryRun bool,
 ) (*context.Aggregator, error) {
 
 	implID := hash.Bytes(impl)
@@ -116,8 +126,10 @@ func newAggregator(
 	}
 	aggregator.Aggregator.Path = ""
 
-	if err := uploadAggregator(aggregator, impl); err != nil {
-		return nil, err
+	if !dryRun {
+		if err := uploadAggregator(aggregator, impl); err != nil {
+			return nil, err
+		}
 	}
 
 	return aggregator, nil
@@ -128,13 +140,13 @@ func uploadAggregator(aggregator *context.Aggregator, impl []byte) error {
 		return nil
 	}
 
-	isUploaded, err := aws.IsS3File(aggregator.ImplKey)
+	isUploaded, err := storage.IsFile(aggregator.ImplKey)
 	if err != nil {
 		return errors.Wrap(err, userconfig.Identify(aggregator), "upload")
 	}
 
 	if !isUploaded {
-		err = aws.UploadBytesToS3(impl, aggregator.ImplKey)
+		err = storage.UploadBytes(impl, aggregator.ImplKey)
 		if err != nil {
 			return errors.Wrap(err, userconfig.Identify(aggregator), "upload")
 		}
@@ -29,15 +29,15 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/msgpack"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 var uploadedConstants = strset.New()
 
-func loadConstants(constantConfigs userconfig.Constants) (context.Constants, error) {
+func loadConstants(constantConfigs userconfig.Constants, dryRun bool) (context.Constants, error) {
 	constants := context.Constants{}
 	for _, constantConfig := range constantConfigs {
-		constant, err := newConstant(*constantConfig)
+		constant, err := newConstant(*constantConfig, dryRun)
 		if err != nil {
 			return nil, err
 		}
@@ -47,7 +47,7 @@ func loadConstants(constantConfigs userconfig.Constants) (context.Constants, err
 	return constants, nil
 }
 
-func newConstant(constantConfig userconfig.Constant) (*context.Constant, error) {
+func newConstant(constantConfig userconfig.Constant, dryRun bool) (*context.Constant, error) {
 	var buf bytes.Buffer
 	buf.WriteString(context.DataTypeID(constantConfig.Type))
 	buf.WriteString(s.Obj(constantConfig.Value))
@@ -64,8 +64,10 @@ func newConstant(constantConfig userconfig.Constant) (*context.Constant, error)
 		Key:      filepath.Join(consts.ConstantsDir, id+".msgpack"),
 	}
 
-	if err := uploadConstant(constant); err != nil {
-		return nil, err
+	if !dryRun {
+		if err := uploadConstant(constant); err != nil {
+			return nil, err
+		}
 	}
 
 	constant.Constant.Value = nil
@@ -77,14 +79,14 @@ func uploadConstant(constant *context.Constant) error {
 		return nil
 	}
 
-	isUploaded, err := aws.IsS3File(constant.Key)
+	isUploaded, err := storage.IsFile(constant.Key)
 	if err != nil {
 		return errors.Wrap(err, userconfig.Identify(constant), "upload")
 	}
 
 	if !isUploaded {
 		serializedConstant := msgpack.MustMarshal(constant.Value)
-		err = aws.UploadBytesToS3(serializedConstant, constant.Key)
+		err = storage.UploadBytes(serializedConstant, constant.Key)
 		if err != nil {
 			return errors.Wrap(err, userconfig.Identify(constant), "upload")
 		}
@@ -17,21 +17,28 @@ limitations under the License.
 package context
 
 import (
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	"github.com/cortexlabs/cortex/pkg/api/userconfig"
 	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/lib/random"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
+// New builds the context for config; with dryRun, nothing is stored (e.g. impl files or the dataset version), and
+// otherwise the caller must hold the app's lock
 func New(
 	config *userconfig.Config,
 	files map[string][]byte,
 	ignoreCache bool,
+	dryRun bool,
 ) (*context.Context, error) {
 	ctx := &context.Context{}
 
@@ -39,7 +46,7 @@ func New(
 
 	ctx.App = getApp(config.App)
 
-	datasetVersion, err := getOrSetDatasetVersion(ctx.App.Name, ignoreCache)
+	datasetVersion, err := getOrSetDatasetVersion(ctx.App.Name, ignoreCache, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -61,28 +68,33 @@ func New(
 
 	ctx.StatusPrefix = StatusPrefix(ctx.App.Name)
 
-	pythonPackages, err := loadPythonPackages(files, ctx.DatasetVersion)
+	pythonPackages, err := loadPythonPackages(files, ctx.DatasetVersion, dryRun)
 	if err != nil {
 		return nil, err
 	}
 	ctx.PythonPackages = pythonPackages
 
-	userTransformers, err := loadUserTransformers(config.Transformers, files, pythonPackages)
+	userTransformers, err := loadUserTransformers(config.Transformers, files, pythonPackages, dryRun)
 	if err != nil {
 		return nil, err
 	}
 
-	userAggregators, err := loadUserAggregators(config.Aggregators, files, pythonPackages)
+	userAggregators, err := loadUserAggregators(config.Aggregators, files, pythonPackages, dryRun)
 	if err != nil {
 		return nil, err
 	}
 
+	// The same checks are run by cortex validate
+	if err := errors.FirstError(config.CheckInputs(builtinAggregatorSignatures(), builtinTransformerSignatures())...); err != nil {
+		return nil, err
+	}
+
 	err = autoGenerateConfig(config, userAggregators, userTransformers)
 	if err != nil {
 		return nil, err
 	}
 
-	constants, err := loadConstants(config.Constants)
+	constants, err := loadConstants(config.Constants, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +130,7 @@ func New(
 	}
 	ctx.TransformedColumns = transformedColumns
 
-	models, err := getModels(config, aggregates, ctx.Columns(), files, ctx.Root, pythonPackages)
+	models, err := getModels(config, aggregates, ctx.Columns(), files, ctx.Root, pythonPackages, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +152,13 @@ func New(
 	return ctx, nil
 }
 
+// ImportContext rebinds ctx, which was exported from another cluster, to this cluster's configuration
+func ImportContext(ctx *context.Context) {
+	ctx.CortexConfig = getCortexConfig()
+	ctx.ID = calculateID(ctx)
+	ctx.Key = ctxKey(ctx.ID, ctx.App.Name)
+}
+
 func ctxKey(ctxID string, appName string) string {
 	return filepath.Join(
 		consts.AppsDir,
@@ -168,10 +187,13 @@ func calculateID(ctx *context.Context) string {
 }
 
 func DownloadContext(ctxID string, appName string) (*context.Context, error) {
-	s3Key := ctxKey(ctxID, appName)
+	key := ctxKey(ctxID, appName)
 	var serial context.Serial
 
-	if err := aws.ReadMsgpackFromS3(&serial, s3Key); err != nil {
+	if err := storage.ReadMsgpack(&serial, key); err != nil {
+		if storage.IsNotFoundErr(err) {
+			return nil, ErrorContextNotFound(ctxID, appName)
+		}
 		return nil, err
 	}
 
@@ -210,3 +232,28 @@ func WorkloadSpecKey(workloadID string, appName string) string {
 		workloadID,
 	)
 }
+
+func HistoryKey(appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.HistoryFile,
+	)
+}
+
+// AuditPrefix is within the app's directory, but is kept when the app is deleted so that the audit log outlives the app
+func AuditPrefix(appName string) string {
+	return filepath.Join(
+		consts.AppsDir,
+		appName,
+		consts.AuditDir,
+	) + "/"
+}
+
+// AuditKey returns a unique key for a record, which sorts chronologically within AuditPrefix()
+func AuditKey(appName string, timestamp time.Time) string {
+	return filepath.Join(
+		AuditPrefix(appName),
+		fmt.Sprintf("%020d-%s.json", timestamp.UnixNano(), random.LowercaseString(8)),
+	)
+}
@@ -18,6 +18,8 @@ package context
 
 import (
 	"fmt"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
 )
 
 type ErrorKind int
@@ -25,14 +27,16 @@ type ErrorKind int
 const (
 	ErrUnknown ErrorKind = iota
 	ErrImplDoesNotExist
+	ErrContextNotFound
 )
 
 var errorKinds = []string{
 	"err_unknown",
 	"err_impl_does_not_exist",
+	"err_context_not_found",
 }
 
-var _ = [1]int{}[int(ErrImplDoesNotExist)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrContextNotFound)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -83,3 +87,10 @@ func ErrorImplDoesNotExist(path string) error {
 		message: fmt.Sprintf("%s: implementation file does not exist", path),
 	}
 }
+
+func ErrorContextNotFound(ctxID string, appName string) error {
+	return Error{
+		Kind:    ErrContextNotFound,
+		message: fmt.Sprintf("context %s was not found for app %s", s.UserStr(ctxID), s.UserStr(appName)),
+	}
+}
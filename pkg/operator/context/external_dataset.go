@@ -23,35 +23,56 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
-func getOrSetDatasetVersion(appName string, ignoreCache bool) (string, error) {
-	datasetVersionFileKey := filepath.Join(
+func datasetVersionKey(appName string) string {
+	return filepath.Join(
 		consts.AppsDir,
 		appName,
 		"dataset_version",
 	)
+}
+
+// getOrSetDatasetVersion returns the app's dataset version, and stores a new one if there is none or ignoreCache is set
+// (unless dryRun, in which case the new version is only returned)
+func getOrSetDatasetVersion(appName string, ignoreCache bool, dryRun bool) (string, error) {
+	datasetVersionFileKey := datasetVersionKey(appName)
 
 	if ignoreCache {
 		datasetVersion := libtime.Timestamp(time.Now())
-		err := aws.UploadStringToS3(datasetVersion, datasetVersionFileKey)
+		if dryRun {
+			return datasetVersion, nil
+		}
+		err := storage.UploadString(datasetVersion, datasetVersionFileKey)
 		if err != nil {
 			return "", errors.Wrap(err, "dataset version") // unexpected error
 		}
 		return datasetVersion, nil
 	}
 
-	datasetVersion, err := aws.ReadStringFromS3(datasetVersionFileKey)
+	datasetVersion, err := storage.ReadString(datasetVersionFileKey)
 	if err != nil {
-		if !aws.IsNoSuchKeyErr(err) {
+		if !storage.IsNotFoundErr(err) {
 			return "", errors.Wrap(err, "dataset version") // unexpected error
 		}
 		datasetVersion = libtime.Timestamp(time.Now())
-		err := aws.UploadStringToS3(datasetVersion, datasetVersionFileKey)
+		if dryRun {
+			return datasetVersion, nil
+		}
+		err := storage.UploadString(datasetVersion, datasetVersionFileKey)
 		if err != nil {
 			return "", errors.Wrap(err, "dataset version") // unexpected error
 		}
 	}
 	return datasetVersion, nil
 }
+
+// SetDatasetVersion sets the dataset version which the app's subsequent deployments will use (e.g. after an import)
+func SetDatasetVersion(appName string, datasetVersion string) error {
+	err := storage.UploadString(datasetVersion, datasetVersionKey(appName))
+	if err != nil {
+		return errors.Wrap(err, "dataset version") // unexpected error
+	}
+	return nil
+}
@@ -29,7 +29,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 var uploadedModels = strset.New()
@@ -41,12 +41,13 @@ func getModels(
 	impls map[string][]byte,
 	root string,
 	pythonPackages context.PythonPackages,
+	dryRun bool,
 ) (context.Models, error) {
 
 	models := context.Models{}
 
 	for _, modelConfig := range config.Models {
-		modelImplID, modelImplKey, err := getModelImplID(modelConfig.Path, impls)
+		modelImplID, modelImplKey, err := getModelImplID(modelConfig.Path, impls, dryRun)
 		if err != nil {
 			return nil, errors.Wrap(err, userconfig.Identify(modelConfig), userconfig.PathKey)
 		}
@@ -127,41 +128,41 @@ func getModels(
 	return models, nil
 }
 
-func getModelImplID(implPath string, impls map[string][]byte) (string, string, error) {
+func getModelImplID(implPath string, impls map[string][]byte, dryRun bool) (string, string, error) {
 	impl, ok := impls[implPath]
 	if !ok {
 		return "", "", ErrorImplDoesNotExist(implPath)
 	}
 	modelImplID := hash.Bytes(impl)
-	modelImplKey, err := uploadModelImpl(modelImplID, impl)
-	if err != nil {
-		return "", "", errors.Wrap(err, implPath)
-	}
-	return modelImplID, modelImplKey, nil
-}
-
-func uploadModelImpl(modelImplID string, impl []byte) (string, error) {
 	modelImplKey := filepath.Join(
 		consts.ModelImplsDir,
 		modelImplID+".py",
 	)
+	if !dryRun {
+		if err := uploadModelImpl(modelImplID, modelImplKey, impl); err != nil {
+			return "", "", errors.Wrap(err, implPath)
+		}
+	}
+	return modelImplID, modelImplKey, nil
+}
 
+func uploadModelImpl(modelImplID string, modelImplKey string, impl []byte) error {
 	if uploadedModels.Has(modelImplID) {
-		return modelImplKey, nil
+		return nil
 	}
 
-	isUploaded, err := aws.IsS3File(modelImplKey)
+	isUploaded, err := storage.IsFile(modelImplKey)
 	if err != nil {
-		return "", errors.Wrap(err, "upload")
+		return errors.Wrap(err, "upload")
 	}
 
 	if !isUploaded {
-		err = aws.UploadBytesToS3(impl, modelImplKey)
+		err = storage.UploadBytes(impl, modelImplKey)
 		if err != nil {
-			return "", errors.Wrap(err, "upload")
+			return errors.Wrap(err, "upload")
 		}
 	}
 
 	uploadedModels.Add(modelImplID)
-	return modelImplKey, nil
+	return nil
 }
@@ -28,7 +28,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/zip"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func findCustomPackages(files map[string][]byte) []string {
@@ -47,7 +47,8 @@ func findCustomPackages(files map[string][]byte) []string {
 	return customPackages
 }
 
-func loadPythonPackages(files map[string][]byte, datasetVersion string) (context.PythonPackages, error) {
+// loadPythonPackages returns the app's python packages, and uploads their sources (unless dryRun)
+func loadPythonPackages(files map[string][]byte, datasetVersion string, dryRun bool) (context.PythonPackages, error) {
 	pythonPackages := make(map[string]*context.PythonPackage)
 
 	if reqFileBytes, ok := files[consts.RequirementsTxt]; ok {
@@ -70,8 +71,10 @@ func loadPythonPackages(files map[string][]byte, datasetVersion string) (context
 			PackageKey: filepath.Join(consts.PythonPackagesDir, id, "package.zip"),
 		}
 
-		if err := aws.UploadBytesToS3(reqFileBytes, pythonPackage.SrcKey); err != nil {
-			return nil, errors.Wrap(err, "upload", "requirements")
+		if !dryRun {
+			if err := storage.UploadBytes(reqFileBytes, pythonPackage.SrcKey); err != nil {
+				return nil, errors.Wrap(err, "upload", "requirements")
+			}
 		}
 
 		pythonPackages[pythonPackage.Name] = &pythonPackage
@@ -108,17 +111,19 @@ func loadPythonPackages(files map[string][]byte, datasetVersion string) (context
 			PackageKey: filepath.Join(consts.PythonPackagesDir, id, "package.zip"),
 		}
 
-		zipInput := zip.Input{
-			Bytes: zipBytesInputs,
-		}
+		if !dryRun {
+			zipInput := zip.Input{
+				Bytes: zipBytesInputs,
+			}
 
-		zipBytes, err := zip.ToMem(&zipInput)
-		if err != nil {
-			return nil, errors.Wrap(err, "zip", packageName)
-		}
+			zipBytes, err := zip.ToMem(&zipInput)
+			if err != nil {
+				return nil, errors.Wrap(err, "zip", packageName)
+			}
 
-		if err := aws.UploadBytesToS3(zipBytes, pythonPackage.SrcKey); err != nil {
-			return nil, errors.Wrap(err, "upload", packageName)
+			if err := storage.UploadBytes(zipBytes, pythonPackage.SrcKey); err != nil {
+				return nil, errors.Wrap(err, "upload", packageName)
+			}
 		}
 
 		pythonPackages[pythonPackage.Name] = &pythonPackage
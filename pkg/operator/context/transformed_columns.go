@@ -44,11 +44,6 @@ func getTransformedColumns(
 			return nil, errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.TransformerKey)
 		}
 
-		err = validateTransformedColumnInputs(transformedColumnConfig, constants, rawColumns, aggregates, transformer)
-		if err != nil {
-			return nil, errors.WithStack(err)
-		}
-
 		valueResourceIDMap := make(map[string]string, len(transformedColumnConfig.Inputs.Args))
 		valueResourceIDWithTagsMap := make(map[string]string, len(transformedColumnConfig.Inputs.Args))
 		for argName, resourceName := range transformedColumnConfig.Inputs.Args {
@@ -89,54 +84,3 @@ func getTransformedColumns(
 
 	return transformedColumns, nil
 }
-
-func validateTransformedColumnInputs(
-	transformedColumnConfig *userconfig.TransformedColumn,
-	constants context.Constants,
-	rawColumns context.RawColumns,
-	aggregates context.Aggregates,
-	transformer *context.Transformer,
-) error {
-
-	columnRuntimeTypes, err := context.GetColumnRuntimeTypes(transformedColumnConfig.Inputs.Columns, rawColumns)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ColumnsKey)
-	}
-	err = userconfig.CheckColumnRuntimeTypesMatch(columnRuntimeTypes, transformer.Inputs.Columns)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ColumnsKey)
-	}
-
-	argTypes, err := getTransformedColumnArgTypes(transformedColumnConfig.Inputs.Args, constants, aggregates)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ArgsKey)
-	}
-	err = userconfig.CheckArgRuntimeTypesMatch(argTypes, transformer.Inputs.Args)
-	if err != nil {
-		return errors.Wrap(err, userconfig.Identify(transformedColumnConfig), userconfig.InputsKey, userconfig.ArgsKey)
-	}
-
-	return nil
-}
-
-func getTransformedColumnArgTypes(
-	args map[string]interface{},
-	constants context.Constants,
-	aggregates context.Aggregates,
-) (map[string]interface{}, error) {
-
-	if len(args) == 0 {
-		return nil, nil
-	}
-
-	argTypes := make(map[string]interface{}, len(args))
-	for argName, valueResourceName := range args {
-		valueResourceNameStr := valueResourceName.(string)
-		valueResource, err := context.GetValueResource(valueResourceNameStr, constants, aggregates)
-		if err != nil {
-			return nil, errors.Wrap(err, argName)
-		}
-		argTypes[argName] = valueResource.GetType()
-	}
-	return argTypes, nil
-}
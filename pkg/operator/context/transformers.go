@@ -29,7 +29,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/hash"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 )
 
@@ -53,7 +53,7 @@ func init() {
 			telemetry.ReportErrorBlocking(err)
 			errors.Exit(err)
 		}
-		transformer, err := newTransformer(*transConfig, impl, pointer.String("cortex"), nil)
+		transformer, err := newTransformer(*transConfig, impl, pointer.String("cortex"), nil, false)
 		if err != nil {
 			telemetry.ReportErrorBlocking(err)
 			errors.Exit(err)
@@ -62,10 +62,19 @@ func init() {
 	}
 }
 
+func builtinTransformerSignatures() map[string]*userconfig.Transformer {
+	signatures := make(map[string]*userconfig.Transformer, len(builtinTransformers))
+	for name, transformer := range builtinTransformers {
+		signatures[name] = transformer.Transformer
+	}
+	return signatures
+}
+
 func loadUserTransformers(
 	transConfigs userconfig.Transformers,
 	impls map[string][]byte,
 	pythonPackages context.PythonPackages,
+	dryRun bool,
 ) (map[string]*context.Transformer, error) {
 
 	userTransformers := make(map[string]*context.Transformer)
@@ -74,7 +83,7 @@ func loadUserTransformers(
 		if !ok {
 			return nil, errors.Wrap(ErrorImplDoesNotExist(transConfig.Path), userconfig.Identify(transConfig))
 		}
-		transformer, err := newTransformer(*transConfig, impl, nil, pythonPackages)
+		transformer, err := newTransformer(*transConfig, impl, nil, pythonPackages, dryRun)
 		if err != nil {
 			return nil, err
 		}
@@ -89,6 +98,7 @@ func newTransformer(
 	impl []byte,
 	namespace *string,
 	pythonPackages context.PythonPackages,
+	dryRun bool,
 ) (*context.Transformer, error) {
 
 	implID := hash.Bytes(impl)
@@ -115,8 +125,10 @@ func newTransformer(
 	}
 	transformer.Transformer.Path = ""
 
-	if err := uploadTransformer(transformer, impl); err != nil {
-		return nil, err
+	if !dryRun {
+		if err := uploadTransformer(transformer, impl); err != nil {
+			return nil, err
+		}
 	}
 
 	return transformer, nil
@@ -127,13 +139,13 @@ func uploadTransformer(transformer *context.Transformer, impl []byte) error {
 		return nil
 	}
 
-	isUploaded, err := aws.IsS3File(transformer.ImplKey)
+	isUploaded, err := storage.IsFile(transformer.ImplKey)
 	if err != nil {
 		return errors.Wrap(err, userconfig.Identify(transformer), "upload")
 	}
 
 	if !isUploaded {
-		err = aws.UploadBytesToS3(impl, transformer.ImplKey)
+		err = storage.UploadBytes(impl, transformer.ImplKey)
 		if err != nil {
 			return errors.Wrap(err, userconfig.Identify(transformer), "upload")
 		}
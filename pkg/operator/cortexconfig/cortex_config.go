@@ -20,7 +20,11 @@ import (
 	"path/filepath"
 
 	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/auth"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/logstore"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/storage"
 )
 
 var (
@@ -37,8 +41,29 @@ var (
 	TFTrainImageGPU     string
 	TFServeImageGPU     string
 	EnableTelemetry     bool
+	StorageType         string
+	StorageEndpoint     string
+	StorageLocalDir     string
+	LogStoreType        string
+	LogStoreEndpoint    string
+	LogStoreLocalDir    string
+	AuthType            string
+	AuthSecretDir       string
+	RBACPolicyPath      string
+	QuotaPolicyPath     string
+	WorkflowEngine      string
+	GCSchedule          string
+	GCKeepContexts      int
+	MetricsToken        string
 )
 
+const (
+	WorkflowEngineArgo   = "argo"
+	WorkflowEngineNative = "native"
+)
+
+var WorkflowEngines = []string{WorkflowEngineArgo, WorkflowEngineNative}
+
 func init() {
 	LogGroup = getStr("LOG_GROUP")
 	Bucket = getStr("BUCKET")
@@ -53,6 +78,32 @@ func init() {
 	TFTrainImageGPU = getStr("IMAGE_TF_TRAIN_GPU")
 	TFServeImageGPU = getStr("IMAGE_TF_SERVE_GPU")
 	EnableTelemetry = getBool("ENABLE_TELEMETRY")
+	StorageType = getStrWithValidation("STORAGE_TYPE", &cr.StringValidation{
+		Default:       storage.TypeS3,
+		AllowedValues: storage.Types,
+	})
+	StorageEndpoint = getStrWithValidation("STORAGE_ENDPOINT", &cr.StringValidation{AllowEmpty: true})
+	StorageLocalDir = getStrWithValidation("STORAGE_LOCAL_DIR", &cr.StringValidation{Default: "/mnt/cortex"})
+	LogStoreType = getStrWithValidation("LOG_STORE_TYPE", &cr.StringValidation{
+		Default:       logstore.TypeCloudWatch,
+		AllowedValues: logstore.Types,
+	})
+	LogStoreEndpoint = getStrWithValidation("LOG_STORE_ENDPOINT", &cr.StringValidation{AllowEmpty: true})
+	LogStoreLocalDir = getStrWithValidation("LOG_STORE_LOCAL_DIR", &cr.StringValidation{Default: "/var/log/cortex"})
+	AuthType = getStrWithValidation("AUTH_TYPE", &cr.StringValidation{
+		Default:       auth.TypeAWS,
+		AllowedValues: auth.Types,
+	})
+	AuthSecretDir = getStrWithValidation("AUTH_SECRET_DIR", &cr.StringValidation{Default: "/configs/auth"})
+	RBACPolicyPath = getStrWithValidation("RBAC_POLICY_PATH", &cr.StringValidation{Default: "/configs/rbac/policy.yaml"})
+	QuotaPolicyPath = getStrWithValidation("QUOTA_POLICY_PATH", &cr.StringValidation{Default: "/configs/quota/quotas.yaml"})
+	WorkflowEngine = getStrWithValidation("WORKFLOW_ENGINE", &cr.StringValidation{
+		Default:       WorkflowEngineArgo,
+		AllowedValues: WorkflowEngines,
+	})
+	GCSchedule = getStrWithValidation("GC_SCHEDULE", &cr.StringValidation{Default: "@daily", AllowEmpty: true})
+	GCKeepContexts = getIntWithValidation("GC_KEEP_CONTEXTS", &cr.IntValidation{Default: 10, GreaterThan: pointer.Int(0)})
+	MetricsToken = getStrWithValidation("METRICS_TOKEN", &cr.StringValidation{AllowEmpty: true})
 }
 
 //
@@ -71,6 +122,16 @@ func getStr(configName string) string {
 	return cr.MustStringFromEnvOrFile(envVarName, filePath, v)
 }
 
+func getStrWithValidation(configName string, v *cr.StringValidation) string {
+	envVarName, filePath := getPaths(configName)
+	return cr.MustStringFromEnvOrFile(envVarName, filePath, v)
+}
+
+func getIntWithValidation(configName string, v *cr.IntValidation) int {
+	envVarName, filePath := getPaths(configName)
+	return cr.MustIntFromEnvOrFile(envVarName, filePath, v)
+}
+
 func getBool(configName string) bool {
 	envVarName, filePath := getPaths(configName)
 	v := &cr.BoolValidation{Default: false}
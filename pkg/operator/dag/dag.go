@@ -0,0 +1,181 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dag
+
+import (
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	listercorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/maps"
+	"github.com/cortexlabs/cortex/pkg/lib/random"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+)
+
+// Workflows are stored in config maps with this label, under workflowKey
+const (
+	workflowLabel = "cortexWorkflow"
+	workflowKey   = "workflow.json"
+)
+
+const (
+	ActionCreate = "create"
+	ActionApply  = "apply"
+)
+
+var (
+	informerFactory  informers.SharedInformerFactory
+	workflowInformer cache.SharedIndexInformer
+	workflowLister   listercorev1.ConfigMapLister
+)
+
+func init() {
+	informerFactory = k8s.NewInformerFactory(workflowLabel + "=true")
+	workflowInformer = informerFactory.Core().V1().ConfigMaps().Informer()
+	workflowLister = informerFactory.Core().V1().ConfigMaps().Lister()
+}
+
+// StartInformers starts watching workflows, and waits until the cache is populated
+func StartInformers(stopCh <-chan struct{}) error {
+	informerFactory.Start(stopCh)
+	return k8s.WaitForInformers("dag", stopCh, workflowInformer.HasSynced)
+}
+
+func OnWorkflowChange(onChange func()) {
+	workflowInformer.AddEventHandler(k8s.ChangeHandler(onChange))
+}
+
+// Run stores wf as a running workflow; its tasks are started by the executor
+func Run(wf *awfv1.Workflow) error {
+	wf = wf.DeepCopy()
+	wf.Status = awfv1.WorkflowStatus{
+		Phase:     awfv1.NodeRunning,
+		StartedAt: metav1.Now(),
+		Nodes:     map[string]awfv1.NodeStatus{},
+	}
+
+	wfJSON, err := libjson.Marshal(wf)
+	if err != nil {
+		return errors.Wrap(err, wf.GenerateName)
+	}
+
+	_, err = k8s.CreateConfigMap(&k8s.ConfigMapSpec{
+		Name:      wf.GenerateName + random.LowercaseString(5),
+		Namespace: cc.Namespace,
+		Labels:    maps.MergeStrMaps(wf.Labels, map[string]string{workflowLabel: "true"}),
+		Data:      map[string]string{workflowKey: string(wfJSON)},
+	})
+	return err
+}
+
+func List(opts *metav1.ListOptions) ([]awfv1.Workflow, error) {
+	listOpts := metav1.ListOptions{}
+	if opts != nil {
+		listOpts = *opts
+	}
+	if listOpts.LabelSelector == "" {
+		listOpts.LabelSelector = workflowLabel + "=true"
+	} else {
+		listOpts.LabelSelector += "," + workflowLabel + "=true"
+	}
+
+	configMaps, err := k8s.ListConfigMaps(&listOpts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(configMaps)
+}
+
+func ListByLabels(labels map[string]string) ([]awfv1.Workflow, error) {
+	opts := &metav1.ListOptions{
+		LabelSelector: k8s.LabelSelector(labels),
+	}
+	return List(opts)
+}
+
+// ListCached lists workflows from the informer's cache instead of the API server
+func ListCached() ([]awfv1.Workflow, error) {
+	configMapPtrs, err := workflowLister.ConfigMaps(cc.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	configMaps := make([]corev1.ConfigMap, len(configMapPtrs))
+	for i, configMapPtr := range configMapPtrs {
+		configMaps[i] = *configMapPtr
+	}
+	return decodeAll(configMaps)
+}
+
+// Ping checks that workflows can be listed
+func Ping() error {
+	_, err := k8s.ListConfigMaps(&metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// Delete deletes the workflow, and (via garbage collection) the resources its tasks created
+func Delete(wfName string) (bool, error) {
+	return k8s.DeleteConfigMap(wfName)
+}
+
+func decodeAll(configMaps []corev1.ConfigMap) ([]awfv1.Workflow, error) {
+	wfs := make([]awfv1.Workflow, 0, len(configMaps))
+	for i := range configMaps {
+		wf, err := decode(&configMaps[i])
+		if err != nil {
+			return nil, err
+		}
+		wfs = append(wfs, *wf)
+	}
+	return wfs, nil
+}
+
+// decode reads the workflow stored in configMap, whose metadata becomes the workflow's metadata
+func decode(configMap *corev1.ConfigMap) (*awfv1.Workflow, error) {
+	wf := &awfv1.Workflow{}
+	if err := libjson.Unmarshal([]byte(configMap.Data[workflowKey]), wf); err != nil {
+		return nil, errors.Wrap(err, "workflow", configMap.Name)
+	}
+	wf.ObjectMeta = *configMap.ObjectMeta.DeepCopy()
+	return wf, nil
+}
+
+// save stores wf's status, and fails if the workflow was modified or deleted since it was read
+func save(wf *awfv1.Workflow) error {
+	wfJSON, err := libjson.Marshal(wf)
+	if err != nil {
+		return errors.Wrap(err, "workflow", wf.Name)
+	}
+
+	configMap := k8s.ConfigMap(&k8s.ConfigMapSpec{
+		Name:      wf.Name,
+		Namespace: wf.Namespace,
+		Labels:    wf.Labels,
+		Data:      map[string]string{workflowKey: string(wfJSON)},
+	})
+	configMap.ResourceVersion = wf.ResourceVersion
+
+	_, err = k8s.UpdateConfigMap(configMap)
+	return err
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dag
+
+import (
+	"context"
+	"time"
+
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/workflow"
+	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+)
+
+// executorInterval is how often running tasks' resources are checked against their conditions
+const executorInterval = 2 * time.Second
+
+// Execute runs the tasks of all running workflows until ctx is cancelled.
+// Only one operator replica (the leader) should execute workflows at a time.
+func Execute(ctx context.Context) {
+	ticker := time.NewTicker(executorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			executeRunning()
+		}
+	}
+}
+
+func executeRunning() {
+	wfs, err := ListCached()
+	if err != nil {
+		telemetry.ReportError(err)
+		errors.PrintError(err)
+		return
+	}
+
+	for _, wf := range wfs {
+		if wf.Status.Phase == awfv1.NodeRunning {
+			executeAndRecover(wf.Name)
+		}
+	}
+}
+
+func executeAndRecover(wfName string) {
+	defer func() {
+		if errInterface := recover(); errInterface != nil {
+			err := errors.CastRecoverError(errInterface, "workflow", wfName)
+			telemetry.ReportError(err)
+			errors.PrintError(err)
+		}
+	}()
+
+	if err := execute(wfName); err != nil {
+		telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
+}
+
+// execute starts the workflow's tasks whose dependencies have succeeded, checks the conditions of its running
+// tasks, and completes the workflow once no more tasks can run
+func execute(wfName string) error {
+	// Read from the API server, since the informer's cache may not include the last update yet
+	configMap, err := k8s.GetConfigMap(wfName)
+	if err != nil || configMap == nil {
+		return err
+	}
+	wf, err := decode(configMap)
+	if err != nil {
+		return err
+	}
+	if wf.Status.Phase != awfv1.NodeRunning {
+		return nil
+	}
+
+	updated, err := workflow.Advance(wf,
+		func(task awfv1.DAGTask, template *awfv1.Template) awfv1.NodeStatus {
+			return startNode(wf, task, template)
+		},
+		func(node *awfv1.NodeStatus, template *awfv1.Template) (bool, error) {
+			return checkNode(wf, node, template)
+		},
+	)
+	if err != nil || !updated {
+		return err
+	}
+
+	err = save(wf)
+	// The workflow was deleted or replaced while it was being executed
+	if k8serrors.IsNotFound(errors.Cause(err)) || k8serrors.IsConflict(errors.Cause(err)) {
+		return nil
+	}
+	return err
+}
+
+func startNode(wf *awfv1.Workflow, task awfv1.DAGTask, template *awfv1.Template) awfv1.NodeStatus {
+	node := awfv1.NodeStatus{
+		ID:           wf.Name + "-" + task.Name,
+		Name:         wf.Name + "." + task.Name,
+		DisplayName:  task.Name,
+		Type:         awfv1.NodeTypePod,
+		TemplateName: template.Name,
+		Phase:        awfv1.NodeRunning,
+		StartedAt:    metav1.Now(),
+	}
+
+	if err := applyManifest(wf, template.Resource); err != nil {
+		failNode(&node, err)
+	}
+
+	return node
+}
+
+// checkNode evaluates the conditions of a running node, and returns whether its phase changed
+func checkNode(wf *awfv1.Workflow, node *awfv1.NodeStatus, template *awfv1.Template) (bool, error) {
+	resource := template.Resource
+	if resource.SuccessCondition == "" && resource.FailureCondition == "" {
+		succeedNode(node)
+		return true, nil
+	}
+
+	obj, err := workflow.ParseManifest(wf, resource.Manifest)
+	if err != nil {
+		failNode(node, err)
+		return true, nil
+	}
+
+	current, err := k8s.GetObject(obj.GroupVersionKind(), obj.GetName())
+	if err != nil {
+		return false, errors.Wrap(err, "workflow", wf.Name, node.TemplateName)
+	}
+	if current == nil {
+		failNode(node, ErrorResourceDeleted(obj.GetKind(), obj.GetName()))
+		return true, nil
+	}
+
+	succeeded, err := workflow.EvaluateConditions(current.Object, resource.SuccessCondition, resource.FailureCondition)
+	if err != nil {
+		failNode(node, err)
+		return true, nil
+	}
+	if succeeded {
+		succeedNode(node)
+		return true, nil
+	}
+	return false, nil
+}
+
+func succeedNode(node *awfv1.NodeStatus) {
+	node.Phase = awfv1.NodeSucceeded
+	node.FinishedAt = metav1.Now()
+}
+
+func failNode(node *awfv1.NodeStatus, err error) {
+	node.Phase = awfv1.NodeFailed
+	node.FinishedAt = metav1.Now()
+	node.Message = errors.Cause(err).Error()
+}
+
+func applyManifest(wf *awfv1.Workflow, resource *awfv1.ResourceTemplate) error {
+	obj, err := workflow.ParseManifest(wf, resource.Manifest)
+	if err != nil {
+		return err
+	}
+
+	switch resource.Action {
+	case ActionCreate:
+		_, err = k8s.CreateObject(obj)
+		// The resource was created in a previous pass whose update to the workflow was not saved
+		if k8serrors.IsAlreadyExists(errors.Cause(err)) {
+			return nil
+		}
+		return err
+	case ActionApply:
+		_, err = k8s.ApplyObject(obj)
+		return err
+	default:
+		return ErrorUnsupportedAction(resource.Action)
+	}
+}
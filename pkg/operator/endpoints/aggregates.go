@@ -22,7 +22,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/api/resource"
 	schema "github.com/cortexlabs/cortex/pkg/api/schema"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
 
@@ -48,7 +48,7 @@ func GetAggregate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exists, err := aws.IsS3File(aggregate.Key)
+	exists, err := storage.IsFile(aggregate.Key)
 	if RespondIfError(w, err, resource.AggregateType.String(), id) {
 		return
 	}
@@ -57,7 +57,7 @@ func GetAggregate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bytes, err := aws.ReadBytesFromS3(aggregate.Key)
+	bytes, err := storage.ReadBytes(aggregate.Key)
 	if RespondIfError(w, err, resource.AggregateType.String(), id) {
 		return
 	}
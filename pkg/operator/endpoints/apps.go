@@ -0,0 +1,43 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/rbac"
+	"github.com/cortexlabs/cortex/pkg/operator/auth"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func GetApps(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.apps")
+
+	// Only list the apps which the caller is allowed to view
+	identity := getIdentity(r)
+	apps, err := workloads.GetApps(func(appName string) bool {
+		return auth.Authorize(identity, appName, rbac.RoleViewer) == nil
+	})
+	if RespondIfError(w, err) {
+		return
+	}
+
+	response := schema.GetAppsResponse{Apps: apps}
+	Respond(w, response)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/metrics"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+const auditRecordKey contextKey = "auditRecord"
+
+// auditedFlags are the query parameters of audited calls which are recorded (values are truncated to maxAuditValueLength)
+var auditedFlags = strset.New(
+	"ctxID",
+	"dryRun",
+	"force",
+	"ignoreCache",
+	"keepCache",
+	"message",
+	"resourceName",
+	"resourceType",
+)
+
+const maxAuditValueLength = 256
+
+// Audited wraps a mutating endpoint so that every authorized call to it is recorded in the app's audit log. It runs
+// before authentication so that it can record the response; calls which fail authentication or authorization are only
+// logged and counted, so that unauthenticated callers can't write to the audit log.
+func Audited(action string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		appName := query.Get("appName")
+		if appName == "" || getOptionalBoolQParam("dryRun", false, r) {
+			handler(w, r)
+			return
+		}
+		if err := ValidateAppName(appName); err != nil {
+			metrics.DeploysTotal.Inc(action, strconv.Itoa(errorStatusCode(err)))
+			RespondError(w, err)
+			return
+		}
+
+		record := &schema.AuditRecord{
+			Timestamp:   time.Now(),
+			Action:      action,
+			AppName:     appName,
+			Environment: truncateAuditValue(query.Get("environment")),
+			Flags:       make(map[string]string),
+		}
+		for paramName := range query {
+			if auditedFlags.Has(paramName) {
+				record.Flags[paramName] = truncateAuditValue(query.Get(paramName))
+			}
+		}
+
+		auditWriter := &auditResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		func() {
+			// Panics are responded to here (rather than by the operator's panic middleware) so that they are recorded
+			defer RecoverAndRespond(auditWriter)
+			handler(auditWriter, r.WithContext(context.WithValue(r.Context(), auditRecordKey, record)))
+		}()
+
+		record.Duration = time.Since(record.Timestamp)
+		record.StatusCode = auditWriter.statusCode
+
+		metrics.DeploysTotal.Inc(action, strconv.Itoa(record.StatusCode))
+		metrics.DeployDuration.Observe(record.Duration.Seconds(), action)
+		record.Message = truncateAuditValue(responseMessage(auditWriter.body.Bytes()))
+
+		// The identity is only set by WithIdentity once the call has been authorized
+		if record.Identity == "" {
+			log.Printf("rejected %s call for app %s: %s", action, appName, record.Message)
+			return
+		}
+
+		if err := workloads.RecordAudit(record); err != nil {
+			telemetry.ReportError(err)
+			errors.PrintError(err)
+		}
+	}
+}
+
+func truncateAuditValue(value string) string {
+	if len(value) <= maxAuditValueLength {
+		return value
+	}
+	return value[:maxAuditValueLength] + "..."
+}
+
+// setAuditContextID records the context affected by an audited call
+func setAuditContextID(r *http.Request, ctxID string) {
+	if record, ok := r.Context().Value(auditRecordKey).(*schema.AuditRecord); ok {
+		record.ContextID = ctxID
+	}
+}
+
+// auditResponseWriter keeps a copy of the response so that it can be recorded
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *auditResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// responseMessage returns the message (or error) from a JSON response body
+func responseMessage(body []byte) string {
+	var response struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	json.Unmarshal(body, &response)
+	if response.Error != "" {
+		return response.Error
+	}
+	return response.Message
+}
+
+func GetAudit(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.audit")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	limit, _ := s.ParseInt(getOptionalQParam("limit", r))
+
+	records, err := workloads.GetAudit(appName, limit)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	response := schema.GetAuditResponse{Records: records}
+	Respond(w, response)
+}
@@ -35,6 +35,16 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 
 	keepCache := getOptionalBoolQParam("keepCache", false, r)
 
+	unlock, err := workloads.LockApp(appName, schema.AuditActionDelete, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
+
+	if ctx := workloads.CurrentContext(appName); ctx != nil {
+		setAuditContextID(r, ctx.ID)
+	}
+
 	wasDeployed := workloads.DeleteApp(appName, keepCache)
 
 	if !wasDeployed {
@@ -19,6 +19,8 @@ package endpoints
 import (
 	"net/http"
 
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	"github.com/cortexlabs/cortex/pkg/api/schema"
 	s "github.com/cortexlabs/cortex/pkg/api/strings"
@@ -27,8 +29,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/files"
 	"github.com/cortexlabs/cortex/pkg/lib/zip"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
@@ -38,13 +40,95 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 
 	ignoreCache := getOptionalBoolQParam("ignoreCache", false, r)
 	force := getOptionalBoolQParam("force", false, r)
+	dryRun := getOptionalBoolQParam("dryRun", false, r)
+
+	if dryRun && ignoreCache {
+		RespondError(w, ErrorDryRunIgnoreCache())
+		return
+	}
+
+	if dryRun {
+		ctx, err := getContext(r, false, true)
+		if RespondIfError(w, err) {
+			return
+		}
+		plan(w, ctx, force)
+		return
+	}
+
+	getCtx := func() (*context.Context, error) {
+		return getContext(r, ignoreCache, false)
+	}
+	deploy(w, r, schema.AuditActionDeploy, getCtx, ignoreCache, force, getOptionalQParam("message", r))
+}
+
+// deploy runs the context returned by getCtx (which may be new or previously deployed) and records it in the app's
+// history. getCtx is called while holding the app's lock, since building a new context stores its implementations
+// and (with ignoreCache) a new dataset version.
+func deploy(w http.ResponseWriter, r *http.Request, action string, getCtx func() (*context.Context, error), ignoreCache bool, force bool, message string) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	unlock, err := workloads.LockApp(appName, action, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
 
-	ctx, err := getContext(r, ignoreCache)
+	ctx, err := getCtx()
 	if RespondIfError(w, err) {
 		return
 	}
+	setAuditContextID(r, ctx.ID)
+
+	resMessage, err := runDeployment(r, ctx, ignoreCache, force, message)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	respondDeploy(w, resMessage)
+}
 
+// runDeployment runs ctx and records it in the app's history, and returns the response message; the caller must hold the app's lock
+func runDeployment(r *http.Request, ctx *context.Context, ignoreCache bool, force bool, message string) (string, error) {
 	newWf, err := workloads.Create(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	existingWf, err := workloads.GetWorkflow(ctx.App.Name)
+	if err != nil {
+		return "", err
+	}
+
+	resMessage, shouldDeploy := deployOutcome(ctx, argo.NumTasks(newWf), existingWf, ignoreCache, force)
+	if !shouldDeploy {
+		return resMessage, nil
+	}
+
+	err = storage.UploadMsgpack(ctx.ToSerial(), ctx.Key)
+	if err != nil {
+		return "", errors.Wrap(err, ctx.App.Name, "upload context")
+	}
+
+	err = workloads.Run(newWf, ctx, existingWf)
+	if err != nil {
+		return "", err
+	}
+
+	err = workloads.RecordDeployment(ctx, getIdentity(r), message)
+	if err != nil {
+		return "", err
+	}
+
+	return resMessage, nil
+}
+
+// plan responds with what deploying ctx would do, without deploying it
+func plan(w http.ResponseWriter, ctx *context.Context, force bool) {
+	deployPlan, err := workloads.Plan(ctx)
 	if RespondIfError(w, err) {
 		return
 	}
@@ -53,52 +137,52 @@ func Deploy(w http.ResponseWriter, r *http.Request) {
 	if RespondIfError(w, err) {
 		return
 	}
+
+	resMessage, shouldDeploy := deployOutcome(ctx, len(deployPlan.Workloads), existingWf, false, force)
+	deployPlan.Message = resMessage
+	if shouldDeploy && existingWf != nil && argo.IsRunning(existingWf) {
+		deployPlan.StoppedWorkflow = existingWf.Name
+		deployPlan.StoppedContextID = existingWf.Labels["ctxID"]
+	}
+
+	Respond(w, deployPlan)
+}
+
+// deployOutcome returns the response message for deploying ctx, and whether the deployment should proceed
+func deployOutcome(ctx *context.Context, numTasks int, existingWf *awfv1.Workflow, ignoreCache bool, force bool) (string, bool) {
 	isRunning := false
 	if existingWf != nil {
 		isRunning = argo.IsRunning(existingWf)
 	}
 
 	if isRunning {
-		if newWf.Labels["ctxID"] == existingWf.Labels["ctxID"] {
+		if ctx.ID == existingWf.Labels["ctxID"] {
 			prevCtx := workloads.CurrentContext(ctx.App.Name)
 			if context.APIResourcesAndComputesMatch(ctx, prevCtx) {
-				respondDeploy(w, s.ResDeploymentRunning)
-				return
+				return s.ResDeploymentRunning, false
 			}
 		}
 		if !force {
-			respondDeploy(w, s.ResDifferentDeploymentRunning)
-			return
+			return s.ResDifferentDeploymentRunning, false
 		}
 	}
 
-	err = aws.UploadMsgpackToS3(ctx.ToSerial(), ctx.Key)
-	if RespondIfError(w, err, ctx.App.Name, "upload context") {
-		return
-	}
-
-	err = workloads.Run(newWf, ctx, existingWf)
-	if RespondIfError(w, err) {
-		return
-	}
-
 	switch {
 	case isRunning && ignoreCache:
-		respondDeploy(w, s.ResDeploymentStoppedCacheDeletedDeploymentStarted)
-	case isRunning && !ignoreCache && argo.NumTasks(newWf) == 0:
-		respondDeploy(w, s.ResDeploymentStoppedDeploymentUpToDate)
-	case isRunning && !ignoreCache && argo.NumTasks(newWf) != 0:
-		respondDeploy(w, s.ResDeploymentStoppedDeploymentStarted)
+		return s.ResDeploymentStoppedCacheDeletedDeploymentStarted, true
+	case isRunning && !ignoreCache && numTasks == 0:
+		return s.ResDeploymentStoppedDeploymentUpToDate, true
+	case isRunning && !ignoreCache && numTasks != 0:
+		return s.ResDeploymentStoppedDeploymentStarted, true
 	case !isRunning && ignoreCache:
-		respondDeploy(w, s.ResCachedDeletedDeploymentStarted)
-	case !isRunning && !ignoreCache && argo.NumTasks(newWf) == 0:
-		if existingWf != nil && existingWf.Labels["ctxID"] == newWf.Labels["ctxID"] {
-			respondDeploy(w, s.ResDeploymentUpToDate)
-			return
+		return s.ResCachedDeletedDeploymentStarted, true
+	case !isRunning && !ignoreCache && numTasks == 0:
+		if existingWf != nil && existingWf.Labels["ctxID"] == ctx.ID {
+			return s.ResDeploymentUpToDate, true
 		}
-		respondDeploy(w, s.ResDeploymentUpdated)
-	case !isRunning && !ignoreCache && argo.NumTasks(newWf) != 0:
-		respondDeploy(w, s.ResDeploymentStarted)
+		return s.ResDeploymentUpdated, true
+	default:
+		return s.ResDeploymentStarted, true
 	}
 }
 
@@ -107,7 +191,13 @@ func respondDeploy(w http.ResponseWriter, message string) {
 	Respond(w, response)
 }
 
-func getContext(r *http.Request, ignoreCache bool) (*context.Context, error) {
+// getContext builds the context for the uploaded config; with dryRun, nothing is stored (see ocontext.New)
+func getContext(r *http.Request, ignoreCache bool, dryRun bool) (*context.Context, error) {
+	appName, err := getRequiredQueryParam("appName", r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	envName, err := getRequiredQueryParam("environment", r)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -131,7 +221,12 @@ func getContext(r *http.Request, ignoreCache bool) (*context.Context, error) {
 		return nil, err
 	}
 
-	ctx, err := ocontext.New(config, zipContents, ignoreCache)
+	// Access is checked against the appName param, so it must match the uploaded config
+	if config.App.Name != appName {
+		return nil, ErrorAppNameMismatch(appName, config.App.Name, "app.yaml")
+	}
+
+	ctx, err := ocontext.New(config, zipContents, ignoreCache, dryRun)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+// GetDiff compares two deployed contexts; either defaults to the app's current context
+func GetDiff(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.diff")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	fromCtx, err := getDeployedContext(getOptionalQParam("from", r), appName)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	toCtx, err := getDeployedContext(getOptionalQParam("to", r), appName)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	respondDiff(w, fromCtx, toCtx)
+}
+
+// DiffLocal compares a deployed context (the app's current context by default) to an uploaded config
+func DiffLocal(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.diff_local")
+
+	toCtx, err := getContext(r, false, true)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	fromCtx, err := getDeployedContext(getOptionalQParam("from", r), toCtx.App.Name)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	respondDiff(w, fromCtx, toCtx)
+}
+
+func getDeployedContext(ctxID string, appName string) (*context.Context, error) {
+	if ctxID != "" {
+		return downloadAppContext(ctxID, appName)
+	}
+
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		return nil, ErrorAppNotDeployed(appName)
+	}
+	return ctx, nil
+}
+
+func respondDiff(w http.ResponseWriter, fromCtx *context.Context, toCtx *context.Context) {
+	response := schema.DiffResponse{
+		FromContextID: fromCtx.ID,
+		ToContextID:   toCtx.ID,
+		Diff:          context.DiffContexts(fromCtx, toCtx),
+	}
+	Respond(w, response)
+}
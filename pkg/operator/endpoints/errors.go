@@ -26,10 +26,6 @@ type ErrorKind int
 
 const (
 	ErrUnknown ErrorKind = iota
-	ErrAuthHeaderMissing
-	ErrAuthHeaderMalformed
-	ErrAuthAPIError
-	ErrAuthForbidden
 	ErrAppNotDeployed
 	ErrFormFileMustBeProvided
 	ErrQueryParamRequired
@@ -37,15 +33,17 @@ const (
 	ErrAnyQueryParamRequired
 	ErrAnyPathParamRequired
 	ErrPending
+	ErrDryRunIgnoreCache
+	ErrAppNameMismatch
+	ErrRouteForbidden
+	ErrMetricsForbidden
+	ErrInvalidAppName
+	ErrInvalidContextID
 )
 
 var (
 	errorKinds = []string{
 		"err_unknown",
-		"err_auth_header_missing",
-		"err_auth_header_malformed",
-		"err_auth_api_error",
-		"err_auth_forbidden",
 		"err_app_not_deployed",
 		"err_form_file_must_be_provided",
 		"err_query_param_required",
@@ -53,10 +51,16 @@ var (
 		"err_any_query_param_required",
 		"err_any_path_param_required",
 		"err_pending",
+		"err_dry_run_ignore_cache",
+		"err_app_name_mismatch",
+		"err_route_forbidden",
+		"err_metrics_forbidden",
+		"err_invalid_app_name",
+		"err_invalid_context_id",
 	}
 )
 
-var _ = [1]int{}[int(ErrPending)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrInvalidContextID)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -101,37 +105,9 @@ func (e Error) Error() string {
 	return e.message
 }
 
-func ErrorAuthHeaderMissing() error {
-	return Error{
-		Kind:    ErrAuthHeaderMissing,
-		message: "auth header missing",
-	}
-}
-
-func ErrorAuthHeaderMalformed() error {
-	return Error{
-		Kind:    ErrAuthHeaderMalformed,
-		message: "auth header malformed",
-	}
-}
-
-func ErrorAuthAPIError() error {
-	return Error{
-		Kind:    ErrAuthAPIError,
-		message: "the operator is unable to verify user's credentials using AWS STS; export AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, and run `./cortex-installer.sh update operator` to update the operator's AWS credentials",
-	}
-}
-
-func ErrorAuthForbidden() error {
-	return Error{
-		Kind:    ErrAuthForbidden,
-		message: "invalid AWS credentials; run `cortex configure` to configure your CLI with credentials for any IAM user in the same AWS account as the operator",
-	}
-}
-
 func ErrorAppNotDeployed(appName string) error {
 	return Error{
-		Kind:    ErrAuthForbidden,
+		Kind:    ErrAppNotDeployed,
 		message: fmt.Sprintf("app %s is not deployed", s.UserStr(appName)),
 	}
 }
@@ -176,3 +152,45 @@ func ErrorPending() error {
 		message: "pending",
 	}
 }
+
+func ErrorDryRunIgnoreCache() error {
+	return Error{
+		Kind:    ErrDryRunIgnoreCache,
+		message: "a dry run cannot be combined with ignoring the cache",
+	}
+}
+
+func ErrorAppNameMismatch(appName string, otherAppName string, source string) error {
+	return Error{
+		Kind:    ErrAppNameMismatch,
+		message: fmt.Sprintf("the app name in the request (%s) does not match the app name in %s (%s)", s.UserStr(appName), source, s.UserStr(otherAppName)),
+	}
+}
+
+func ErrorRouteForbidden(path string) error {
+	return Error{
+		Kind:    ErrRouteForbidden,
+		message: fmt.Sprintf("%s does not have an access policy", s.UserStr(path)),
+	}
+}
+
+func ErrorMetricsForbidden() error {
+	return Error{
+		Kind:    ErrMetricsForbidden,
+		message: "metrics require the operator's metrics token (CORTEX_METRICS_TOKEN)",
+	}
+}
+
+func ErrorInvalidAppName(appName string) error {
+	return Error{
+		Kind:    ErrInvalidAppName,
+		message: fmt.Sprintf("%s is not a valid app name (app names may only contain letters, numbers, dashes, and underscores)", s.UserStr(appName)),
+	}
+}
+
+func ErrorInvalidContextID(ctxID string) error {
+	return Error{
+		Kind:    ErrInvalidContextID,
+		message: fmt.Sprintf("%s is not a valid context ID", s.UserStr(ctxID)),
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"archive/zip"
+	"fmt"
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func GetExport(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.export")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+
+	export, err := workloads.ExportApp(ctx)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", appName+".zip"))
+	if err := export.Write(w, storage.Client()); err != nil {
+		// The response has already started, so the error can't be returned; the archive is left incomplete, which cortex export detects
+		telemetry.ReportError(err)
+		errors.PrintError(err)
+	}
+}
+
+func Import(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.import")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	force := getOptionalBoolQParam("force", false, r)
+
+	// Large archives are spooled to temporary files (which are removed once the request is done) rather than held in memory
+	archiveFile, archiveHeader, err := r.FormFile("archive.zip")
+	if err != nil {
+		RespondError(w, ErrorFormFileMustBeProvided("archive.zip"))
+		return
+	}
+	defer archiveFile.Close()
+
+	archive, err := zip.NewReader(archiveFile, archiveHeader.Size)
+	if RespondIfError(w, errors.WithStack(err), "form file", "archive.zip") {
+		return
+	}
+
+	unlock, err := workloads.LockApp(appName, schema.AuditActionImport, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
+
+	// Check before uploading anything, since the running workflow may be writing the same resources' statuses
+	existingWf, err := workloads.GetWorkflow(appName)
+	if RespondIfError(w, err) {
+		return
+	}
+	if !force && existingWf != nil && argo.IsRunning(existingWf) {
+		RespondError(w, workloads.ErrorWorkflowRunning(appName))
+		return
+	}
+
+	ctx, manifest, numRestored, err := workloads.ImportApp(archive, appName)
+	if RespondIfError(w, err) {
+		return
+	}
+	setAuditContextID(r, ctx.ID)
+
+	message := getOptionalQParam("message", r)
+	if message == "" {
+		message = "import of " + manifest.ContextID
+	}
+
+	resMessage, err := runDeployment(r, ctx, false, force, message)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	response := schema.ImportResponse{
+		Message:   fmt.Sprintf("%s (restored %d cached resources)", resMessage, numRestored),
+		ContextID: ctx.ID,
+	}
+	Respond(w, response)
+}
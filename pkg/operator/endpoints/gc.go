@@ -0,0 +1,61 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func GC(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.gc")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	dryRun := getOptionalBoolQParam("dryRun", false, r)
+
+	unlock, err := workloads.LockApp(appName, schema.AuditActionGC, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
+
+	reclaimed, err := workloads.GCApp(appName, dryRun)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	objects, bytes := workloads.GCTotal(reclaimed)
+
+	message := fmt.Sprintf("Reclaimed %s (%s objects)", s.ByteSize(bytes), s.Int(objects))
+	if dryRun {
+		message = fmt.Sprintf("Would reclaim %s (%s objects)", s.ByteSize(bytes), s.Int(objects))
+	}
+
+	Respond(w, schema.GCResponse{
+		Message:   message,
+		DryRun:    dryRun,
+		Reclaimed: reclaimed,
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/metrics"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+const readyzOK = "ok"
+
+// Healthz reports that the operator is running
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(readyzOK + "\n"))
+}
+
+// Readyz reports whether the operator can reach Kubernetes, Argo, and the storage backend
+func Readyz(w http.ResponseWriter, r *http.Request) {
+	checkNames := []string{"kubernetes", "argo", "storage"}
+	checkFns := []func() error{
+		k8s.Ping,
+		argo.Ping,
+		func() error {
+			_, err := storage.IsPrefix(consts.AppsDir)
+			return err
+		},
+	}
+
+	checkErrs := parallel.Run(checkFns...)
+
+	response := schema.ReadyzResponse{
+		Ready:  !errors.HasErrors(checkErrs),
+		Checks: make(map[string]string, len(checkNames)),
+	}
+	for i, checkName := range checkNames {
+		if checkErrs[i] != nil {
+			errors.PrintError(checkErrs[i], "readyz", checkName)
+			response.Checks[checkName] = checkErrs[i].Error()
+		} else {
+			response.Checks[checkName] = readyzOK
+		}
+	}
+
+	if !response.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+	Respond(w, response)
+}
+
+// Metrics exports the operator's metrics in the Prometheus text exposition format. The app metrics aren't filtered by
+// RBAC, so scrapers must present the metrics token (CORTEX_METRICS_TOKEN), and metrics are disabled if there is none.
+func Metrics(w http.ResponseWriter, r *http.Request) {
+	expected := []byte("Bearer " + cc.MetricsToken)
+	if cc.MetricsToken == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+		RespondError(w, ErrorMetricsForbidden())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	if err := metrics.Write(w); err != nil {
+		errors.PrintError(err, "metrics")
+	}
+}
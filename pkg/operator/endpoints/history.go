@@ -0,0 +1,47 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func GetHistory(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.history")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	history, err := workloads.GetHistory(appName)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	if len(history) == 0 {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+
+	response := schema.GetHistoryResponse{History: history}
+	Respond(w, response)
+}
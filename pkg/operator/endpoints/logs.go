@@ -23,6 +23,7 @@ import (
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
+	"github.com/cortexlabs/cortex/pkg/operator/metrics"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
 
@@ -109,5 +110,8 @@ func readLogs(w http.ResponseWriter, r *http.Request, workloadID string, appName
 	}
 	defer socket.Close()
 
+	metrics.WebsocketSessions.Inc("logs")
+	defer metrics.WebsocketSessions.Dec("logs")
+
 	workloads.ReadLogs(appName, workloadID, verbose, socket)
 }
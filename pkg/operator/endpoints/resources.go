@@ -19,7 +19,11 @@ package endpoints
 import (
 	"net/http"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/operator/metrics"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
 
@@ -35,33 +39,79 @@ func GetResources(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dataStatuses, err := workloads.GetCurrentDataStatuses(ctx)
+	response, err := workloads.GetResources(ctx)
 	if RespondIfError(w, err) {
 		return
 	}
 
-	apiStatuses, err := workloads.GetCurrentAPIStatuses(ctx, dataStatuses)
+	Respond(w, response)
+}
+
+// WatchResources streams changes to the app's resource statuses over a websocket (see schema.ResourcesUpdate)
+func WatchResources(w http.ResponseWriter, r *http.Request) {
+	appName, err := getRequiredQueryParam("appName", r)
 	if RespondIfError(w, err) {
 		return
 	}
 
-	apiGroupStatuses, err := workloads.GetAPIGroupStatuses(apiStatuses, ctx)
-	if RespondIfError(w, err) {
+	if workloads.CurrentContext(appName) == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
 		return
 	}
 
-	apisBaseURL, err := workloads.APIsBaseURL()
+	upgrader := websocket.Upgrader{}
+	socket, err := upgrader.Upgrade(w, r, nil)
 	if RespondIfError(w, err) {
 		return
 	}
+	defer socket.Close()
 
-	response := schema.GetResourcesResponse{
-		Context:          ctx,
-		DataStatuses:     dataStatuses,
-		APIStatuses:      apiStatuses,
-		APIGroupStatuses: apiGroupStatuses,
-		APIsBaseURL:      apisBaseURL,
-	}
+	metrics.WebsocketSessions.Inc("resources_watch")
+	defer metrics.WebsocketSessions.Dec("resources_watch")
 
-	Respond(w, response)
+	snapshots, unsubscribe := workloads.SubscribeResources(appName)
+	defer unsubscribe()
+
+	// The client doesn't send any messages, so this only detects when it disconnects
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := socket.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	var prevResources *schema.GetResourcesResponse
+	for {
+		select {
+		case <-disconnected:
+			return
+		case snapshot := <-snapshots:
+			var update *schema.ResourcesUpdate
+			switch {
+			case snapshot.Err != nil:
+				errors.PrintError(snapshot.Err)
+				update = &schema.ResourcesUpdate{Error: errorResponse(snapshot.Err, errorStatusCode(snapshot.Err))}
+			case snapshot.Resources == nil:
+				err := ErrorAppNotDeployed(appName)
+				update = &schema.ResourcesUpdate{Error: errorResponse(err, errorStatusCode(err))}
+			default:
+				update = schema.NewResourcesUpdate(prevResources, snapshot.Resources)
+				prevResources = snapshot.Resources
+			}
+
+			if update == nil {
+				continue
+			}
+			if err := socket.WriteJSON(update); err != nil {
+				return
+			}
+			if update.Error != nil {
+				socket.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+		}
+	}
 }
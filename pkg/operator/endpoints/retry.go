@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func Retry(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.retry")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	resourceName, err := getRequiredQueryParam("resourceName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+	resourceType := getOptionalQParam("resourceType", r)
+
+	unlock, err := workloads.LockApp(appName, schema.AuditActionRetry, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
+
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+	setAuditContextID(r, ctx.ID)
+
+	var res context.ComputedResource
+	if resourceType != "" {
+		res, err = ctx.VisibleResourceByNameAndType(resourceName, resourceType)
+	} else {
+		res, err = ctx.VisibleResourceByName(resourceName)
+	}
+	if RespondIfError(w, err) {
+		return
+	}
+
+	resourceIDs, err := workloads.RetryResource(res, ctx)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	response := schema.RetryResponse{
+		Message:     fmt.Sprintf("Retrying %s (%d resources will be recomputed)", res.GetName(), len(resourceIDs)),
+		ResourceIDs: resourceIDs.Slice(),
+	}
+	Respond(w, response)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/regex"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+)
+
+func Rollback(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.rollback")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	ctxID, err := getRequiredQueryParam("ctxID", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	force := getOptionalBoolQParam("force", false, r)
+
+	message := getOptionalQParam("message", r)
+	if message == "" {
+		message = "rollback to " + ctxID
+	}
+
+	getCtx := func() (*context.Context, error) {
+		return downloadAppContext(ctxID, appName)
+	}
+	deploy(w, r, schema.AuditActionRollback, getCtx, false, force, message)
+}
+
+// downloadAppContext downloads one of appName's contexts by its ID; the ID is checked so that it can't refer to another app's context
+func downloadAppContext(ctxID string, appName string) (*context.Context, error) {
+	if !regex.CheckLowercaseHex(ctxID) {
+		return nil, ErrorInvalidContextID(ctxID)
+	}
+
+	ctx, err := ocontext.DownloadContext(ctxID, appName)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.App.Name != appName {
+		return nil, ErrorAppNameMismatch(appName, ctx.App.Name, "context "+ctxID)
+	}
+	return ctx, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package endpoints
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -24,8 +25,13 @@ import (
 
 	"github.com/cortexlabs/cortex/pkg/api/schema"
 	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/lib/auth"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/rbac"
+	"github.com/cortexlabs/cortex/pkg/lib/regex"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
 
 func Respond(w http.ResponseWriter, response interface{}) {
@@ -34,7 +40,7 @@ func Respond(w http.ResponseWriter, response interface{}) {
 }
 
 func RespondError(w http.ResponseWriter, err error, strs ...string) {
-	RespondErrorCode(w, http.StatusBadRequest, err, strs...)
+	RespondErrorCode(w, errorStatusCode(err), err, strs...)
 }
 
 func RespondErrorCode(w http.ResponseWriter, code int, err error, strs ...string) {
@@ -42,10 +48,57 @@ func RespondErrorCode(w http.ResponseWriter, code int, err error, strs ...string
 	errors.PrintError(err)
 
 	w.WriteHeader(code)
-	response := schema.ErrorResponse{
-		Error: err.Error(),
+	json.NewEncoder(w).Encode(errorResponse(err, code))
+}
+
+func errorResponse(err error, code int) *schema.ErrorResponse {
+	return &schema.ErrorResponse{
+		Error:      err.Error(),
+		Kind:       errors.Kind(err),
+		Message:    errors.Message(err),
+		Path:       errors.Path(err),
+		StatusCode: code,
 	}
-	json.NewEncoder(w).Encode(response)
+}
+
+// errorStatusCode maps the kind of err's cause to an HTTP status code
+func errorStatusCode(err error) int {
+	switch cause := errors.Cause(err).(type) {
+	case Error:
+		switch cause.Kind {
+		case ErrAppNotDeployed:
+			return http.StatusNotFound
+		case ErrRouteForbidden, ErrMetricsForbidden:
+			return http.StatusForbidden
+		}
+	case auth.Error:
+		switch cause.Kind {
+		case auth.ErrAuthHeaderMissing, auth.ErrAuthHeaderMalformed:
+			return http.StatusUnauthorized
+		case auth.ErrAWSAuthForbidden, auth.ErrInvalidToken, auth.ErrTokenExpired:
+			return http.StatusForbidden
+		case auth.ErrAWSAuthAPIError:
+			return http.StatusInternalServerError
+		}
+	case rbac.Error:
+		if cause.Kind == rbac.ErrForbidden {
+			return http.StatusForbidden
+		}
+	case ocontext.Error:
+		if cause.Kind == ocontext.ErrContextNotFound {
+			return http.StatusNotFound
+		}
+	case workloads.Error:
+		switch cause.Kind {
+		case workloads.ErrNotFound:
+			return http.StatusNotFound
+		case workloads.ErrDeploymentInProgress, workloads.ErrWorkflowRunning:
+			return http.StatusConflict
+		case workloads.ErrCortexInstallationBroken, workloads.ErrLoadBalancerInitializing:
+			return http.StatusServiceUnavailable
+		}
+	}
+	return http.StatusBadRequest
 }
 
 func RespondIfError(w http.ResponseWriter, err error, strs ...string) bool {
@@ -92,3 +145,29 @@ func getOptionalBoolQParam(paramName string, defaultVal bool, r *http.Request) b
 	}
 	return defaultVal
 }
+
+type contextKey string
+
+const identityKey contextKey = "identity"
+
+// ValidateAppName checks an app name from a request, since app names are used in storage keys
+func ValidateAppName(appName string) error {
+	if !regex.CheckAlphaNumericDashUnderscore(appName) {
+		return ErrorInvalidAppName(appName)
+	}
+	return nil
+}
+
+// WithIdentity attaches the authorized caller's identity to the request (and to its audit record, if it is audited)
+func WithIdentity(r *http.Request, identity string) *http.Request {
+	if record, ok := r.Context().Value(auditRecordKey).(*schema.AuditRecord); ok {
+		record.Identity = identity
+	}
+	return r.WithContext(context.WithValue(r.Context(), identityKey, identity))
+}
+
+// getIdentity returns the identity that authMiddleware attached to the request
+func getIdentity(r *http.Request) string {
+	identity, _ := r.Context().Value(identityKey).(string)
+	return identity
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"net/http"
+
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+func Stop(w http.ResponseWriter, r *http.Request) {
+	telemetry.ReportEvent("endpoint.stop")
+
+	appName, err := getRequiredQueryParam("appName", r)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	unlock, err := workloads.LockApp(appName, schema.AuditActionStop, getIdentity(r))
+	if RespondIfError(w, err) {
+		return
+	}
+	defer unlock()
+
+	ctx := workloads.CurrentContext(appName)
+	if ctx == nil {
+		RespondError(w, ErrorAppNotDeployed(appName))
+		return
+	}
+	setAuditContextID(r, ctx.ID)
+
+	wasRunning, err := workloads.StopApp(ctx)
+	if RespondIfError(w, err) {
+		return
+	}
+
+	response := schema.StopResponse{Message: s.ResDeploymentStopped}
+	if !wasRunning {
+		response.Message = s.ResDeploymentNotRunning
+	}
+	Respond(w, response)
+}
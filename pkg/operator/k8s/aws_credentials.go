@@ -48,3 +48,16 @@ func AWSCredentials() []corev1.EnvVar {
 
 	return envVars
 }
+
+// StorageEnvVars returns the environment variables which workloads use to reach storage: the AWS credentials, and
+// the endpoint of the S3-compatible store (CORTEX_STORAGE_ENDPOINT) unless it is ""
+func StorageEnvVars(endpoint string) []corev1.EnvVar {
+	envVars := AWSCredentials()
+	if endpoint != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "CORTEX_STORAGE_ENDPOINT",
+			Value: endpoint,
+		})
+	}
+	return envVars
+}
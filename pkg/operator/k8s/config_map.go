@@ -0,0 +1,112 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var configMapTypeMeta = metav1.TypeMeta{
+	APIVersion: "v1",
+	Kind:       "ConfigMap",
+}
+
+type ConfigMapSpec struct {
+	Name      string
+	Namespace string
+	Data      map[string]string
+	Labels    map[string]string
+}
+
+func ConfigMap(spec *ConfigMapSpec) *corev1.ConfigMap {
+	if spec.Namespace == "" {
+		spec.Namespace = "default"
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta: configMapTypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.Name,
+			Namespace: spec.Namespace,
+			Labels:    spec.Labels,
+		},
+		Data: spec.Data,
+	}
+	return configMap
+}
+
+func CreateConfigMap(spec *ConfigMapSpec) (*corev1.ConfigMap, error) {
+	configMap, err := configMapClient.Create(ConfigMap(spec))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return configMap, nil
+}
+
+func UpdateConfigMap(configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	configMap, err := configMapClient.Update(configMap)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return configMap, nil
+}
+
+func GetConfigMap(name string) (*corev1.ConfigMap, error) {
+	configMap, err := configMapClient.Get(name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	configMap.TypeMeta = configMapTypeMeta
+	return configMap, nil
+}
+
+func DeleteConfigMap(name string) (bool, error) {
+	err := configMapClient.Delete(name, deleteOpts)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func ListConfigMaps(opts *metav1.ListOptions) ([]corev1.ConfigMap, error) {
+	if opts == nil {
+		opts = &metav1.ListOptions{}
+	}
+	configMapList, err := configMapClient.List(*opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range configMapList.Items {
+		configMapList.Items[i].TypeMeta = configMapTypeMeta
+	}
+	return configMapList.Items, nil
+}
+
+func ListConfigMapsByLabels(labels map[string]string) ([]corev1.ConfigMap, error) {
+	opts := &metav1.ListOptions{
+		LabelSelector: LabelSelector(labels),
+	}
+	return ListConfigMaps(opts)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+var dynamicClient dynamic.Interface
+
+var (
+	restMapper      meta.RESTMapper
+	restMapperMutex sync.Mutex
+)
+
+// resourceClient returns a client for gvk, discovering the kinds served by the API server if necessary
+func resourceClient(gvk schema.GroupVersionKind) (dynamic.ResourceInterface, error) {
+	restMapperMutex.Lock()
+	defer restMapperMutex.Unlock()
+
+	var mapping *meta.RESTMapping
+	var err error
+	if restMapper != nil {
+		mapping, err = restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+
+	// Custom resources may have been installed since the kinds were last discovered
+	if restMapper == nil || meta.IsNoMatchError(err) {
+		var groupResources []*restmapper.APIGroupResources
+		groupResources, err = restmapper.GetAPIGroupResources(clientset.Discovery())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		restMapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+		mapping, err = restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, gvk.String())
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return dynamicClient.Resource(mapping.Resource), nil
+	}
+	return dynamicClient.Resource(mapping.Resource).Namespace(cc.Namespace), nil
+}
+
+// CreateObject creates an object of any kind
+func CreateObject(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := resourceClient(obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+	obj, err = client.Create(obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return obj, nil
+}
+
+// ApplyObject creates an object of any kind, or replaces it if it already exists
+func ApplyObject(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	client, err := resourceClient(obj.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := client.Get(obj.GetName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		obj, err = client.Create(obj, metav1.CreateOptions{})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return obj, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	obj, err = client.Update(obj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return obj, nil
+}
+
+// GetObject gets an object of any kind, and returns nil if it does not exist
+func GetObject(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	client, err := resourceClient(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Get(name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return obj, nil
+}
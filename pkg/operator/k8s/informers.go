@@ -0,0 +1,141 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	listerbatchv1 "k8s.io/client-go/listers/batch/v1"
+	listercorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+// InformerResyncPeriod is how often informers re-deliver every object, in case an event was missed
+const InformerResyncPeriod = 5 * time.Minute
+
+var (
+	informerFactory    informers.SharedInformerFactory
+	podInformer        cache.SharedIndexInformer
+	deploymentInformer cache.SharedIndexInformer
+	jobInformer        cache.SharedIndexInformer
+	podLister          listercorev1.PodLister
+	jobLister          listerbatchv1.JobLister
+)
+
+func initInformers() {
+	informerFactory = informers.NewFilteredSharedInformerFactory(clientset, InformerResyncPeriod, cc.Namespace, nil)
+	podInformer = informerFactory.Core().V1().Pods().Informer()
+	podLister = informerFactory.Core().V1().Pods().Lister()
+	deploymentInformer = informerFactory.Apps().V1beta1().Deployments().Informer()
+	jobInformer = informerFactory.Batch().V1().Jobs().Informer()
+	jobLister = informerFactory.Batch().V1().Jobs().Lister()
+}
+
+// NewInformerFactory creates an informer factory which only watches the namespace's objects that match labelSelector
+func NewInformerFactory(labelSelector string) informers.SharedInformerFactory {
+	return informers.NewFilteredSharedInformerFactory(clientset, InformerResyncPeriod, cc.Namespace, func(opts *metav1.ListOptions) {
+		opts.LabelSelector = labelSelector
+	})
+}
+
+// StartInformers starts watching pods, deployments, and jobs, and waits until their caches are populated
+func StartInformers(stopCh <-chan struct{}) error {
+	informerFactory.Start(stopCh)
+	return WaitForInformers("kubernetes", stopCh, podInformer.HasSynced, deploymentInformer.HasSynced, jobInformer.HasSynced)
+}
+
+// WaitForInformers waits until the informers' caches are populated
+func WaitForInformers(name string, stopCh <-chan struct{}, hasSynced ...cache.InformerSynced) error {
+	if !cache.WaitForCacheSync(stopCh, hasSynced...) {
+		return errors.New(name, "informers", "failed to sync caches")
+	}
+	return nil
+}
+
+// ChangeHandler calls onChange whenever an informer's object is added, updated, or deleted
+func ChangeHandler(onChange func()) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { onChange() },
+		UpdateFunc: func(oldObj, newObj interface{}) { onChange() },
+		DeleteFunc: func(obj interface{}) { onChange() },
+	}
+}
+
+func OnPodChange(onChange func()) {
+	podInformer.AddEventHandler(ChangeHandler(onChange))
+}
+
+func OnDeploymentChange(onChange func()) {
+	deploymentInformer.AddEventHandler(ChangeHandler(onChange))
+}
+
+func OnJobChange(onChange func()) {
+	jobInformer.AddEventHandler(ChangeHandler(onChange))
+}
+
+// ListCachedPodsByLabels lists pods from the informer's cache instead of the API server
+func ListCachedPodsByLabels(labelMap map[string]string) ([]corev1.Pod, error) {
+	return listCachedPods(labels.SelectorFromSet(labelMap), "")
+}
+
+// ListCachedPodsByPhase lists pods from the informer's cache instead of the API server
+func ListCachedPodsByPhase(phase corev1.PodPhase) ([]corev1.Pod, error) {
+	return listCachedPods(labels.Everything(), phase)
+}
+
+func listCachedPods(selector labels.Selector, phase corev1.PodPhase) ([]corev1.Pod, error) {
+	podPtrs, err := podLister.Pods(cc.Namespace).List(selector)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(podPtrs))
+	for _, podPtr := range podPtrs {
+		if phase != "" && podPtr.Status.Phase != phase {
+			continue
+		}
+		// Objects in the cache are shared, so they must not be modified
+		pod := *podPtr.DeepCopy()
+		pod.TypeMeta = podTypeMeta
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// GetCachedJob gets a job from the informer's cache instead of the API server, or returns nil if it doesn't exist
+func GetCachedJob(name string) (*batchv1.Job, error) {
+	jobPtr, err := jobLister.Jobs(cc.Namespace).Get(name)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Objects in the cache are shared, so they must not be modified
+	job := jobPtr.DeepCopy()
+	job.TypeMeta = jobTypeMeta
+	return job, nil
+}
@@ -33,10 +33,11 @@ var jobTypeMeta = metav1.TypeMeta{
 }
 
 type JobSpec struct {
-	Name      string
-	Namespace string
-	PodSpec   PodSpec
-	Labels    map[string]string
+	Name                  string
+	Namespace             string
+	PodSpec               PodSpec
+	Labels                map[string]string
+	ActiveDeadlineSeconds *int64
 }
 
 func Job(spec *JobSpec) *batchv1.Job {
@@ -62,9 +63,10 @@ func Job(spec *JobSpec) *batchv1.Job {
 			Labels:    spec.Labels,
 		},
 		Spec: batchv1.JobSpec{
-			BackoffLimit: &backoffLimit,
-			Parallelism:  &parallelism,
-			Completions:  &completions,
+			BackoffLimit:          &backoffLimit,
+			Parallelism:           &parallelism,
+			Completions:           &completions,
+			ActiveDeadlineSeconds: spec.ActiveDeadlineSeconds,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      spec.PodSpec.Name,
@@ -106,6 +108,19 @@ func GetJob(name string) (*batchv1.Job, error) {
 	return job, nil
 }
 
+// IsJobDeadlineExceeded returns whether Kubernetes terminated the job because it ran for longer than its activeDeadlineSeconds
+func IsJobDeadlineExceeded(job *batchv1.Job) bool {
+	if job == nil {
+		return false
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue && condition.Reason == "DeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
 func DeleteJob(name string) (bool, error) {
 	err := jobClient.Delete(name, deleteOpts)
 	if k8serrors.IsNotFound(err) {
@@ -23,6 +23,7 @@ import (
 
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynamic "k8s.io/client-go/dynamic"
 	kubernetes "k8s.io/client-go/kubernetes"
 	tappsv1b1 "k8s.io/client-go/kubernetes/typed/apps/v1beta1"
 	tbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
@@ -49,6 +50,7 @@ var (
 	deploymentClient tappsv1b1.DeploymentInterface
 	jobClient        tbatchv1.JobInterface
 	ingressClient    textensionsv1b1.IngressInterface
+	configMapClient  tcorev1.ConfigMapInterface
 )
 
 var deletePolicy = metav1.DeletePropagationBackground
@@ -85,6 +87,22 @@ func init() {
 	deploymentClient = clientset.AppsV1beta1().Deployments(cc.Namespace)
 	jobClient = clientset.BatchV1().Jobs(cc.Namespace)
 	ingressClient = clientset.ExtensionsV1beta1().Ingresses(cc.Namespace)
+	configMapClient = clientset.CoreV1().ConfigMaps(cc.Namespace)
+
+	dynamicClient, err = dynamic.NewForConfig(Config)
+	if err != nil {
+		err = errors.Wrap(err, "kubeconfig")
+		telemetry.ReportErrorBlocking(err)
+		errors.Exit(err)
+	}
+
+	initInformers()
+}
+
+// Ping checks that the Kubernetes API server is reachable
+func Ping() error {
+	_, err := clientset.Discovery().ServerVersion()
+	return errors.WithStack(err)
 }
 
 // ValidName ensures name contains only lower case alphanumeric, '-', or '.'
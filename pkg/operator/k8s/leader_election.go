@@ -0,0 +1,84 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	tcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Leader transitions are recorded as events on the lock's config map
+var eventBroadcaster record.EventBroadcaster
+var eventBroadcasterOnce sync.Once
+
+// RunLeaderElection campaigns for the lease stored in the lockName config map, and calls onStartedLeading
+// once identity holds it. When the lease is lost, the context passed to onStartedLeading is cancelled and
+// onStoppedLeading is called. RunLeaderElection blocks until then.
+func RunLeaderElection(lockName string, identity string, onStartedLeading func(context.Context), onStoppedLeading func()) error {
+	eventBroadcasterOnce.Do(func() {
+		eventBroadcaster = record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&tcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events(cc.Namespace)})
+	})
+
+	lock := &resourcelock.ConfigMapLock{
+		ConfigMapMeta: metav1.ObjectMeta{
+			Name:      lockName,
+			Namespace: cc.Namespace,
+		},
+		Client: clientset.CoreV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: lockName}),
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: onStartedLeading,
+			OnStoppedLeading: onStoppedLeading,
+		},
+		Name: lockName,
+	})
+	if err != nil {
+		return errors.Wrap(err, "leader election", lockName)
+	}
+
+	elector.Run(context.Background())
+	return nil
+}
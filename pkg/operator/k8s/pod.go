@@ -265,9 +265,7 @@ func PodMap(pods []corev1.Pod) map[string]corev1.Pod {
 func StalledPods() ([]corev1.Pod, error) {
 	var stalledPods []corev1.Pod
 
-	pods, err := ListPods(&metav1.ListOptions{
-		FieldSelector: "status.phase=Pending",
-	})
+	pods, err := ListCachedPodsByPhase(corev1.PodPending)
 	if err != nil {
 		return nil, err
 	}
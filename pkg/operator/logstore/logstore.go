@@ -0,0 +1,56 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logstore
+
+import (
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	liblogstore "github.com/cortexlabs/cortex/pkg/lib/logstore"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+var client liblogstore.Client
+
+func init() {
+	var err error
+
+	switch cc.LogStoreType {
+	case liblogstore.TypeCloudWatch:
+		client, err = liblogstore.NewCloudWatchClient(cc.LogGroup, cc.Region)
+	case liblogstore.TypeLocal:
+		client, err = liblogstore.NewLocalClient(cc.LogStoreLocalDir)
+	case liblogstore.TypeElasticsearch, liblogstore.TypeLoki:
+		if cc.LogStoreEndpoint == "" {
+			errors.Exit(cr.ErrorMustBeDefined(), s.EnvVar("CORTEX_LOG_STORE_ENDPOINT"))
+		}
+		if cc.LogStoreType == liblogstore.TypeElasticsearch {
+			client, err = liblogstore.NewElasticsearchClient(cc.LogStoreEndpoint)
+		} else {
+			client, err = liblogstore.NewLokiClient(cc.LogStoreEndpoint)
+		}
+	}
+
+	if err != nil {
+		errors.Exit(err, "log store")
+	}
+}
+
+// GetLogs returns the logs of all pods whose names start with prefix (used once the pods are gone)
+func GetLogs(prefix string) (string, error) {
+	return client.GetLogs(prefix)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"io"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/metrics"
+	"github.com/cortexlabs/cortex/pkg/operator/workloads"
+)
+
+var registry = metrics.NewRegistry()
+
+var (
+	DeploysTotal = registry.NewCounter(
+		"cortex_operator_deploys_total",
+		"Number of requests which change apps (those recorded in the apps' audit logs), by audit action and response status code.",
+		"action", "status_code",
+	)
+	DeployDuration = registry.NewHistogram(
+		"cortex_operator_deploy_duration_seconds",
+		"Duration of requests which change apps (those recorded in the apps' audit logs), by audit action.",
+		[]float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		"action",
+	)
+	StatusUpdateDuration = registry.NewHistogram(
+		"cortex_operator_status_update_duration_seconds",
+		"Duration of the operator's status updates.",
+		metrics.DefaultBuckets,
+	)
+	StatusUpdateErrors = registry.NewCounter(
+		"cortex_operator_status_update_errors_total",
+		"Number of errors in the operator's status updates, by step.",
+		"step",
+	)
+	StatusUpdateLastSuccess = registry.NewGauge(
+		"cortex_operator_status_update_last_success_timestamp_seconds",
+		"Unix time of the operator's last status update which had no errors.",
+	)
+	Leader = registry.NewGauge(
+		"cortex_operator_leader",
+		"Whether this operator replica is the leader (which updates statuses).",
+	)
+	WebsocketSessions = registry.NewGauge(
+		"cortex_operator_websocket_sessions",
+		"Number of open websocket sessions (log streams and status watches), by endpoint.",
+		"endpoint",
+	)
+	cacheEntries = registry.NewGauge(
+		"cortex_operator_cache_entries",
+		"Number of entries in the operator's status caches.",
+		"cache",
+	)
+	appResources = registry.NewGauge(
+		"cortex_app_resources",
+		"Number of resources in each deployed app, by status (updated by the leader's status updates).",
+		"app_name", "status",
+	)
+)
+
+func init() {
+	registry.OnCollect(collectCacheSizes)
+}
+
+// Write writes all metrics in the Prometheus text exposition format
+func Write(w io.Writer) error {
+	return registry.Write(w)
+}
+
+func collectCacheSizes() {
+	for cache, size := range workloads.CacheSizes() {
+		cacheEntries.Set(float64(size), cache)
+	}
+}
+
+// UpdateAppResources sets the app resource gauges from the current statuses; it is called after each status update
+// rather than on every scrape, since it reads every app's statuses
+func UpdateAppResources() {
+	counts := make(map[[2]string]int)
+	for _, ctx := range workloads.CurrentContexts() {
+		resources, err := workloads.GetResources(ctx)
+		if err != nil {
+			errors.PrintError(err, "metrics", ctx.App.Name)
+			continue
+		}
+
+		for _, dataStatus := range resources.DataStatuses {
+			counts[[2]string{ctx.App.Name, dataStatus.Code.String()}]++
+		}
+		for _, apiGroupStatus := range resources.APIGroupStatuses {
+			counts[[2]string{ctx.App.Name, apiGroupStatus.Code.String()}]++
+		}
+	}
+
+	appResources.Reset()
+	for labels, count := range counts {
+		appResources.Set(float64(count), labels[0], labels[1])
+	}
+}
+
+// ResetAppResources clears the app resource gauges (e.g. when this replica stops updating statuses)
+func ResetAppResources() {
+	appResources.Reset()
+}
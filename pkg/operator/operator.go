@@ -17,23 +17,31 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	cron "gopkg.in/robfig/cron.v2"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/rbac"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/auth"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/dag"
 	"github.com/cortexlabs/cortex/pkg/operator/endpoints"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/metrics"
+	"github.com/cortexlabs/cortex/pkg/operator/spark"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 	"github.com/cortexlabs/cortex/pkg/operator/workloads"
 )
@@ -41,25 +49,104 @@ import (
 const (
 	operatorPortStr       = "8888"
 	workflowDeletionDelay = 60 // seconds
-	cronInterval          = 5  // seconds
+	syncInterval          = 5  // seconds
+
+	// Bursts of informer events (e.g. while pods are being scheduled) are coalesced into one status update per interval
+	statusUpdateMinInterval = time.Second
+
+	// Statuses are also updated periodically, since retry backoffs and workload timeouts elapse without informer events
+	statusUpdateInterval = 10 * time.Second
 )
 
 var markedWorkflows = strset.New()
 
+const leaderElectionLockName = "operator-leader"
+
+var (
+	leaderMutex sync.Mutex
+	isLeader    bool
+)
+
+// statusUpdateRequests holds at most one pending request, so that requests made during an update are coalesced
+var statusUpdateRequests = make(chan struct{}, 1)
+
+// routeRoles are the roles required for app-scoped routes (keyed by path template)
+var routeRoles = map[string]rbac.Role{
+	"/deploy":          rbac.RoleDeployer,
+	"/rollback":        rbac.RoleDeployer,
+	"/retry":           rbac.RoleDeployer,
+	"/stop":            rbac.RoleDeployer,
+	"/cache/rm":        rbac.RoleDeployer,
+	"/export":          rbac.RoleDeployer,
+	"/import":          rbac.RoleDeployer,
+	"/gc":              rbac.RoleAdmin,
+	"/delete":          rbac.RoleAdmin,
+	"/history":         rbac.RoleViewer,
+	"/audit":           rbac.RoleViewer,
+	"/diff":            rbac.RoleViewer,
+	"/cache":           rbac.RoleViewer,
+	"/resources":       rbac.RoleViewer,
+	"/resources/watch": rbac.RoleViewer,
+	"/aggregate/{id}":  rbac.RoleViewer,
+	"/logs/read":       rbac.RoleViewer,
+}
+
+// routeAuditActions are the audit actions of the routes whose calls are recorded in the app's audit log (keyed by path template)
+var routeAuditActions = map[string]string{
+	"/deploy":   schema.AuditActionDeploy,
+	"/delete":   schema.AuditActionDelete,
+	"/rollback": schema.AuditActionRollback,
+	"/retry":    schema.AuditActionRetry,
+	"/stop":     schema.AuditActionStop,
+	"/gc":       schema.AuditActionGC,
+	"/cache/rm": schema.AuditActionCacheRm,
+	"/import":   schema.AuditActionImport,
+}
+
+// unscopedRoutes are the authenticated routes which aren't scoped to an app, and filter what they return by role themselves
+var unscopedRoutes = strset.New(
+	"/apps",
+)
+
 func main() {
 	telemetry.ReportEvent("operator.init")
-	startCron()
+	startInformers()
+	startSyncCron()
+	startGCCron()
+	go runLeaderElection()
 
 	router := mux.NewRouter()
 	router.Use(panicMiddleware)
-	router.Use(apiVersionCheckMiddleware)
-	router.Use(authMiddleware)
 
-	router.HandleFunc("/deploy", endpoints.Deploy).Methods("POST")
-	router.HandleFunc("/delete", endpoints.Delete).Methods("POST")
-	router.HandleFunc("/resources", endpoints.GetResources).Methods("GET")
-	router.HandleFunc("/aggregate/{id}", endpoints.GetAggregate).Methods("GET")
-	router.HandleFunc("/logs/read", endpoints.ReadLogs)
+	// Probes are unauthenticated so that Kubernetes can reach them; metrics check the metrics token themselves
+	router.HandleFunc("/healthz", endpoints.Healthz).Methods("GET")
+	router.HandleFunc("/readyz", endpoints.Readyz).Methods("GET")
+	router.HandleFunc("/metrics", endpoints.Metrics).Methods("GET")
+
+	api := router.PathPrefix("/").Subrouter()
+	api.Use(apiVersionCheckMiddleware)
+	api.Use(auditMiddleware)
+	api.Use(authMiddleware)
+
+	api.HandleFunc("/deploy", endpoints.Deploy).Methods("POST")
+	api.HandleFunc("/delete", endpoints.Delete).Methods("POST")
+	api.HandleFunc("/rollback", endpoints.Rollback).Methods("POST")
+	api.HandleFunc("/retry", endpoints.Retry).Methods("POST")
+	api.HandleFunc("/stop", endpoints.Stop).Methods("POST")
+	api.HandleFunc("/gc", endpoints.GC).Methods("POST")
+	api.HandleFunc("/cache/rm", endpoints.RemoveCache).Methods("POST")
+	api.HandleFunc("/import", endpoints.Import).Methods("POST")
+	api.HandleFunc("/export", endpoints.GetExport).Methods("GET")
+	api.HandleFunc("/history", endpoints.GetHistory).Methods("GET")
+	api.HandleFunc("/audit", endpoints.GetAudit).Methods("GET")
+	api.HandleFunc("/diff", endpoints.GetDiff).Methods("GET")
+	api.HandleFunc("/diff", endpoints.DiffLocal).Methods("POST")
+	api.HandleFunc("/cache", endpoints.GetCache).Methods("GET")
+	api.HandleFunc("/apps", endpoints.GetApps).Methods("GET")
+	api.HandleFunc("/resources", endpoints.GetResources).Methods("GET")
+	api.HandleFunc("/resources/watch", endpoints.WatchResources)
+	api.HandleFunc("/aggregate/{id}", endpoints.GetAggregate).Methods("GET")
+	api.HandleFunc("/logs/read", endpoints.ReadLogs)
 
 	log.Print("Running on port " + operatorPortStr)
 	log.Fatal(http.ListenAndServe(":"+operatorPortStr, router))
@@ -72,37 +159,69 @@ func panicMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func authMiddleware(next http.Handler) http.Handler {
+// auditMiddleware records calls to the routes in routeAuditActions (calls which fail authentication or authorization are logged instead)
+func auditMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-
-		if !strings.HasPrefix(authHeader, "CortexAWS") {
-			endpoints.RespondError(w, endpoints.ErrorAuthHeaderMissing())
-			return
-		}
-
-		parts := strings.Split(authHeader[10:], "|")
-		if len(parts) != 2 {
-			endpoints.RespondError(w, endpoints.ErrorAuthHeaderMalformed())
-			return
+		if route := mux.CurrentRoute(r); route != nil {
+			if pathTemplate, err := route.GetPathTemplate(); err == nil {
+				if action, ok := routeAuditActions[pathTemplate]; ok {
+					endpoints.Audited(action, next.ServeHTTP)(w, r)
+					return
+				}
+			}
 		}
+		next.ServeHTTP(w, r)
+	})
+}
 
-		accessKeyID, secretAccessKey := parts[0], parts[1]
-		authed, err := aws.AuthUser(accessKeyID, secretAccessKey)
+func authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := auth.Authenticate(r.Header.Get("Authorization"))
 		if err != nil {
-			endpoints.RespondError(w, endpoints.ErrorAuthAPIError())
+			endpoints.RespondError(w, err)
 			return
 		}
 
-		if !authed {
-			endpoints.RespondErrorCode(w, http.StatusForbidden, endpoints.ErrorAuthForbidden())
+		if err := authorize(r, identity); err != nil {
+			endpoints.RespondError(w, err)
 			return
 		}
+		r = endpoints.WithIdentity(r, identity)
 
 		next.ServeHTTP(w, r)
 	})
 }
 
+// authorize checks that identity has the role required by the route for the requested app; routes which are in
+// neither routeRoles nor unscopedRoutes are denied
+func authorize(r *http.Request, identity string) error {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return endpoints.ErrorRouteForbidden(r.URL.Path)
+	}
+	pathTemplate, err := route.GetPathTemplate()
+	if err != nil {
+		return endpoints.ErrorRouteForbidden(r.URL.Path)
+	}
+	if unscopedRoutes.Has(pathTemplate) {
+		return nil
+	}
+	role, ok := routeRoles[pathTemplate]
+	if !ok {
+		return endpoints.ErrorRouteForbidden(pathTemplate)
+	}
+
+	appName := r.URL.Query().Get("appName")
+	if appName == "" {
+		return endpoints.ErrorQueryParamRequired("appName")
+	}
+	if err := endpoints.ValidateAppName(appName); err != nil {
+		return err
+	}
+
+	return auth.Authorize(identity, appName, role)
+}
+
 func apiVersionCheckMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientVersion := r.Header.Get("CortexAPIVersion")
@@ -114,45 +233,202 @@ func apiVersionCheckMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func startCron() {
-	cronRunner := cron.New()
-	cronInterval := fmt.Sprintf("@every %ds", cronInterval)
-	cronRunner.AddFunc(cronInterval, runCron)
-	cronRunner.Start()
+// startInformers watches the namespace's workloads, so that statuses are updated as soon as they change
+func startInformers() {
+	k8s.OnPodChange(requestStatusUpdate)
+	k8s.OnDeploymentChange(requestStatusUpdate)
+	k8s.OnJobChange(requestStatusUpdate)
+	argo.OnWorkflowChange(requestStatusUpdate)
+	spark.OnSparkApplicationChange(requestStatusUpdate)
+
+	stopCh := make(chan struct{})
+	for _, start := range []func(<-chan struct{}) error{k8s.StartInformers, argo.StartInformers, spark.StartInformers} {
+		if err := start(stopCh); err != nil {
+			telemetry.ReportErrorBlocking(err)
+			errors.Exit(err)
+		}
+	}
 }
 
-func runCron() {
-	defer reportAndRecover("cron failed")
-	apiPods, err := k8s.ListPodsByLabels(map[string]string{
-		"workloadType": workloads.WorkloadTypeAPI,
-		"userFacing":   "true",
-	})
-	if err != nil {
+// runLeaderElection runs status updates on one operator replica at a time
+func runLeaderElection() {
+	for {
+		err := k8s.RunLeaderElection(leaderElectionLockName, workloads.OperatorID(), startLeading, stopLeading)
+		if err != nil {
+			telemetry.ReportErrorBlocking(err)
+			errors.Exit(err)
+		}
+	}
+}
+
+// startLeading runs status updates (and native workflows) until ctx is cancelled (when the lease is lost)
+func startLeading(ctx context.Context) {
+	leaderMutex.Lock()
+	log.Print("Started leading as " + workloads.OperatorID())
+	isLeader = true
+	metrics.Leader.Set(1)
+	leaderMutex.Unlock()
+
+	if argo.IsNative() {
+		go dag.Execute(ctx)
+	}
+
+	ticker := time.NewTicker(statusUpdateInterval)
+	defer ticker.Stop()
+
+	requestStatusUpdate()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			requestStatusUpdate()
+		case <-statusUpdateRequests:
+			updateStatuses()
+			time.Sleep(statusUpdateMinInterval)
+		}
+	}
+}
+
+func stopLeading() {
+	leaderMutex.Lock()
+	defer leaderMutex.Unlock()
+
+	if !isLeader {
+		return
+	}
+
+	log.Print("Stopped leading as " + workloads.OperatorID())
+	isLeader = false
+	metrics.Leader.Set(0)
+	metrics.ResetAppResources()
+}
+
+func requestStatusUpdate() {
+	select {
+	case statusUpdateRequests <- struct{}{}:
+	default:
+	}
+}
+
+// startSyncCron keeps every replica up to date with deployments made by the others
+func startSyncCron() {
+	syncRunner := cron.New()
+	syncRunner.AddFunc(fmt.Sprintf("@every %ds", syncInterval), runSync)
+	syncRunner.Start()
+}
+
+func runSync() {
+	defer reportAndRecover("sync failed")
+
+	leaderMutex.Lock()
+	leader := isLeader
+	leaderMutex.Unlock()
+
+	if err := workloads.SyncState(leader); err != nil {
 		telemetry.ReportError(err)
 		errors.PrintError(err)
 	}
+}
 
-	if err := workloads.UpdateAPISavedStatuses(apiPods); err != nil {
+// startGCCron deletes unreachable objects from storage on the configured schedule (on the leader only)
+func startGCCron() {
+	if cc.GCSchedule == "" {
+		return
+	}
+	gcRunner := cron.New()
+	if _, err := gcRunner.AddFunc(cc.GCSchedule, runGC); err != nil {
+		errors.Exit(err, "CORTEX_GC_SCHEDULE")
+	}
+	gcRunner.Start()
+}
+
+func runGC() {
+	defer reportAndRecover("gc failed")
+
+	leaderMutex.Lock()
+	leader := isLeader
+	leaderMutex.Unlock()
+	if !leader {
+		return
+	}
+
+	reclaimed, err := workloads.GC()
+	if err != nil {
 		telemetry.ReportError(err)
 		errors.PrintError(err)
 	}
 
-	if err := workloads.UploadLogPrefixesFromAPIPods(apiPods); err != nil {
+	objects, bytes := workloads.GCTotal(reclaimed)
+	log.Printf("Garbage collection reclaimed %s (%d objects)", s.ByteSize(bytes), objects)
+}
+
+// updateStatuses saves the statuses of the pods in the informer's cache
+func updateStatuses() {
+	defer reportAndRecover("status update failed")
+
+	start := time.Now()
+	defer func() {
+		metrics.StatusUpdateDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	succeeded := true
+
+	reportStatusUpdateError := func(step string, err error) {
+		succeeded = false
+		metrics.StatusUpdateErrors.Inc(step)
 		telemetry.ReportError(err)
 		errors.PrintError(err)
 	}
 
-	failedPods, err := k8s.ListPods(&metav1.ListOptions{
-		FieldSelector: "status.phase=Failed",
+	apiPods, err := k8s.ListCachedPodsByLabels(map[string]string{
+		"workloadType": workloads.WorkloadTypeAPI,
+		"userFacing":   "true",
 	})
 	if err != nil {
-		telemetry.ReportError(err)
-		errors.PrintError(err)
+		reportStatusUpdateError("list_api_pods", err)
+	}
+
+	if err := workloads.UpdateAPISavedStatuses(apiPods); err != nil {
+		reportStatusUpdateError("update_api_statuses", err)
+	}
+
+	if err := workloads.UploadLogPrefixesFromAPIPods(apiPods); err != nil {
+		reportStatusUpdateError("upload_log_prefixes", err)
+	}
+
+	if err := workloads.EnforceWorkloadTimeouts(); err != nil {
+		reportStatusUpdateError("enforce_timeouts", err)
+	}
+
+	failedPods, err := k8s.ListCachedPodsByPhase(corev1.PodFailed)
+	if err != nil {
+		reportStatusUpdateError("list_failed_pods", err)
 	}
 
 	if err := workloads.UpdateDataWorkflowErrors(failedPods); err != nil {
-		telemetry.ReportError(err)
-		errors.PrintError(err)
+		reportStatusUpdateError("update_data_errors", err)
+	}
+
+	if argo.IsNative() {
+		wfs, err := argo.ListCached()
+		if err != nil {
+			reportStatusUpdateError("list_workflows", err)
+		}
+
+		if err := workloads.UpdateDataWorkflowErrorsFromWorkflows(wfs); err != nil {
+			reportStatusUpdateError("update_data_errors", err)
+		}
+	}
+
+	if err := workloads.RetryFailedWorkloads(); err != nil {
+		reportStatusUpdateError("retry_workloads", err)
+	}
+
+	metrics.UpdateAppResources()
+
+	if succeeded {
+		metrics.StatusUpdateLastSuccess.Set(float64(time.Now().Unix()))
 	}
 }
 
@@ -175,6 +451,7 @@ func deleteMarkerDelayed(markerMap strset.Set, key string) {
 func reportAndRecover(strs ...string) error {
 	if errInterface := recover(); errInterface != nil {
 		err := errors.CastRecoverError(errInterface, strs...)
+		metrics.StatusUpdateErrors.Inc("panic")
 		telemetry.ReportError(err)
 		errors.PrintError(err)
 		return err
@@ -22,8 +22,10 @@ import (
 	sparkop "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/apis/sparkoperator.k8s.io/v1alpha1"
 	clientset "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/client/clientset/versioned"
 	clientsettyped "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/client/clientset/versioned/typed/sparkoperator.k8s.io/v1alpha1"
+	sparkinformers "github.com/GoogleCloudPlatform/spark-on-k8s-operator/pkg/client/informers/externalversions"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	s "github.com/cortexlabs/cortex/pkg/api/strings"
@@ -32,14 +34,16 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 )
 
 var sparkClientset clientset.Interface
 var sparkClient clientsettyped.SparkApplicationInterface
+var informerFactory sparkinformers.SharedInformerFactory
+var sparkInformer cache.SharedIndexInformer
 
 var doneStates = []string{
 	string(sparkop.CompletedState),
@@ -77,6 +81,19 @@ func init() {
 	}
 
 	sparkClient = sparkClientset.SparkoperatorV1alpha1().SparkApplications(cc.Namespace)
+
+	informerFactory = sparkinformers.NewFilteredSharedInformerFactory(sparkClientset, k8s.InformerResyncPeriod, cc.Namespace, nil)
+	sparkInformer = informerFactory.Sparkoperator().V1alpha1().SparkApplications().Informer()
+}
+
+// StartInformers starts watching Spark applications, and waits until the cache is populated
+func StartInformers(stopCh <-chan struct{}) error {
+	informerFactory.Start(stopCh)
+	return k8s.WaitForInformers("spark", stopCh, sparkInformer.HasSynced)
+}
+
+func OnSparkApplicationChange(onChange func()) {
+	sparkInformer.AddEventHandler(k8s.ChangeHandler(onChange))
 }
 
 func Spec(workloadID string, ctx *context.Context, workloadType string, sparkCompute *userconfig.SparkCompute, args ...string) *sparkop.SparkApplication {
@@ -116,10 +133,11 @@ func Spec(workloadID string, ctx *context.Context, workloadType string, sparkCom
 			MainApplicationFile:  pointer.String("local:///src/spark_job/spark_job.py"),
 			RestartPolicy:        sparkop.RestartPolicy{Type: sparkop.Never},
 			MemoryOverheadFactor: memOverheadFactor,
+			HadoopConf:           hadoopConf(),
 			Arguments: []string{
 				strings.TrimSpace(
 					" --workload-id=" + workloadID +
-						" --context=" + aws.S3Path(ctx.Key) +
+						" --context=" + storage.Path(ctx.Key) +
 						" --cache-dir=" + consts.ContextCacheDir +
 						" " + strings.Join(args, " ")),
 			},
@@ -147,12 +165,7 @@ func Spec(workloadID string, ctx *context.Context, workloadType string, sparkCom
 							Key:  "AWS_SECRET_ACCESS_KEY",
 						},
 					},
-					EnvVars: map[string]string{
-						"CORTEX_SPARK_VERBOSITY": ctx.Environment.LogLevel.Spark,
-						"CORTEX_CONTEXT_S3_PATH": aws.S3Path(ctx.Key),
-						"CORTEX_WORKLOAD_ID":     workloadID,
-						"CORTEX_CACHE_DIR":       consts.ContextCacheDir,
-					},
+					EnvVars: envVars(ctx, workloadID),
 				},
 				PodName:        &workloadID,
 				ServiceAccount: pointer.String("spark"),
@@ -177,12 +190,7 @@ func Spec(workloadID string, ctx *context.Context, workloadType string, sparkCom
 							Key:  "AWS_SECRET_ACCESS_KEY",
 						},
 					},
-					EnvVars: map[string]string{
-						"CORTEX_SPARK_VERBOSITY": ctx.Environment.LogLevel.Spark,
-						"CORTEX_CONTEXT_S3_PATH": aws.S3Path(ctx.Key),
-						"CORTEX_WORKLOAD_ID":     workloadID,
-						"CORTEX_CACHE_DIR":       consts.ContextCacheDir,
-					},
+					EnvVars: envVars(ctx, workloadID),
 				},
 				Instances: &sparkCompute.Executors,
 			},
@@ -190,6 +198,31 @@ func Spec(workloadID string, ctx *context.Context, workloadType string, sparkCom
 	}
 }
 
+func envVars(ctx *context.Context, workloadID string) map[string]string {
+	envVars := map[string]string{
+		"CORTEX_SPARK_VERBOSITY": ctx.Environment.LogLevel.Spark,
+		"CORTEX_CONTEXT_S3_PATH": storage.Path(ctx.Key),
+		"CORTEX_WORKLOAD_ID":     workloadID,
+		"CORTEX_CACHE_DIR":       consts.ContextCacheDir,
+	}
+	if endpoint := storage.Endpoint(); endpoint != "" {
+		envVars["CORTEX_STORAGE_ENDPOINT"] = endpoint
+	}
+	return envVars
+}
+
+// hadoopConf points Spark's S3 filesystem at the S3-compatible store, if there is one
+func hadoopConf() map[string]string {
+	endpoint := storage.Endpoint()
+	if endpoint == "" {
+		return nil
+	}
+	return map[string]string{
+		"fs.s3a.endpoint":          endpoint,
+		"fs.s3a.path.style.access": "true",
+	}
+}
+
 func List(opts *metav1.ListOptions) ([]sparkop.SparkApplication, error) {
 	if opts == nil {
 		opts = &metav1.ListOptions{}
@@ -0,0 +1,207 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	libs3 "github.com/cortexlabs/cortex/pkg/lib/aws/s3"
+	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/msgpack"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
+	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+var client libstorage.Client
+
+func init() {
+	var err error
+
+	switch cc.StorageType {
+	case libstorage.TypeS3:
+		if _, err := aws.AccountID(); err != nil {
+			errors.Exit(err, aws.ErrorAuth())
+		}
+		client, err = libstorage.NewS3Client(cc.Bucket, cc.Region)
+	case libstorage.TypeS3Compatible:
+		if cc.StorageEndpoint == "" {
+			errors.Exit(cr.ErrorMustBeDefined(), s.EnvVar("CORTEX_STORAGE_ENDPOINT"))
+		}
+		client, err = libstorage.NewS3CompatibleClient(cc.Bucket, cc.Region, cc.StorageEndpoint)
+	case libstorage.TypeLocal:
+		if cr.MustBoolFromEnv("CONST_OPERATOR_IN_CLUSTER", &cr.BoolValidation{Default: true}) {
+			errors.Exit(libstorage.ErrorLocalInCluster(), "storage")
+		}
+		client, err = libstorage.NewLocalClient(cc.StorageLocalDir, cc.Bucket)
+	}
+
+	if err != nil {
+		errors.Exit(err, "storage")
+	}
+}
+
+// Client returns the storage client, for packages which read and write objects through a libstorage.Client
+func Client() libstorage.Client {
+	return client
+}
+
+// Endpoint returns the endpoint which workloads must use to reach storage, or "" if they use AWS S3
+func Endpoint() string {
+	if cc.StorageType == libstorage.TypeS3Compatible {
+		return cc.StorageEndpoint
+	}
+	return ""
+}
+
+// Path returns the location of key as understood by workloads (e.g. s3://bucket/key)
+func Path(key string) string {
+	return client.Path(key)
+}
+
+func IsNotFoundErr(err error) bool {
+	return libstorage.IsNotFoundErr(err)
+}
+
+func IsFile(key string) (bool, error) {
+	return client.IsFile(key)
+}
+
+func IsDir(dirPath string) (bool, error) {
+	prefix := s.EnsureSuffix(dirPath, "/")
+	return IsPrefix(prefix)
+}
+
+func IsPrefix(prefix string) (bool, error) {
+	return client.IsPrefix(prefix)
+}
+
+func IsS3aPrefixExternal(s3aPath string) (bool, error) {
+	bucket, key, err := libs3.SplitS3aPath(s3aPath)
+	if err != nil {
+		return false, err
+	}
+	return client.IsPrefixExternal(key, bucket)
+}
+
+func UploadBytes(data []byte, key string) error {
+	return client.UploadBytes(data, key)
+}
+
+func UploadReader(reader io.Reader, key string) error {
+	return client.UploadReader(reader, key)
+}
+
+func UploadByteses(data []byte, keys ...string) error {
+	fns := make([]func() error, len(keys))
+	for i, key := range keys {
+		key := key
+		fns[i] = func() error {
+			return UploadBytes(data, key)
+		}
+	}
+	return parallel.RunFirstErr(fns...)
+}
+
+func UploadFile(filePath string, key string) error {
+	data, err := files.ReadFileBytes(filePath)
+	if err != nil {
+		return err
+	}
+	return UploadBytes(data, key)
+}
+
+func UploadBuffer(buffer *bytes.Buffer, key string) error {
+	return UploadBytes(buffer.Bytes(), key)
+}
+
+func UploadString(str string, key string) error {
+	str = strings.TrimSpace(str)
+	return UploadBytes([]byte(str), key)
+}
+
+func UploadJSON(obj interface{}, key string) error {
+	jsonBytes, err := libjson.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return UploadBytes(jsonBytes, key)
+}
+
+func ReadJSON(objPtr interface{}, key string) error {
+	jsonBytes, err := ReadBytes(key)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(json.Unmarshal(jsonBytes, objPtr), key)
+}
+
+func UploadMsgpack(obj interface{}, key string) error {
+	msgpackBytes, err := msgpack.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return UploadBytes(msgpackBytes, key)
+}
+
+func ReadMsgpack(objPtr interface{}, key string) error {
+	msgpackBytes, err := ReadBytes(key)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(msgpack.Unmarshal(msgpackBytes, objPtr), key)
+}
+
+func ReadString(key string) (string, error) {
+	data, err := ReadBytes(key)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func ReadBytes(key string) ([]byte, error) {
+	return client.ReadBytes(key)
+}
+
+func ReadToWriter(key string, writer io.Writer) error {
+	return client.ReadToWriter(key, writer)
+}
+
+func ListKeys(prefix string) ([]string, error) {
+	return client.ListKeys(prefix)
+}
+
+func ListObjects(prefix string) ([]*libstorage.Object, error) {
+	return client.ListObjects(prefix)
+}
+
+func DeleteByPrefix(prefix string, continueIfFailure bool) error {
+	return client.DeleteByPrefix(prefix, continueIfFailure)
+}
+
+func DeleteKeys(keys []string) error {
+	return client.DeleteKeys(keys)
+}
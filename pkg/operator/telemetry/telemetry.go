@@ -27,6 +27,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	cr "github.com/cortexlabs/cortex/pkg/lib/configreader"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 )
@@ -124,18 +126,27 @@ func (client *Client) sendErrorEvent(operatorID string, err error) {
 
 func ReportEvent(name string) {
 	if cc.EnableTelemetry {
-		go getDefaultClient().sendUsageEvent(aws.HashedAccountID, name)
+		go getDefaultClient().sendUsageEvent(operatorID(), name)
 	}
 }
 
 func ReportErrorBlocking(err error) {
 	if cc.EnableTelemetry {
-		getDefaultClient().sendErrorEvent(aws.HashedAccountID, err)
+		getDefaultClient().sendErrorEvent(operatorID(), err)
 	}
 }
 
 func ReportError(err error) {
 	if cc.EnableTelemetry {
-		go getDefaultClient().sendErrorEvent(aws.HashedAccountID, err)
+		go getDefaultClient().sendErrorEvent(operatorID(), err)
 	}
 }
+
+// operatorID identifies the operator: the hash of its AWS account ID if it uses S3, or of its bucket otherwise
+// (so that operators which don't use AWS never look up their account)
+func operatorID() string {
+	if cc.StorageType == libstorage.TypeS3 {
+		return aws.HashedAccountID()
+	}
+	return hash.String(cc.Bucket)
+}
@@ -28,9 +28,9 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 const (
@@ -101,12 +101,12 @@ func apiSpec(
 							"--workload-id=" + workloadID,
 							"--port=" + defaultPortStr,
 							"--tf-serve-port=" + tfServingPortStr,
-							"--context=" + aws.S3Path(ctx.Key),
+							"--context=" + storage.Path(ctx.Key),
 							"--api=" + ctx.APIs[apiName].ID,
 							"--model-dir=" + path.Join(consts.EmptyDirMountPath, "model"),
 							"--cache-dir=" + consts.ContextCacheDir,
 						},
-						Env:          k8s.AWSCredentials(),
+						Env:          k8s.StorageEnvVars(storage.Endpoint()),
 						VolumeMounts: k8s.DefaultVolumeMounts(),
 						Resources: corev1.ResourceRequirements{
 							Requests: transformResourceList,
@@ -120,7 +120,7 @@ func apiSpec(
 							"--port=" + tfServingPortStr,
 							"--model_base_path=" + path.Join(consts.EmptyDirMountPath, "model"),
 						},
-						Env:          k8s.AWSCredentials(),
+						Env:          k8s.StorageEnvVars(storage.Endpoint()),
 						VolumeMounts: k8s.DefaultVolumeMounts(),
 						Resources: corev1.ResourceRequirements{
 							Requests: tfServingResourceList,
@@ -171,7 +171,7 @@ func serviceSpec(ctx *context.Context, apiName string) *k8s.ServiceSpec {
 	}
 }
 
-func apiWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
+func apiWorkloadSpecs(ctx *context.Context, opts *createOptions) ([]*WorkloadSpec, error) {
 	var workloadSpecs []*WorkloadSpec
 
 	deployments, err := deploymentMap(ctx.App.Name)
@@ -180,6 +180,10 @@ func apiWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	}
 
 	for apiName, api := range ctx.APIs {
+		if opts != nil && opts.resourceIDs != nil && !opts.resourceIDs.Has(api.ID) {
+			continue
+		}
+
 		workloadID := generateWorkloadID()
 		deployment, deploymentExists := deployments[apiName]
 		if deploymentExists && deployment.Labels["resourceID"] == api.ID && deployment.DeletionTimestamp == nil {
@@ -197,6 +201,7 @@ func apiWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 			K8sAction:        "apply",
 			SuccessCondition: k8s.DeploymentSuccessConditionAll,
 			WorkloadType:     WorkloadTypeAPI,
+			APICompute:       api.Compute,
 		})
 	}
 
@@ -25,9 +25,9 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func uploadAPISavedStatus(savedStatus *resource.APISavedStatus) error {
@@ -36,7 +36,7 @@ func uploadAPISavedStatus(savedStatus *resource.APISavedStatus) error {
 	}
 
 	key := ocontext.StatusKey(savedStatus.ResourceID, savedStatus.WorkloadID, savedStatus.AppName)
-	err := aws.UploadJSONToS3(savedStatus, key)
+	err := storage.UploadJSON(savedStatus, key)
 	if err != nil {
 		return errors.Wrap(err, "upload api saved status", savedStatus.AppName, savedStatus.ResourceID, savedStatus.WorkloadID)
 	}
@@ -65,8 +65,8 @@ func getAPISavedStatus(resourceID string, workloadID string, appName string) (*r
 
 	key := ocontext.StatusKey(resourceID, workloadID, appName)
 	var savedStatus resource.APISavedStatus
-	err := aws.ReadJSONFromS3(&savedStatus, key)
-	if aws.IsNoSuchKeyErr(err) {
+	err := storage.ReadJSON(&savedStatus, key)
+	if storage.IsNotFoundErr(err) {
 		cacheNilAPISavedStatus(resourceID, workloadID, appName)
 		return nil, nil
 	}
@@ -143,3 +143,21 @@ func setAPISavedStatusMap(
 	}
 	savedStatusMap[appName][resourceID][workloadID] = savedStatus
 }
+
+func resetAPIStatusCache() {
+	apiStatusCache.Lock()
+	defer apiStatusCache.Unlock()
+	apiStatusCache.m = make(map[string]map[string]map[string]*resource.APISavedStatus)
+}
+
+func apiStatusCacheSize() int {
+	apiStatusCache.RLock()
+	defer apiStatusCache.RUnlock()
+	size := 0
+	for _, resourceStatuses := range apiStatusCache.m {
+		for _, statuses := range resourceStatuses {
+			size += len(statuses)
+		}
+	}
+	return size
+}
@@ -38,7 +38,7 @@ func GetCurrentAPIStatuses(
 		return nil, err
 	}
 
-	podList, err := k8s.ListPodsByLabels(map[string]string{
+	podList, err := k8s.ListCachedPodsByLabels(map[string]string{
 		"workloadType": WorkloadTypeAPI,
 		"appName":      ctx.App.Name,
 		"userFacing":   "true",
@@ -194,10 +194,10 @@ func updateAPIStatusCodeByParents(apiStatus *resource.APIStatus, dataStatuses ma
 	parentSkipped := false
 	for dependency := range allDependencies {
 		switch dataStatuses[dependency].Code {
-		case resource.StatusDataKilled, resource.StatusDataKilledOOM:
+		case resource.StatusDataKilled, resource.StatusDataKilledOOM, resource.StatusDataTimedOut:
 			apiStatus.Code = resource.StatusParentKilled
 			return
-		case resource.StatusDataFailed:
+		case resource.StatusDataFailed, resource.StatusDataQuotaExceeded:
 			apiStatus.Code = resource.StatusParentFailed
 			return
 		case resource.StatusSkipped:
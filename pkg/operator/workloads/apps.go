@@ -0,0 +1,127 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"sort"
+
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+)
+
+// GetApps summarizes the deployed apps for which include returns true, sorted by name
+func GetApps(include func(appName string) bool) ([]*schema.AppSummary, error) {
+	wfs, err := argo.List(&metav1.ListOptions{LabelSelector: "appName"})
+	if err != nil {
+		return nil, err
+	}
+	appWfs := make(map[string]*awfv1.Workflow)
+	for i, wf := range wfs {
+		appWfs[wf.Labels["appName"]] = &wfs[i]
+	}
+
+	var ctxs []*context.Context
+	for _, ctx := range CurrentContexts() {
+		if include(ctx.App.Name) {
+			ctxs = append(ctxs, ctx)
+		}
+	}
+	sort.Slice(ctxs, func(i, j int) bool {
+		return ctxs[i].App.Name < ctxs[j].App.Name
+	})
+
+	apps := make([]*schema.AppSummary, len(ctxs))
+	fns := make([]func() error, len(ctxs))
+	for i, ctx := range ctxs {
+		i, ctx := i, ctx
+		fns[i] = func() error {
+			app, err := getAppSummary(ctx, appWfs[ctx.App.Name])
+			apps[i] = app
+			return errors.Wrap(err, ctx.App.Name)
+		}
+	}
+
+	if err := parallel.RunFirstErr(fns...); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+func getAppSummary(ctx *context.Context, wf *awfv1.Workflow) (*schema.AppSummary, error) {
+	app := &schema.AppSummary{
+		Name:        ctx.App.Name,
+		Environment: ctx.Environment.Name,
+		ContextID:   ctx.ID,
+	}
+
+	if wf != nil {
+		app.Running = argo.IsRunning(wf)
+		app.DeployedAt = &wf.CreationTimestamp.Time
+	}
+
+	// The history includes deployments which didn't need a new workflow
+	history, err := GetHistory(ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 && history[len(history)-1].ContextID == ctx.ID {
+		app.DeployedAt = &history[len(history)-1].Timestamp
+	}
+
+	resources, err := GetResources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	app.Status = appStatus(resources)
+
+	return app, nil
+}
+
+// appStatus rolls up the statuses of an app's resources: an error if any resource failed,
+// updating if any resource is pending or in progress, and ready otherwise
+func appStatus(resources *schema.GetResourcesResponse) string {
+	var codes []resource.StatusCode
+	for _, dataStatus := range resources.DataStatuses {
+		codes = append(codes, dataStatus.Code)
+	}
+	for _, apiGroupStatus := range resources.APIGroupStatuses {
+		codes = append(codes, apiGroupStatus.Code)
+	}
+
+	status := schema.AppStatusReady
+	for _, code := range codes {
+		switch code {
+		case resource.StatusParentFailed, resource.StatusParentKilled,
+			resource.StatusDataFailed, resource.StatusDataKilled, resource.StatusDataKilledOOM, resource.StatusDataTimedOut, resource.StatusDataQuotaExceeded,
+			resource.StatusAPIError, resource.StatusAPIGroupParentFailed, resource.StatusAPIGroupParentKilled:
+			return schema.AppStatusError
+		case resource.StatusDataSucceeded, resource.StatusSkipped,
+			resource.StatusAPIReady, resource.StatusAPIStopped, resource.StatusAPIGroupUpdateSkipped:
+			continue
+		default:
+			status = schema.AppStatusUpdating
+		}
+	}
+	return status
+}
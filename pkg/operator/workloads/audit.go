@@ -0,0 +1,61 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/parallel"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// RecordAudit stores record as a new object in the app's audit log (existing records are never modified)
+func RecordAudit(record *schema.AuditRecord) error {
+	err := storage.UploadJSON(record, ocontext.AuditKey(record.AppName, record.Timestamp))
+	if err != nil {
+		return errors.Wrap(err, "upload audit record", record.AppName)
+	}
+	return nil
+}
+
+// GetAudit returns the app's audit records, oldest first. If limit is positive, only the latest limit records are returned.
+func GetAudit(appName string, limit int) ([]*schema.AuditRecord, error) {
+	keys, err := storage.ListKeys(ocontext.AuditPrefix(appName))
+	if err != nil {
+		return nil, errors.Wrap(err, "list audit records", appName)
+	}
+
+	if limit > 0 && len(keys) > limit {
+		keys = keys[len(keys)-limit:]
+	}
+
+	records := make([]*schema.AuditRecord, len(keys))
+	fns := make([]func() error, len(keys))
+	for i, key := range keys {
+		i, key := i, key
+		fns[i] = func() error {
+			records[i] = &schema.AuditRecord{}
+			return storage.ReadJSON(records[i], key)
+		}
+	}
+
+	if err := parallel.RunFirstErr(fns...); err != nil {
+		return nil, errors.Wrap(err, "download audit records", appName)
+	}
+	return records, nil
+}
@@ -57,3 +57,19 @@ func deleteCurrentContext(appName string) {
 	defer currentCtxs.Unlock()
 	delete(currentCtxs.m, appName)
 }
+
+// replaceCurrentContext sets the app's current context to ctx (or deletes it if ctx is nil),
+// unless it has changed from prevCtx
+func replaceCurrentContext(appName string, prevCtx *context.Context, ctx *context.Context) bool {
+	currentCtxs.Lock()
+	defer currentCtxs.Unlock()
+	if currentCtxs.m[appName] != prevCtx {
+		return false
+	}
+	if ctx == nil {
+		delete(currentCtxs.m, appName)
+	} else {
+		currentCtxs.m[appName] = ctx
+	}
+	return true
+}
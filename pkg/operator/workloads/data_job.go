@@ -27,8 +27,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	"github.com/cortexlabs/cortex/pkg/operator/spark"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func dataJobSpec(
@@ -56,10 +56,10 @@ func dataJobSpec(
 	return spec
 }
 
-func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
+func dataWorkloadSpecs(ctx *context.Context, opts *createOptions) ([]*WorkloadSpec, error) {
 	workloadID := generateWorkloadID()
 
-	rawFileExists, err := aws.IsS3File(filepath.Join(ctx.RawDataset.Key, "_SUCCESS"))
+	rawFileExists, err := storage.IsFile(filepath.Join(ctx.RawDataset.Key, "_SUCCESS"))
 	if err != nil {
 		return nil, errors.Wrap(err, ctx.App.Name, "raw dataset")
 	}
@@ -69,7 +69,7 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	shouldIngest := !rawFileExists
 	if shouldIngest {
 		externalDataPath := ctx.Environment.Data.GetExternalPath()
-		externalDataExists, err := aws.IsS3aPrefixExternal(externalDataPath)
+		externalDataExists, err := storage.IsS3aPrefixExternal(externalDataPath)
 		if err != nil || !externalDataExists {
 			return nil, errors.Wrap(ErrorUserDataUnavailable(externalDataPath), ctx.App.Name, userconfig.Identify(ctx.Environment), userconfig.DataKey, userconfig.PathKey)
 		}
@@ -81,7 +81,7 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	rawColumnIDs := strset.New()
 	var rawColumns []string
 	for rawColumnName, rawColumn := range ctx.RawColumns {
-		isCached, err := checkResourceCached(rawColumn, ctx)
+		isCached, err := checkResourceSkipped(rawColumn, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -96,7 +96,7 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	aggregateIDs := strset.New()
 	var aggregates []string
 	for aggregateName, aggregate := range ctx.Aggregates {
-		isCached, err := checkResourceCached(aggregate, ctx)
+		isCached, err := checkResourceSkipped(aggregate, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -111,7 +111,7 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	transformedColumnIDs := strset.New()
 	var transformedColumns []string
 	for transformedColumnName, transformedColumn := range ctx.TransformedColumns {
-		isCached, err := checkResourceCached(transformedColumn, ctx)
+		isCached, err := checkResourceSkipped(transformedColumn, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -127,7 +127,7 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	var trainingDatasets []string
 	for modelName, model := range ctx.Models {
 		dataset := model.Dataset
-		isCached, err := checkResourceCached(dataset, ctx)
+		isCached, err := checkResourceSkipped(dataset, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -150,7 +150,8 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 		return nil, nil
 	}
 
-	sparkCompute := userconfig.MaxSparkCompute(allComputes...)
+	attempt := opts.attempt(resourceIDSet)
+	sparkCompute := userconfig.MaxSparkCompute(allComputes...).ScaleMem(attempt.MemFactor)
 	spec := dataJobSpec(ctx, shouldIngest, rawColumnIDs, aggregateIDs, transformedColumnIDs, trainingDatasetIDs, workloadID, sparkCompute)
 
 	workloadSpec := &WorkloadSpec{
@@ -161,6 +162,11 @@ func dataWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 		SuccessCondition: spark.SuccessCondition,
 		FailureCondition: spark.FailureCondition,
 		WorkloadType:     workloadTypeData,
+		SparkCompute:     sparkCompute,
+		Timeout:          sparkCompute.Timeout,
+		Retry:            sparkCompute.Retry,
+		Attempt:          attempt.Attempt,
+		MemFactor:        attempt.MemFactor,
 	}
 	return []*WorkloadSpec{workloadSpec}, nil
 }
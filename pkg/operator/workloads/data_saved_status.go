@@ -24,8 +24,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func uploadDataSavedStatus(savedStatus *resource.DataSavedStatus) error {
@@ -34,7 +34,7 @@ func uploadDataSavedStatus(savedStatus *resource.DataSavedStatus) error {
 	}
 
 	key := ocontext.StatusKey(savedStatus.ResourceID, savedStatus.WorkloadID, savedStatus.AppName)
-	err := aws.UploadJSONToS3(savedStatus, key)
+	err := storage.UploadJSON(savedStatus, key)
 	if err != nil {
 		return errors.Wrap(err, "upload data saved status", savedStatus.AppName, savedStatus.ResourceID, savedStatus.WorkloadID)
 	}
@@ -63,8 +63,8 @@ func getDataSavedStatus(resourceID string, workloadID string, appName string) (*
 
 	key := ocontext.StatusKey(resourceID, workloadID, appName)
 	var savedStatus resource.DataSavedStatus
-	err := aws.ReadJSONFromS3(&savedStatus, key)
-	if aws.IsNoSuchKeyErr(err) {
+	err := storage.ReadJSON(&savedStatus, key)
+	if storage.IsNotFoundErr(err) {
 		return nil, nil
 	}
 	if err != nil {
@@ -79,3 +79,19 @@ func uncacheDataSavedStatuses(currentResourceWorkloadIDs map[string]string, appN
 		}
 	}
 }
+
+func resetDataStatusCache() {
+	dataStatusCache.Lock()
+	defer dataStatusCache.Unlock()
+	dataStatusCache.m = make(map[string]map[string]*resource.DataSavedStatus)
+}
+
+func dataStatusCacheSize() int {
+	dataStatusCache.RLock()
+	defer dataStatusCache.RUnlock()
+	size := 0
+	for _, statuses := range dataStatusCache.m {
+		size += len(statuses)
+	}
+	return size
+}
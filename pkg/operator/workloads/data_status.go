@@ -52,6 +52,11 @@ func GetCurrentDataStatuses(ctx *context.Context) (map[string]*resource.DataStat
 		}
 	}
 
+	err = setDataStatusAttempts(dataStatuses, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, dataStatus := range dataStatuses {
 		updateDataStatusCodeByParents(dataStatus, dataStatuses, ctx)
 	}
@@ -79,6 +84,10 @@ func dataStatusCode(dataSavedStatus *resource.DataSavedStatus) resource.StatusCo
 		return resource.StatusDataKilled
 	case resource.ExitCodeDataOOM:
 		return resource.StatusDataKilledOOM
+	case resource.ExitCodeDataTimedOut:
+		return resource.StatusDataTimedOut
+	case resource.ExitCodeDataQuotaExceeded:
+		return resource.StatusDataQuotaExceeded
 	}
 
 	return resource.StatusUnknown
@@ -93,10 +102,10 @@ func updateDataStatusCodeByParents(dataStatus *resource.DataStatus, dataStatuses
 	parentSkipped := false
 	for dependency := range allDependencies {
 		switch dataStatuses[dependency].Code {
-		case resource.StatusDataKilled, resource.StatusDataKilledOOM:
+		case resource.StatusDataKilled, resource.StatusDataKilledOOM, resource.StatusDataTimedOut:
 			dataStatus.Code = resource.StatusParentKilled
 			return
-		case resource.StatusDataFailed:
+		case resource.StatusDataFailed, resource.StatusDataQuotaExceeded:
 			dataStatus.Code = resource.StatusParentFailed
 			return
 		case resource.StatusSkipped:
@@ -150,7 +159,7 @@ func didSparkShortCircuit(dataStatuses map[string]*resource.DataStatus, ctx *con
 		if _, ok := sparkResources[dataStatus.ResourceID]; !ok {
 			continue
 		}
-		if dataStatus.Code == resource.StatusDataFailed || dataStatus.Code == resource.StatusDataKilled {
+		if dataStatus.Code == resource.StatusDataFailed || dataStatus.Code == resource.StatusDataKilled || dataStatus.Code == resource.StatusDataTimedOut {
 			return true
 		}
 	}
@@ -177,3 +186,24 @@ func setInsufficientComputeDataStatusCodes(dataStatuses map[string]*resource.Dat
 
 	return nil
 }
+
+// setDataStatusAttempts sets the attempt of each resource's workload, and how many attempts its retry policy allows
+func setDataStatusAttempts(dataStatuses map[string]*resource.DataStatus, appName string) error {
+	for _, dataStatus := range dataStatuses {
+		if dataStatus.WorkloadID == "" {
+			continue
+		}
+		savedWorkloadSpec, err := getSavedWorkloadSpec(dataStatus.WorkloadID, appName)
+		if err != nil {
+			return err
+		}
+		if savedWorkloadSpec == nil {
+			continue
+		}
+		dataStatus.Attempt = savedWorkloadSpec.Attempt
+		if savedWorkloadSpec.Retry != nil {
+			dataStatus.MaxAttempts = savedWorkloadSpec.Retry.MaxAttempts
+		}
+	}
+	return nil
+}
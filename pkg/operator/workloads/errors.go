@@ -18,6 +18,9 @@ package workloads
 
 import (
 	"fmt"
+
+	s "github.com/cortexlabs/cortex/pkg/api/strings"
+	libtime "github.com/cortexlabs/cortex/pkg/lib/time"
 )
 
 type ErrorKind int
@@ -31,6 +34,10 @@ const (
 	ErrCortexInstallationBroken
 	ErrLoadBalancerInitializing
 	ErrNotFound
+	ErrDeploymentInProgress
+	ErrResourceNotFailed
+	ErrWorkflowRunning
+	ErrResourceNotCached
 )
 
 var errorKinds = []string{
@@ -42,9 +49,13 @@ var errorKinds = []string{
 	"err_cortex_installation_broken",
 	"err_load_balancer_initializing",
 	"err_not_found",
+	"err_deployment_in_progress",
+	"err_resource_not_failed",
+	"err_workflow_running",
+	"err_resource_not_cached",
 }
 
-var _ = [1]int{}[int(ErrNotFound)-(len(errorKinds)-1)] // Ensure list length matches
+var _ = [1]int{}[int(ErrResourceNotCached)-(len(errorKinds)-1)] // Ensure list length matches
 
 func (t ErrorKind) String() string {
 	return errorKinds[t]
@@ -137,3 +148,35 @@ func ErrorNotFound() error {
 		message: "not found",
 	}
 }
+
+func ErrorDeploymentInProgress(lock *AppLock) error {
+	identity := lock.Identity
+	if identity == "" {
+		identity = "another user"
+	}
+	return Error{
+		Kind:    ErrDeploymentInProgress,
+		message: fmt.Sprintf("%s: deployment in progress by %s (%s started %s ago); try again once it completes", lock.AppName, identity, lock.Action, libtime.Since(&lock.AcquiredAt)),
+	}
+}
+
+func ErrorResourceNotFailed(resourceName string, status string) error {
+	return Error{
+		Kind:    ErrResourceNotFailed,
+		message: fmt.Sprintf("%s can't be retried because it hasn't failed (its status is \"%s\")", s.UserStr(resourceName), status),
+	}
+}
+
+func ErrorWorkflowRunning(appName string) error {
+	return Error{
+		Kind:    ErrWorkflowRunning,
+		message: fmt.Sprintf("%s is still running; try again once it completes, or redeploy with --force", s.UserStr(appName)),
+	}
+}
+
+func ErrorResourceNotCached(resourceName string) error {
+	return Error{
+		Kind:    ErrResourceNotCached,
+		message: fmt.Sprintf("%s isn't cached, so it can't be invalidated (apis are updated on each deployment)", s.UserStr(resourceName)),
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"archive/zip"
+	"sort"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/archive"
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// ExportApp determines the contents of an archive of ctx, the files it references (implementation files, constants, and
+// python package sources), and the outputs of its cached python packages, aggregates, and models. The statuses of the
+// cached resources are included so that their outputs are reused when the archive is imported.
+func ExportApp(ctx *context.Context) (*archive.Export, error) {
+	resourceIDs := strset.New()
+	for _, res := range ctx.DataComputedResources() {
+		resourceIDs.Add(res.GetID())
+	}
+	resourceWorkloadIDs, err := getCurrentLatestWorkloadIDs(resourceIDs, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := ctx.ExportedFileKeys().Slice()
+	var statusKeys []string
+	var resourceNames []string
+	for _, res := range ctx.DataComputedResources() {
+		outputKey := context.ExportedOutputKey(res)
+		if outputKey == "" {
+			continue
+		}
+
+		workloadID := resourceWorkloadIDs[res.GetID()]
+		if workloadID == "" {
+			continue
+		}
+		savedStatus, err := getDataSavedStatus(res.GetID(), workloadID, ctx.App.Name)
+		if err != nil {
+			return nil, err
+		}
+		if savedStatus == nil || savedStatus.ExitCode != resource.ExitCodeDataSucceeded {
+			continue
+		}
+
+		keys = append(keys, outputKey)
+		statusKeys = append(statusKeys, ocontext.StatusKey(res.GetID(), workloadID, ctx.App.Name), ocontext.LatestWorkloadIDKey(res.GetID(), ctx.App.Name))
+		resourceNames = append(resourceNames, res.GetName())
+	}
+	sort.Strings(resourceNames)
+
+	manifest := schema.ExportManifest{
+		AppName:       ctx.App.Name,
+		ContextID:     ctx.ID,
+		Environment:   ctx.Environment.Name,
+		CortexVersion: consts.CortexVersion,
+		ExportedAt:    time.Now(),
+		Resources:     resourceNames,
+	}
+	return archive.NewExport(&manifest, ctx, keys, statusKeys)
+}
+
+// ImportApp uploads the files in an archive written by ExportApp, and returns the archived context (rebound to this
+// cluster), the archive's manifest, and the number of cached resources which were restored. The app's dataset version
+// is set to the archived context's, so that its subsequent deployments reuse the imported outputs. The caller must
+// hold the app's lock.
+func ImportApp(zipReader *zip.Reader, appName string) (*context.Context, *schema.ExportManifest, int, error) {
+	// All keys are checked before anything is uploaded
+	imp, err := archive.Read(zipReader, appName, ocontext.StatusPrefix(appName))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	numRestored, err := imp.Upload(storage.Client())
+	if err != nil {
+		return nil, nil, 0, errors.Wrap(err, appName, "import")
+	}
+
+	if err := ocontext.SetDatasetVersion(appName, imp.Context.DatasetVersion); err != nil {
+		return nil, nil, 0, err
+	}
+
+	ocontext.ImportContext(imp.Context)
+	return imp.Context, imp.Manifest, numRestored, nil
+}
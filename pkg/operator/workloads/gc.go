@@ -0,0 +1,264 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/consts"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/gc"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// gcDirs are the directories within each app's directory which are garbage collected; everything else is kept
+var gcDirs = []string{
+	consts.DataDir,
+	consts.ContextsDir,
+	consts.ResourceStatusesDir,
+	consts.WorkloadSpecsDir,
+	consts.LogPrefixesDir,
+}
+
+// GC deletes the objects of every app which are not reachable from the app's current context or its last
+// cc.GCKeepContexts deployments, as well as the python packages which none of those contexts use
+func GC() ([]*schema.GCReclaimed, error) {
+	appObjects, err := listAppObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	var allReclaimed []*schema.GCReclaimed
+	pythonPackagesReachable := gc.NewReachable()
+	skippedApps := false
+	for _, appName := range sortedAppNames(appObjects) {
+		unlock, err := LockApp(appName, schema.AuditActionGC, "operator")
+		if err != nil {
+			// The app is being deployed or deleted; it will be collected next time
+			if cause, ok := errors.Cause(err).(Error); ok && cause.Kind == ErrDeploymentInProgress {
+				skippedApps = true
+				continue
+			}
+			return allReclaimed, err
+		}
+		reclaimed, collected, err := gcApp(appName, appObjects[appName], pythonPackagesReachable, false)
+		unlock()
+		if err != nil {
+			return allReclaimed, err
+		}
+		if !collected {
+			skippedApps = true
+		}
+		allReclaimed = append(allReclaimed, reclaimed...)
+	}
+
+	// The python packages used by skipped apps are unknown
+	if skippedApps {
+		return allReclaimed, nil
+	}
+
+	pythonPackageObjects, err := storage.ListObjects(consts.PythonPackagesDir + "/")
+	if err != nil {
+		return allReclaimed, err
+	}
+	reclaimed, err := gcObjects(gc.Unreachable(pythonPackageObjects, pythonPackagesReachable, time.Now()), false)
+	if err != nil {
+		return allReclaimed, err
+	}
+	return append(allReclaimed, reclaimed...), nil
+}
+
+// GCApp deletes the app's objects which are not reachable from its current context or its last cc.GCKeepContexts deployments.
+// Python packages are shared between apps, so they are only collected by GC(). The caller must hold the app's lock.
+func GCApp(appName string, dryRun bool) ([]*schema.GCReclaimed, error) {
+	objects, err := storage.ListObjects(filepath.Join(consts.AppsDir, appName) + "/")
+	if err != nil {
+		return nil, err
+	}
+	reclaimed, _, err := gcApp(appName, objects, gc.NewReachable(), dryRun)
+	return reclaimed, err
+}
+
+// gcApp collects the app's objects, and adds the python packages used by its kept contexts to pythonPackagesReachable.
+// Apps without any kept contexts are not collected (see gc.App.Unreachable), in which case collected is false.
+func gcApp(appName string, objects []*libstorage.Object, pythonPackagesReachable *gc.Reachable, dryRun bool) ([]*schema.GCReclaimed, bool, error) {
+	ctxs, err := gcKeptContexts(appName)
+	if err != nil {
+		return nil, false, errors.Wrap(err, appName, "gc")
+	}
+
+	// The current dataset version may not have been deployed yet
+	datasetVersion, err := storage.ReadString(filepath.Join(consts.AppsDir, appName, "dataset_version"))
+	if err != nil && !storage.IsNotFoundErr(err) {
+		return nil, false, errors.Wrap(err, appName, "gc")
+	}
+	var datasetPrefix string
+	if datasetVersion != "" {
+		datasetPrefix = filepath.Join(consts.AppsDir, appName, consts.DataDir, datasetVersion) + "/"
+	}
+
+	var collectable []*libstorage.Object
+	for _, object := range objects {
+		for _, dir := range gcDirs {
+			if strings.HasPrefix(object.Key, filepath.Join(consts.AppsDir, appName, dir)+"/") {
+				collectable = append(collectable, object)
+				break
+			}
+		}
+	}
+
+	app := &gc.App{
+		Objects:      collectable,
+		Contexts:     ctxs,
+		StatusPrefix: ocontext.StatusPrefix(appName),
+		WorkloadKeys: func(workloadID string) []string {
+			return []string{ocontext.WorkloadSpecKey(workloadID, appName), logPreifixKey(workloadID, appName)}
+		},
+		DatasetPrefix: datasetPrefix,
+	}
+	unreachable, collected := app.Unreachable(pythonPackagesReachable, time.Now())
+	if !collected {
+		return nil, false, nil
+	}
+
+	reclaimed, err := gcObjects(unreachable, dryRun)
+	return reclaimed, true, err
+}
+
+// gcKeptContexts returns the app's current context and the contexts of its last cc.GCKeepContexts deployments
+func gcKeptContexts(appName string) ([]*context.Context, error) {
+	var ctxs []*context.Context
+	ctxIDs := strset.New()
+
+	if ctx := CurrentContext(appName); ctx != nil {
+		ctxs = append(ctxs, ctx)
+		ctxIDs.Add(ctx.ID)
+	}
+
+	history, err := GetHistory(appName)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > cc.GCKeepContexts {
+		history = history[len(history)-cc.GCKeepContexts:]
+	}
+
+	for _, deployment := range history {
+		if ctxIDs.Has(deployment.ContextID) {
+			continue
+		}
+		ctxIDs.Add(deployment.ContextID)
+
+		ctx, err := ocontext.DownloadContext(deployment.ContextID, appName)
+		if err != nil {
+			if cause, ok := errors.Cause(err).(ocontext.Error); ok && cause.Kind == ocontext.ErrContextNotFound {
+				continue
+			}
+			return nil, err
+		}
+		ctxs = append(ctxs, ctx)
+	}
+
+	return ctxs, nil
+}
+
+// gcObjects deletes the objects (unless dryRun), and summarizes them by directory
+func gcObjects(objects []*libstorage.Object, dryRun bool) ([]*schema.GCReclaimed, error) {
+	var keys []string
+	reclaimedByPrefix := make(map[string]*schema.GCReclaimed)
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+
+		prefix := gcReportPrefix(object.Key)
+		if _, ok := reclaimedByPrefix[prefix]; !ok {
+			reclaimedByPrefix[prefix] = &schema.GCReclaimed{Prefix: prefix}
+		}
+		reclaimedByPrefix[prefix].Objects++
+		reclaimedByPrefix[prefix].Bytes += object.Size
+	}
+
+	if !dryRun && len(keys) > 0 {
+		if err := storage.DeleteKeys(keys); err != nil {
+			return nil, errors.Wrap(err, "gc")
+		}
+	}
+
+	var reclaimed []*schema.GCReclaimed
+	for _, prefixReclaimed := range reclaimedByPrefix {
+		reclaimed = append(reclaimed, prefixReclaimed)
+	}
+	sort.Slice(reclaimed, func(i, j int) bool {
+		return reclaimed[i].Prefix < reclaimed[j].Prefix
+	})
+	return reclaimed, nil
+}
+
+// GCTotal returns the total number of objects and bytes reclaimed
+func GCTotal(reclaimed []*schema.GCReclaimed) (int, int64) {
+	var objects int
+	var bytes int64
+	for _, prefixReclaimed := range reclaimed {
+		objects += prefixReclaimed.Objects
+		bytes += prefixReclaimed.Bytes
+	}
+	return objects, bytes
+}
+
+// gcReportPrefix groups keys by app directory (e.g. "apps/iris/contexts") or top-level directory (e.g. "python_packages")
+func gcReportPrefix(key string) string {
+	parts := strings.Split(key, "/")
+	if parts[0] == consts.AppsDir && len(parts) > 3 {
+		return strings.Join(parts[:3], "/")
+	}
+	return parts[0]
+}
+
+// listAppObjects returns the objects in each app's directory, by app name
+func listAppObjects() (map[string][]*libstorage.Object, error) {
+	objects, err := storage.ListObjects(consts.AppsDir + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	appObjects := make(map[string][]*libstorage.Object)
+	for _, object := range objects {
+		parts := strings.SplitN(object.Key, "/", 3)
+		if len(parts) < 3 {
+			continue
+		}
+		appObjects[parts[1]] = append(appObjects[parts[1]], object)
+	}
+	return appObjects, nil
+}
+
+func sortedAppNames(appObjects map[string][]*libstorage.Object) []string {
+	appNames := make([]string, 0, len(appObjects))
+	for appName := range appObjects {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	return appNames
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// Serializes read-modify-write of history files
+var historyMutex sync.Mutex
+
+// RecordDeployment appends ctx to the app's deployment history, unless it is already the latest entry
+func RecordDeployment(ctx *context.Context, identity string, message string) error {
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+
+	history, err := GetHistory(ctx.App.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(history) > 0 && history[len(history)-1].ContextID == ctx.ID {
+		return nil
+	}
+
+	history = append(history, &schema.Deployment{
+		ContextID:   ctx.ID,
+		Timestamp:   time.Now(),
+		Environment: ctx.Environment.Name,
+		Identity:    identity,
+		Message:     message,
+	})
+
+	err = storage.UploadJSON(history, ocontext.HistoryKey(ctx.App.Name))
+	if err != nil {
+		return errors.Wrap(err, "upload deployment history", ctx.App.Name)
+	}
+	return nil
+}
+
+// GetHistory returns the app's deployments, oldest first
+func GetHistory(appName string) ([]*schema.Deployment, error) {
+	var history []*schema.Deployment
+	err := storage.ReadJSON(&history, ocontext.HistoryKey(appName))
+	if storage.IsNotFoundErr(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "download deployment history", appName)
+	}
+	return history, nil
+}
@@ -20,8 +20,8 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func uploadLatestWorkloadID(resourceID string, workloadID string, appName string) error {
@@ -30,7 +30,7 @@ func uploadLatestWorkloadID(resourceID string, workloadID string, appName string
 	}
 
 	key := ocontext.LatestWorkloadIDKey(resourceID, appName)
-	err := aws.UploadStringToS3(workloadID, key)
+	err := storage.UploadString(workloadID, key)
 	if err != nil {
 		return errors.Wrap(err, "upload latest workload ID", appName, resourceID, workloadID)
 	}
@@ -64,8 +64,8 @@ func getSavedLatestWorkloadID(resourceID string, appName string) (string, error)
 	}
 
 	key := ocontext.LatestWorkloadIDKey(resourceID, appName)
-	workloadID, err := aws.ReadStringFromS3(key)
-	if aws.IsNoSuchKeyErr(err) {
+	workloadID, err := storage.ReadString(key)
+	if storage.IsNotFoundErr(err) {
 		cacheEmptyLatestWorkloadID(resourceID, appName)
 		return "", nil
 	}
@@ -76,6 +76,13 @@ func getSavedLatestWorkloadID(resourceID string, appName string) (string, error)
 	return workloadID, nil
 }
 
+// getCurrentLatestWorkloadIDs reads the latest workload IDs of the resources from storage rather than the cache, since another
+// operator replica may have invalidated them (e.g. via cortex cache rm); the app's cache is repopulated with the stored IDs
+func getCurrentLatestWorkloadIDs(resourceIDs strset.Set, appName string) (map[string]string, error) {
+	uncacheLatestWorkloadIDs(nil, appName)
+	return getSavedLatestWorkloadIDs(resourceIDs, appName)
+}
+
 func getSavedLatestWorkloadIDs(resourceIDs strset.Set, appName string) (map[string]string, error) {
 	resourceIDList := resourceIDs.Slice()
 	workloadIDList := make([]string, len(resourceIDList))
@@ -81,3 +81,19 @@ func uncacheLatestWorkloadIDs(currentResourceIDs strset.Set, appName string) {
 		}
 	}
 }
+
+func resetWorkloadIDCache() {
+	workloadIDCache.Lock()
+	defer workloadIDCache.Unlock()
+	workloadIDCache.m = make(map[string]map[string]string)
+}
+
+func workloadIDCacheSize() int {
+	workloadIDCache.RLock()
+	defer workloadIDCache.RUnlock()
+	size := 0
+	for _, workloadIDs := range workloadIDCache.m {
+		size += len(workloadIDs)
+	}
+	return size
+}
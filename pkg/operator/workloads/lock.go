@@ -0,0 +1,246 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/hash"
+	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/random"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+)
+
+const (
+	// A lock which hasn't been renewed for this long was abandoned (e.g. its operator was restarted)
+	appLockTTL           = 2 * time.Minute
+	appLockRenewInterval = 30 * time.Second
+	appLockLabel         = "cortexAppLock"
+	appLockKey           = "lock"
+)
+
+type AppLock struct {
+	AppName    string    `json:"app_name"`
+	Action     string    `json:"action"`
+	Identity   string    `json:"identity"`
+	OperatorID string    `json:"operator_id"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+
+	// resourceVersion is the version of the lock's config map which this operator last wrote
+	resourceVersion string
+}
+
+// operatorID distinguishes this operator process from others (and from its previous runs) which may hold locks
+var operatorID = newOperatorID()
+
+// appLocks holds the locks which this operator holds or is acquiring. A lock is added before it is stored, so that
+// requests to this operator for the same app fail fast, and appLocksMutex is never held during I/O.
+var appLocksMutex sync.Mutex
+var appLocks = make(map[string]*AppLock)
+
+// OperatorID identifies this operator process to other replicas
+func OperatorID() string {
+	return operatorID
+}
+
+func newOperatorID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "operator"
+	}
+	return hostname + "-" + random.LowercaseString(8)
+}
+
+func isAppLocked(appName string) bool {
+	appLocksMutex.Lock()
+	defer appLocksMutex.Unlock()
+	_, ok := appLocks[appName]
+	return ok
+}
+
+// LockApp prevents concurrent deploys and deletes of an app, by this operator or any other replica.
+// If the app is already locked, ErrorDeploymentInProgress is returned; otherwise the returned function releases the lock.
+//
+// Locks are stored in config maps, which Kubernetes only creates if they don't exist and only updates if they haven't
+// changed since they were read, so two replicas can't both acquire a lock. A replica which stalls for longer than
+// appLockTTL (e.g. while partitioned from Kubernetes) may still lose its lock to another replica while it is working;
+// it logs an error when it next fails to renew the lock.
+func LockApp(appName string, action string, identity string) (func(), error) {
+	lock := &AppLock{
+		AppName:    appName,
+		Action:     action,
+		Identity:   identity,
+		OperatorID: operatorID,
+		AcquiredAt: time.Now(),
+	}
+
+	appLocksMutex.Lock()
+	if heldLock, ok := appLocks[appName]; ok {
+		appLocksMutex.Unlock()
+		return nil, ErrorDeploymentInProgress(heldLock)
+	}
+	appLocks[appName] = lock
+	appLocksMutex.Unlock()
+
+	if err := acquireAppLock(lock); err != nil {
+		appLocksMutex.Lock()
+		delete(appLocks, appName)
+		appLocksMutex.Unlock()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	released := make(chan struct{})
+	go holdAppLock(lock, done, released)
+
+	var releaseOnce sync.Once
+	release := func() {
+		releaseOnce.Do(func() {
+			close(done)
+			<-released
+		})
+	}
+	return release, nil
+}
+
+// acquireAppLock stores lock, unless another operator holds an unexpired lock for the app
+func acquireAppLock(lock *AppLock) error {
+	configMap, storedLock, err := getStoredAppLockConfigMap(lock.AppName)
+	if err != nil {
+		return err
+	}
+	if storedLock != nil && storedLock.OperatorID != operatorID && time.Now().Before(storedLock.ExpiresAt) {
+		return ErrorDeploymentInProgress(storedLock)
+	}
+
+	resourceVersion := ""
+	if configMap != nil {
+		resourceVersion = configMap.ResourceVersion
+	}
+
+	err = storeAppLock(lock, resourceVersion, time.Now().Add(appLockTTL))
+	if k8serrors.IsAlreadyExists(errors.Cause(err)) || k8serrors.IsConflict(errors.Cause(err)) {
+		// Another operator stored its lock since it was read
+		if storedLock, _ := getStoredAppLock(lock.AppName); storedLock != nil {
+			return ErrorDeploymentInProgress(storedLock)
+		}
+		return ErrorDeploymentInProgress(lock)
+	}
+	return err
+}
+
+// holdAppLock renews lock until done is closed, and then releases it
+func holdAppLock(lock *AppLock, done <-chan struct{}, released chan<- struct{}) {
+	defer close(released)
+
+	ticker := time.NewTicker(appLockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			releaseAppLock(lock)
+			return
+		case <-ticker.C:
+			if err := storeAppLock(lock, lock.resourceVersion, time.Now().Add(appLockTTL)); err != nil {
+				errors.PrintError(errors.Wrap(err, "renew lock", lock.AppName))
+			}
+		}
+	}
+}
+
+func releaseAppLock(lock *AppLock) {
+	appLocksMutex.Lock()
+	if appLocks[lock.AppName] == lock {
+		delete(appLocks, lock.AppName)
+	}
+	appLocksMutex.Unlock()
+
+	// The lock is released by expiring it, which fails if another operator has taken it over; if this fails for
+	// another reason, the lock will expire once it's no longer renewed
+	err := storeAppLock(lock, lock.resourceVersion, time.Now())
+	if err != nil && !k8serrors.IsConflict(errors.Cause(err)) {
+		errors.PrintError(errors.Wrap(err, "release lock", lock.AppName))
+	}
+}
+
+// storeAppLock writes lock with the expiration time expiresAt. The lock's config map is created if resourceVersion is
+// empty, and otherwise only updated if its version still matches; lock.resourceVersion is set to the new version.
+func storeAppLock(lock *AppLock, resourceVersion string, expiresAt time.Time) error {
+	lock.ExpiresAt = expiresAt
+	lockJSON, err := libjson.Marshal(lock)
+	if err != nil {
+		return errors.Wrap(err, "lock", lock.AppName)
+	}
+
+	spec := &k8s.ConfigMapSpec{
+		Name:      appLockName(lock.AppName),
+		Namespace: cc.Namespace,
+		Labels:    map[string]string{appLockLabel: "true", "appName": lock.AppName},
+		Data:      map[string]string{appLockKey: string(lockJSON)},
+	}
+
+	var configMap *corev1.ConfigMap
+	if resourceVersion == "" {
+		configMap, err = k8s.CreateConfigMap(spec)
+	} else {
+		configMap = k8s.ConfigMap(spec)
+		configMap.ResourceVersion = resourceVersion
+		configMap, err = k8s.UpdateConfigMap(configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	lock.resourceVersion = configMap.ResourceVersion
+	return nil
+}
+
+func getStoredAppLock(appName string) (*AppLock, error) {
+	_, lock, err := getStoredAppLockConfigMap(appName)
+	return lock, err
+}
+
+// getStoredAppLockConfigMap returns the app's lock config map and the lock stored in it (both are nil if it doesn't exist)
+func getStoredAppLockConfigMap(appName string) (*corev1.ConfigMap, *AppLock, error) {
+	configMap, err := k8s.GetConfigMap(appLockName(appName))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get lock", appName)
+	}
+	if configMap == nil {
+		return nil, nil, nil
+	}
+
+	var lock AppLock
+	if err := libjson.Unmarshal([]byte(configMap.Data[appLockKey]), &lock); err != nil {
+		return nil, nil, errors.Wrap(err, "get lock", appName)
+	}
+	return configMap, &lock, nil
+}
+
+// appLockName is the name of the app's lock config map; app names may not be valid Kubernetes names, so it is hashed
+func appLockName(appName string) string {
+	return "app-lock-" + hash.String(appName)
+}
@@ -26,7 +26,7 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/parallel"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func logPreifixKey(workloadID string, appName string) string {
@@ -43,7 +43,7 @@ func uploadLogPrefix(logPrefix string, workloadID string, appName string) error
 		return nil
 	}
 	key := logPreifixKey(workloadID, appName)
-	err := aws.UploadStringToS3(logPrefix, key)
+	err := storage.UploadString(logPrefix, key)
 	if err != nil {
 		return errors.Wrap(err, "upload log prefix", appName, workloadID)
 	}
@@ -77,9 +77,9 @@ func getSavedLogPrefix(workloadID string, appName string, allowNil bool) (string
 		return logPrefix, nil
 	}
 	key := logPreifixKey(workloadID, appName)
-	logPrefix, err := aws.ReadStringFromS3(key)
+	logPrefix, err := storage.ReadString(key)
 	if err != nil {
-		if aws.IsNoSuchKeyErr(err) && allowNil {
+		if storage.IsNotFoundErr(err) && allowNil {
 			return "", nil
 		}
 		return "", errors.Wrap(err, "download log prefix", appName, workloadID)
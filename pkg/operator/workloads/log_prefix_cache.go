@@ -66,3 +66,19 @@ func uncacheLogPrefixes(currentWorkloadIDs map[string]strset.Set) {
 		}
 	}
 }
+
+func resetLogPrefixCache() {
+	logPrefixCache.Lock()
+	defer logPrefixCache.Unlock()
+	logPrefixCache.m = make(map[string]map[string]string)
+}
+
+func logPrefixCacheSize() int {
+	logPrefixCache.RLock()
+	defer logPrefixCache.RUnlock()
+	size := 0
+	for _, logPrefixes := range logPrefixCache.m {
+		size += len(logPrefixes)
+	}
+	return size
+}
@@ -27,13 +27,15 @@ import (
 
 	"github.com/gorilla/websocket"
 
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/logstore"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 )
 
@@ -47,7 +49,7 @@ func ReadLogs(appName string, workloadID string, verbose bool, socket *websocket
 	wrotePending := false
 
 	for true {
-		allPods, err := k8s.ListPodsByLabels(map[string]string{
+		allPods, err := k8s.ListCachedPodsByLabels(map[string]string{
 			"appName":    appName,
 			"workloadID": workloadID,
 			"userFacing": "true",
@@ -99,7 +101,13 @@ func ReadLogs(appName string, workloadID string, verbose bool, socket *websocket
 			if logPrefix == "" {
 				logPrefix = workloadID
 			}
-			getCloudWatchLogs(logPrefix, verbose, socket)
+			getSavedLogs(logPrefix, verbose, socket)
+			return
+		}
+
+		// Native workflows' tasks don't have pods, so their failures are reported in the workflow
+		if wfItem := pWf.Workloads[workloadID]; argo.IsNative() && wfItem.ArgoPhase != nil && *wfItem.ArgoPhase == awfv1.NodeFailed {
+			writeSocket("\nFailed to start:\n"+wfItem.Message, socket)
 			return
 		}
 
@@ -172,8 +180,8 @@ func getKubectlLogs(pod *corev1.Pod, verbose bool, wrotePending bool, socket *we
 	stopProcess(process)
 }
 
-func getCloudWatchLogs(prefix string, verbose bool, socket *websocket.Conn) {
-	logs, err := aws.GetLogs(prefix)
+func getSavedLogs(prefix string, verbose bool, socket *websocket.Conn) {
+	logs, err := logstore.GetLogs(prefix)
 	if err != nil {
 		telemetry.ReportError(err)
 		errors.PrintError(err)
@@ -191,7 +199,7 @@ func getCloudWatchLogs(prefix string, verbose bool, socket *websocket.Conn) {
 
 	inr, inw, err := os.Pipe()
 	if err != nil {
-		errors.Panic(err, "logs", "cloudwatch", "os.pipe")
+		errors.Panic(err, "logs", "log store", "os.pipe")
 	}
 	defer inr.Close()
 	defer inw.Close()
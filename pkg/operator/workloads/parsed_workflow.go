@@ -35,6 +35,7 @@ type WorkflowItem struct {
 	StartedAt          *time.Time
 	FinishedAt         *time.Time
 	ArgoPhase          *awfv1.NodePhase
+	Message            string
 	DirectDependencies strset.Set
 	AllDependencies    strset.Set
 }
@@ -67,6 +68,7 @@ func parseWorkflow(wf *awfv1.Workflow) (*ParsedWorkflow, error) {
 			StartedAt:          argoWfItem.StartedAt(),
 			FinishedAt:         argoWfItem.FinishedAt(),
 			ArgoPhase:          argoWfItem.Phase(),
+			Message:            argoWfItem.Message(),
 			DirectDependencies: argoWfItem.Dependencies(),
 		}
 	}
@@ -103,6 +105,10 @@ func getAllDependencies(workloadID string, workloads map[string]*WorkflowItem) (
 }
 
 func getFailedArgoWorkloadIDs(appName string) (strset.Set, error) {
+	if argo.IsNative() {
+		return getFailedNativeWorkloadIDs(appName)
+	}
+
 	failedArgoPods, err := k8s.ListPods(&metav1.ListOptions{
 		FieldSelector: "status.phase=Failed",
 		LabelSelector: k8s.LabelSelector(map[string]string{
@@ -121,6 +127,29 @@ func getFailedArgoWorkloadIDs(appName string) (strset.Set, error) {
 	return failedWorkloadIDs, nil
 }
 
+// getFailedNativeWorkloadIDs returns the workloads whose tasks failed in the app's native workflow
+func getFailedNativeWorkloadIDs(appName string) (strset.Set, error) {
+	wf, err := GetWorkflow(appName)
+	if err != nil {
+		return nil, err
+	}
+	pWf, err := parseWorkflow(wf)
+	if err != nil {
+		return nil, err
+	}
+
+	failedWorkloadIDs := strset.New()
+	if pWf == nil {
+		return failedWorkloadIDs, nil
+	}
+	for workloadID, wfItem := range pWf.Workloads {
+		if wfItem.ArgoPhase != nil && *wfItem.ArgoPhase == awfv1.NodeFailed {
+			failedWorkloadIDs.Add(workloadID)
+		}
+	}
+	return failedWorkloadIDs, nil
+}
+
 func getFailedArgoPodForWorkload(workloadID string, appName string) (*corev1.Pod, error) {
 	failedArgoPods, err := k8s.ListPods(&metav1.ListOptions{
 		FieldSelector: "status.phase=Failed",
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"sort"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+)
+
+// Plan returns the resources and workloads that deploying ctx would compute, without running anything.
+// Nothing is uploaded if ctx was built with ocontext.New's dryRun.
+func Plan(ctx *context.Context) (*schema.DeployPlanResponse, error) {
+	allSpecs, _, err := createWorkloadSpecs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	computedResourceIDs := strset.New()
+	for _, spec := range allSpecs {
+		computedResourceIDs.Merge(spec.ResourceIDs)
+	}
+
+	plan := &schema.DeployPlanResponse{
+		ContextID: ctx.ID,
+	}
+
+	for _, res := range ctx.ComputedResources() {
+		plan.Resources = append(plan.Resources, plannedResource(res, computedResourceIDs))
+	}
+	sortPlannedResources(plan.Resources)
+
+	for _, spec := range allSpecs {
+		workload := &schema.PlannedWorkload{
+			WorkloadID:   spec.WorkloadID,
+			WorkloadType: spec.WorkloadType,
+			SparkCompute: spec.SparkCompute,
+			TFCompute:    spec.TFCompute,
+			APICompute:   spec.APICompute,
+		}
+		for resourceID := range spec.ResourceIDs {
+			res := ctx.OneResourceByID(resourceID)
+			workload.Resources = append(workload.Resources, plannedResource(res, computedResourceIDs))
+		}
+		sortPlannedResources(workload.Resources)
+		plan.Workloads = append(plan.Workloads, workload)
+	}
+
+	return plan, nil
+}
+
+func plannedResource(res context.Resource, computedResourceIDs strset.Set) *schema.PlannedResource {
+	return &schema.PlannedResource{
+		Name:         res.GetName(),
+		ResourceType: res.GetResourceType(),
+		Cached:       !computedResourceIDs.Has(res.GetID()),
+	}
+}
+
+func sortPlannedResources(resources []*schema.PlannedResource) {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].ResourceType != resources[j].ResourceType {
+			return resources[i].ResourceType < resources[j].ResourceType
+		}
+		return resources[i].Name < resources[j].Name
+	})
+}
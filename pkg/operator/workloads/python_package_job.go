@@ -26,14 +26,15 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func pythonPackageJobSpec(ctx *context.Context, pythonPackages strset.Set, workloadID string) *batchv1.Job {
 	spec := k8s.Job(&k8s.JobSpec{
-		Name: workloadID,
+		Name:                  workloadID,
+		ActiveDeadlineSeconds: ctx.App.Timeout,
 		Labels: map[string]string{
 			"appName":      ctx.App.Name,
 			"workloadType": workloadTypePythonPackager,
@@ -55,12 +56,12 @@ func pythonPackageJobSpec(ctx *context.Context, pythonPackages strset.Set, workl
 						ImagePullPolicy: "Always",
 						Args: []string{
 							"--workload-id=" + workloadID,
-							"--context=" + aws.S3Path(ctx.Key),
+							"--context=" + storage.Path(ctx.Key),
 							"--cache-dir=" + consts.ContextCacheDir,
 							"--python-packages=" + strings.Join(pythonPackages.Slice(), ","),
 							"--build",
 						},
-						Env:          k8s.AWSCredentials(),
+						Env:          k8s.StorageEnvVars(storage.Endpoint()),
 						VolumeMounts: k8s.DefaultVolumeMounts(),
 					},
 				},
@@ -74,11 +75,11 @@ func pythonPackageJobSpec(ctx *context.Context, pythonPackages strset.Set, workl
 	return spec
 }
 
-func pythonPackageWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
+func pythonPackageWorkloadSpecs(ctx *context.Context, opts *createOptions) ([]*WorkloadSpec, error) {
 	resourceIDs := strset.New()
 
 	for _, pythonPackage := range ctx.PythonPackages {
-		isPythonPackageCached, err := checkResourceCached(pythonPackage, ctx)
+		isPythonPackageCached, err := checkResourceSkipped(pythonPackage, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -103,6 +104,7 @@ func pythonPackageWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 		SuccessCondition: k8s.JobSuccessCondition,
 		FailureCondition: k8s.JobFailureCondition,
 		WorkloadType:     workloadTypePythonPackager,
+		Timeout:          ctx.App.Timeout,
 	}
 
 	return []*WorkloadSpec{workloadSpec}, nil
@@ -0,0 +1,163 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/files"
+	"github.com/cortexlabs/cortex/pkg/lib/quota"
+	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
+)
+
+// quotaPolicy is nil when no quota policy is configured, in which case deployments may request any amount of compute
+var quotaPolicy *quota.Policy
+
+func init() {
+	if err := files.CheckFile(cc.QuotaPolicyPath); err == nil {
+		quotaPolicy, err = quota.NewPolicyFromFile(cc.QuotaPolicyPath)
+		if err != nil {
+			errors.Exit(err, "quota")
+		}
+	}
+}
+
+// checkQuotas returns an error if the compute requested by allSpecs' data processing and training workloads,
+// plus all of ctx's APIs, exceeds any quota which applies to ctx's app
+func checkQuotas(ctx *context.Context, allSpecs []*WorkloadSpec) error {
+	if quotaPolicy == nil {
+		return nil
+	}
+
+	var items []*quota.Item
+	for _, spec := range allSpecs {
+		var usage *quota.Usage
+		var name string
+		switch {
+		case spec.SparkCompute != nil:
+			usage = sparkComputeUsage(spec.SparkCompute)
+			name = "data processing job"
+		case spec.TFCompute != nil:
+			usage = tfComputeUsage(spec.TFCompute)
+			name = "model " + workloadResourceNames(spec, ctx)
+		default:
+			continue // APIs are counted below, whether or not they are being updated
+		}
+
+		items = append(items, &quota.Item{
+			Name:  name,
+			Tags:  workloadResourceTags(spec, ctx),
+			Usage: usage,
+		})
+	}
+
+	for apiName, api := range ctx.APIs {
+		items = append(items, &quota.Item{
+			Name:  "api " + apiName,
+			Tags:  []map[string]string{resourceTags(api)},
+			Usage: apiComputeUsage(api.Compute),
+		})
+	}
+
+	return quotaPolicy.Check(ctx.App.Name, items)
+}
+
+func sparkComputeUsage(sparkCompute *userconfig.SparkCompute) *quota.Usage {
+	executors := int64(sparkCompute.Executors)
+
+	usage := &quota.Usage{
+		CPU: sparkCompute.DriverCPU.Quantity.DeepCopy(),
+		Mem: sparkCompute.DriverMem.Quantity.DeepCopy(),
+	}
+	usage.CPU.Add(scaleQuantity(sparkCompute.ExecutorCPU.Quantity, executors))
+	usage.Mem.Add(scaleQuantity(sparkCompute.ExecutorMem.Quantity, executors))
+	if sparkCompute.DriverMemOverhead != nil {
+		usage.Mem.Add(sparkCompute.DriverMemOverhead.Quantity)
+	}
+	if sparkCompute.ExecutorMemOverhead != nil {
+		usage.Mem.Add(scaleQuantity(sparkCompute.ExecutorMemOverhead.Quantity, executors))
+	}
+	return usage
+}
+
+func tfComputeUsage(tfCompute *userconfig.TFCompute) *quota.Usage {
+	usage := &quota.Usage{}
+	if tfCompute.CPU != nil {
+		usage.CPU.Add(tfCompute.CPU.Quantity)
+	}
+	if tfCompute.Mem != nil {
+		usage.Mem.Add(tfCompute.Mem.Quantity)
+	}
+	if tfCompute.GPU != nil {
+		usage.GPU = *tfCompute.GPU
+	}
+	return usage
+}
+
+func apiComputeUsage(apiCompute *userconfig.APICompute) *quota.Usage {
+	replicas := int64(apiCompute.Replicas)
+
+	usage := &quota.Usage{
+		GPU:         apiCompute.GPU * replicas,
+		APIReplicas: apiCompute.Replicas,
+	}
+	if apiCompute.CPU != nil {
+		usage.CPU.Add(scaleQuantity(apiCompute.CPU.Quantity, replicas))
+	}
+	if apiCompute.Mem != nil {
+		usage.Mem.Add(scaleQuantity(apiCompute.Mem.Quantity, replicas))
+	}
+	return usage
+}
+
+func scaleQuantity(quantity k8sresource.Quantity, factor int64) k8sresource.Quantity {
+	return *k8sresource.NewMilliQuantity(quantity.MilliValue()*factor, quantity.Format)
+}
+
+func workloadResourceNames(spec *WorkloadSpec, ctx *context.Context) string {
+	var names []string
+	for resourceID := range spec.ResourceIDs {
+		names = append(names, ctx.OneResourceByID(resourceID).GetName())
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func workloadResourceTags(spec *WorkloadSpec, ctx *context.Context) []map[string]string {
+	var allTags []map[string]string
+	for resourceID := range spec.ResourceIDs {
+		allTags = append(allTags, resourceTags(ctx.OneResourceByID(resourceID)))
+	}
+	return allTags
+}
+
+func resourceTags(res context.Resource) map[string]string {
+	tags := make(map[string]string)
+	if tagged, ok := res.(userconfig.Tagged); ok {
+		for key, value := range tagged.GetTags() {
+			tags[key] = fmt.Sprint(value)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	libstorage "github.com/cortexlabs/cortex/pkg/lib/storage"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// GetCachedResources returns whether each of ctx's data resources has a cached output (which the next deployment would reuse),
+// and the size of the raw dataset (which all of the raw columns share)
+func GetCachedResources(ctx *context.Context) (*schema.GetCacheResponse, error) {
+	resources := ctx.DataComputedResources()
+	resourceIDs := strset.New()
+	for _, res := range resources {
+		resourceIDs.Add(res.GetID())
+	}
+	resourceWorkloadIDs, err := getCurrentLatestWorkloadIDs(resourceIDs, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+	savedStatuses, err := getDataSavedStatuses(resourceWorkloadIDs, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := listResourceOutputObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cachedResources []*schema.CachedResource
+	for _, res := range resources {
+		cachedResource := &schema.CachedResource{
+			Name:         res.GetName(),
+			ResourceType: res.GetResourceType(),
+			ResourceID:   res.GetID(),
+			WorkloadID:   resourceWorkloadIDs[res.GetID()],
+		}
+		if savedStatus := savedStatuses[res.GetID()]; savedStatus != nil && savedStatus.ExitCode == resource.ExitCodeDataSucceeded {
+			cachedResource.Cached = true
+			cachedResource.CachedAt = savedStatus.End
+		}
+		if prefix := resourceOutputPrefix(res); prefix != "" {
+			cachedResource.Bytes = pointer.Int64(prefixBytes(objects, prefix))
+		}
+		cachedResources = append(cachedResources, cachedResource)
+	}
+
+	response := &schema.GetCacheResponse{Resources: cachedResources}
+	if len(ctx.RawColumns) > 0 {
+		response.RawDatasetBytes = pointer.Int64(prefixBytes(objects, ctx.RawDataset.Key))
+	}
+
+	sort.Slice(cachedResources, func(i, j int) bool {
+		if cachedResources[i].ResourceType != cachedResources[j].ResourceType {
+			return cachedResources[i].ResourceType < cachedResources[j].ResourceType
+		}
+		return cachedResources[i].Name < cachedResources[j].Name
+	})
+	return response, nil
+}
+
+// InvalidateResource removes res, and the data resources which depend on it, from the app's cache so that the
+// next deployment recomputes them; their outputs are overwritten when they are recomputed.
+// It returns the IDs of the invalidated resources.
+func InvalidateResource(res context.ComputedResource, ctx *context.Context) (strset.Set, error) {
+	if res.GetResourceType() == resource.APIType {
+		return nil, ErrorResourceNotCached(res.GetName())
+	}
+
+	existingWf, err := GetWorkflow(ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+	if argo.IsRunning(existingWf) {
+		return nil, ErrorWorkflowRunning(ctx.App.Name)
+	}
+
+	resourceIDs := strset.New(res.GetID())
+	for _, dataResource := range ctx.DataComputedResources() {
+		if ctx.AllComputedResourceDependencies(dataResource.GetID()).Has(res.GetID()) {
+			resourceIDs.Add(dataResource.GetID())
+		}
+	}
+
+	keys := make([]string, 0, len(resourceIDs))
+	for resourceID := range resourceIDs {
+		keys = append(keys, ocontext.LatestWorkloadIDKey(resourceID, ctx.App.Name))
+	}
+	err = storage.DeleteKeys(keys)
+	uncacheLatestWorkloadIDs(nil, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceIDs, nil
+}
+
+// resourceOutputPrefix returns the prefix of the objects which hold res's output, or "" if it isn't stored separately
+// (raw columns are stored together in the raw dataset, and transformed columns are stored in the training datasets which use them)
+func resourceOutputPrefix(res context.ComputedResource) string {
+	switch res := res.(type) {
+	case *context.PythonPackage:
+		return filepath.Dir(res.PackageKey) + "/"
+	case *context.Aggregate:
+		return res.Key
+	case *context.TrainingDataset:
+		return filepath.Dir(res.TrainKey) + "/"
+	case *context.Model:
+		return res.Key
+	}
+	return ""
+}
+
+func listResourceOutputObjects(ctx *context.Context) ([]*libstorage.Object, error) {
+	objects, err := storage.ListObjects(ctx.Root + "/")
+	if err != nil {
+		return nil, err
+	}
+	for _, pythonPackage := range ctx.PythonPackages {
+		packageObjects, err := storage.ListObjects(resourceOutputPrefix(pythonPackage))
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, packageObjects...)
+	}
+	return objects, nil
+}
+
+func prefixBytes(objects []*libstorage.Object, prefix string) int64 {
+	var bytes int64
+	for _, object := range objects {
+		if strings.HasPrefix(object.Key, prefix) {
+			bytes += object.Size
+		}
+	}
+	return bytes
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+)
+
+// GetResources returns the statuses of all of ctx's resources
+func GetResources(ctx *context.Context) (*schema.GetResourcesResponse, error) {
+	dataStatuses, err := GetCurrentDataStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiStatuses, err := GetCurrentAPIStatuses(ctx, dataStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	apiGroupStatuses, err := GetAPIGroupStatuses(apiStatuses, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apisBaseURL, err := APIsBaseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.GetResourcesResponse{
+		Context:          ctx,
+		DataStatuses:     dataStatuses,
+		APIStatuses:      apiStatuses,
+		APIGroupStatuses: apiGroupStatuses,
+		APIsBaseURL:      apisBaseURL,
+	}, nil
+}
+
+// ResourcesSnapshot is sent to resources subscribers. Resources is nil if the app is not deployed.
+type ResourcesSnapshot struct {
+	Resources *schema.GetResourcesResponse
+	Err       error
+}
+
+// Each watched app is polled once per interval, regardless of how many subscribers it has
+var (
+	resourcesSubscribers      = make(map[string]map[chan *ResourcesSnapshot]bool)
+	resourcesSubscribersMutex sync.Mutex
+)
+
+// SubscribeResources returns a channel which receives snapshots of the app's resources until unsubscribe is called.
+// Snapshots are not queued: a slow subscriber only receives the latest one.
+func SubscribeResources(appName string) (<-chan *ResourcesSnapshot, func()) {
+	snapshots := make(chan *ResourcesSnapshot, 1)
+
+	resourcesSubscribersMutex.Lock()
+	defer resourcesSubscribersMutex.Unlock()
+
+	if resourcesSubscribers[appName] == nil {
+		resourcesSubscribers[appName] = make(map[chan *ResourcesSnapshot]bool)
+		go pollResources(appName)
+	}
+	resourcesSubscribers[appName][snapshots] = true
+
+	unsubscribe := func() {
+		resourcesSubscribersMutex.Lock()
+		defer resourcesSubscribersMutex.Unlock()
+		delete(resourcesSubscribers[appName], snapshots)
+	}
+
+	return snapshots, unsubscribe
+}
+
+func pollResources(appName string) {
+	for true {
+		snapshot := &ResourcesSnapshot{}
+		if ctx := CurrentContext(appName); ctx != nil {
+			snapshot.Resources, snapshot.Err = GetResources(ctx)
+		}
+
+		resourcesSubscribersMutex.Lock()
+		if len(resourcesSubscribers[appName]) == 0 {
+			delete(resourcesSubscribers, appName)
+			resourcesSubscribersMutex.Unlock()
+			return
+		}
+		for snapshots := range resourcesSubscribers[appName] {
+			select {
+			case snapshots <- snapshot:
+			default:
+				// Replace the snapshot which hasn't been received yet
+				select {
+				case <-snapshots:
+				default:
+				}
+				snapshots <- snapshot
+			}
+		}
+		resourcesSubscribersMutex.Unlock()
+
+		time.Sleep(time.Duration(userFacingCheckInterval) * time.Second)
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"time"
+
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/schema"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/quota"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
+)
+
+// workloadAttempt describes how a failed workload's resources should be recomputed
+type workloadAttempt struct {
+	Attempt   int32
+	MemFactor float64 // multiplies the user-configured memory (compounded across out-of-memory retries)
+}
+
+// attempt returns the latest attempt (and largest memory factor) of any of the resources
+func (opts *createOptions) attempt(resourceIDs strset.Set) *workloadAttempt {
+	merged := &workloadAttempt{
+		Attempt:   1,
+		MemFactor: 1,
+	}
+	if opts == nil {
+		return merged
+	}
+	for resourceID := range resourceIDs {
+		attempt, ok := opts.attempts[resourceID]
+		if !ok {
+			continue
+		}
+		if attempt.Attempt > merged.Attempt {
+			merged.Attempt = attempt.Attempt
+		}
+		if attempt.MemFactor > merged.MemFactor {
+			merged.MemFactor = attempt.MemFactor
+		}
+	}
+	return merged
+}
+
+// RetryFailedWorkloads redeploys apps whose workflows failed, if all of the failed workloads have retries remaining
+// and their backoffs have elapsed. Only the failed workloads' resources (and the resources which depend on them) are recomputed.
+func RetryFailedWorkloads() error {
+	var errs []error
+	for _, ctx := range CurrentContexts() {
+		if err := retryFailedWorkloads(ctx.App.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.FirstError(errs...)
+}
+
+func retryFailedWorkloads(appName string) error {
+	wf, err := GetWorkflow(appName)
+	if err != nil {
+		return err
+	}
+	attempts, workloadIDs, err := getRetryAttempts(wf, appName)
+	if err != nil || attempts == nil {
+		return err
+	}
+
+	unlock, err := LockApp(appName, schema.AuditActionRetry, "operator")
+	if err != nil {
+		// The app is being deployed or deleted; the retry will be reconsidered afterwards
+		if cause, ok := errors.Cause(err).(Error); ok && cause.Kind == ErrDeploymentInProgress {
+			return nil
+		}
+		return err
+	}
+	defer unlock()
+
+	// The app may have been redeployed or deleted since the workflow was read
+	existingWf, err := GetWorkflow(appName)
+	if err != nil {
+		return err
+	}
+	if existingWf == nil || existingWf.Name != wf.Name {
+		return nil
+	}
+
+	ctx, err := ocontext.DownloadContext(wf.Labels["ctxID"], appName)
+	if err != nil {
+		return errors.Wrap(err, appName, "retry")
+	}
+
+	newWf, err := create(ctx, &createOptions{attempts: attempts})
+	if cause, ok := errors.Cause(err).(quota.Error); ok && cause.Kind == quota.ErrQuotaExceeded {
+		// The retries would exceed the quota (e.g. because out-of-memory retries request more memory), so they are given up on
+		nowTime := pointer.Time(time.Now())
+		for _, workloadID := range workloadIDs {
+			if markErr := setDataWorkloadExitCode(workloadID, appName, resource.ExitCodeDataQuotaExceeded, nowTime,
+				resource.ExitCodeDataFailed, resource.ExitCodeDataKilled, resource.ExitCodeDataOOM, resource.ExitCodeDataTimedOut); markErr != nil {
+				return errors.Wrap(markErr, appName, "retry")
+			}
+		}
+		return errors.Wrap(err, appName, "retry")
+	}
+	if err != nil {
+		return errors.Wrap(err, appName, "retry")
+	}
+
+	err = storage.UploadMsgpack(ctx.ToSerial(), ctx.Key)
+	if err != nil {
+		return errors.Wrap(err, appName, "retry", "upload context")
+	}
+
+	err = Run(newWf, ctx, existingWf)
+	if err != nil {
+		return errors.Wrap(err, appName, "retry")
+	}
+
+	return nil
+}
+
+// getRetryAttempts returns the next attempts of the resources in wf's failed workloads (keyed by resource ID) and the IDs of those workloads,
+// or nil if wf hasn't failed, one of its failed workloads can't be retried, or a backoff hasn't elapsed
+func getRetryAttempts(wf *awfv1.Workflow, appName string) (map[string]*workloadAttempt, []string, error) {
+	if wf == nil || !argo.IsDone(wf) || !isFailedPhase(wf.Status.Phase) {
+		return nil, nil, nil
+	}
+
+	pWf, err := parseWorkflow(wf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attempts := make(map[string]*workloadAttempt)
+	var workloadIDs []string
+	for workloadID, wfItem := range pWf.Workloads {
+		if wfItem.ArgoPhase == nil || !isFailedPhase(*wfItem.ArgoPhase) {
+			continue
+		}
+
+		savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if savedWorkloadSpec == nil {
+			return nil, nil, nil
+		}
+
+		attempt, err := nextAttempt(savedWorkloadSpec)
+		if err != nil || attempt == nil {
+			return nil, nil, err
+		}
+
+		finishedAt := wfItem.FinishedAt
+		if finishedAt == nil {
+			finishedAt = &wf.Status.FinishedAt.Time
+		}
+		backoff := time.Duration(savedWorkloadSpec.Retry.BackoffSeconds(savedWorkloadSpec.Attempt)) * time.Second
+		if time.Now().Before(finishedAt.Add(backoff)) {
+			return nil, nil, nil
+		}
+
+		for resourceID := range savedWorkloadSpec.Resources {
+			attempts[resourceID] = attempt
+		}
+		workloadIDs = append(workloadIDs, workloadID)
+	}
+
+	if len(attempts) == 0 {
+		return nil, nil, nil
+	}
+	return attempts, workloadIDs, nil
+}
+
+// nextAttempt returns the workload's next attempt, or nil if its retry policy doesn't allow another one
+// (or its retries were given up on because they would exceed the quota)
+func nextAttempt(savedWorkloadSpec *SavedWorkloadSpec) (*workloadAttempt, error) {
+	retry := savedWorkloadSpec.Retry
+	if retry == nil || savedWorkloadSpec.Attempt < 1 || savedWorkloadSpec.Attempt >= retry.MaxAttempts {
+		return nil, nil
+	}
+
+	isQuotaExceeded, err := hasWorkloadExitCode(savedWorkloadSpec, resource.ExitCodeDataQuotaExceeded)
+	if err != nil || isQuotaExceeded {
+		return nil, err
+	}
+
+	memFactor := savedWorkloadSpec.MemFactor
+	if memFactor < 1 {
+		memFactor = 1
+	}
+
+	isOOM, err := hasWorkloadExitCode(savedWorkloadSpec, resource.ExitCodeDataOOM)
+	if err != nil {
+		return nil, err
+	}
+	if isOOM {
+		if !retry.RetryOOM {
+			return nil, nil
+		}
+		memFactor *= retry.OOMMemFactor
+	}
+
+	return &workloadAttempt{
+		Attempt:   savedWorkloadSpec.Attempt + 1,
+		MemFactor: memFactor,
+	}, nil
+}
+
+// hasWorkloadExitCode returns whether any of the workload's resources ended with exitCode
+func hasWorkloadExitCode(savedWorkloadSpec *SavedWorkloadSpec, exitCode resource.DataExitCode) (bool, error) {
+	for resourceID := range savedWorkloadSpec.Resources {
+		savedStatus, err := getDataSavedStatus(resourceID, savedWorkloadSpec.WorkloadID, savedWorkloadSpec.AppName)
+		if err != nil {
+			return false, err
+		}
+		if savedStatus != nil && savedStatus.ExitCode == exitCode {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isFailedPhase(phase awfv1.NodePhase) bool {
+	return phase == awfv1.NodeFailed || phase == awfv1.NodeError
+}
+
+// RetryResource reruns the failed workload which computed res, and the workloads of the resources which depend on it
+// (the app's other resources, including its other failed resources, are left as they are). Retries start from the first attempt.
+// It returns the IDs of the resources which will be recomputed.
+func RetryResource(res context.ComputedResource, ctx *context.Context) (strset.Set, error) {
+	existingWf, err := GetWorkflow(ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+	if argo.IsRunning(existingWf) {
+		return nil, ErrorWorkflowRunning(ctx.App.Name)
+	}
+
+	dataStatuses, err := GetCurrentDataStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataStatus := dataStatuses[res.GetID()]
+	if dataStatus == nil {
+		return nil, ErrorResourceNotFailed(res.GetName(), resource.StatusUnknown.Message())
+	}
+	switch dataStatus.Code {
+	case resource.StatusDataFailed, resource.StatusDataKilled, resource.StatusDataKilledOOM, resource.StatusDataTimedOut, resource.StatusDataQuotaExceeded:
+	default:
+		return nil, ErrorResourceNotFailed(res.GetName(), dataStatus.Message())
+	}
+
+	resourceIDs := strset.New(res.GetID())
+	savedWorkloadSpec, err := getSavedWorkloadSpec(dataStatus.WorkloadID, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+	if savedWorkloadSpec != nil {
+		for resourceID := range savedWorkloadSpec.Resources {
+			resourceIDs.Add(resourceID)
+		}
+	}
+
+	for _, computedResource := range ctx.ComputedResources() {
+		if ctx.AllComputedResourceDependencies(computedResource.GetID()).HasAny(resourceIDs.Slice()...) {
+			resourceIDs.Add(computedResource.GetID())
+		}
+	}
+
+	// The current context is shared, so the new workload IDs are populated in a fresh copy
+	newCtx, err := ocontext.DownloadContext(ctx.ID, ctx.App.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	newWf, err := create(newCtx, &createOptions{resourceIDs: resourceIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.UploadMsgpack(newCtx.ToSerial(), newCtx.Key)
+	if err != nil {
+		return nil, errors.Wrap(err, ctx.App.Name, "upload context")
+	}
+
+	err = Run(newWf, newCtx, existingWf)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceIDs, nil
+}
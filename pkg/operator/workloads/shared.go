@@ -22,11 +22,39 @@ import (
 	"github.com/cortexlabs/cortex/pkg/lib/random"
 )
 
+// CacheSizes returns the number of entries in each of the operator's status caches
+func CacheSizes() map[string]int {
+	return map[string]int{
+		"data_saved_status":  dataStatusCacheSize(),
+		"api_saved_status":   apiStatusCacheSize(),
+		"latest_workload_id": workloadIDCacheSize(),
+		"log_prefix":         logPrefixCacheSize(),
+		"workload_spec":      workloadSpecCacheSize(),
+	}
+}
+
+// resetCaches empties the status caches, so that statuses are read from storage again
+func resetCaches() {
+	resetDataStatusCache()
+	resetAPIStatusCache()
+	resetWorkloadIDCache()
+	resetLogPrefixCache()
+	resetWorkloadSpecCache()
+}
+
 func generateWorkloadID() string {
 	// k8s needs all characters to be lower case, and the first to be a letter
 	return random.LowercaseLetters(1) + random.LowercaseString(19)
 }
 
+// checkResourceSkipped returns whether res doesn't need to be computed, because it's cached or excluded by opts
+func checkResourceSkipped(res context.ComputedResource, ctx *context.Context, opts *createOptions) (bool, error) {
+	if opts != nil && opts.resourceIDs != nil && !opts.resourceIDs.Has(res.GetID()) {
+		return true, nil
+	}
+	return checkResourceCached(res, ctx)
+}
+
 func checkResourceCached(res context.ComputedResource, ctx *context.Context) (bool, error) {
 	workloadID := res.GetWorkloadID()
 	if workloadID == "" {
@@ -0,0 +1,99 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
+	"github.com/cortexlabs/cortex/pkg/operator/argo"
+	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
+)
+
+// SyncState reconciles this operator's current contexts with the running workflows, which may have been
+// created or deleted by another operator replica. The leader keeps its status caches (it writes most of the
+// statuses), except for apps which were redeployed elsewhere; other replicas empty theirs.
+func SyncState(isLeader bool) error {
+	// Contexts which change while the workflows are listed (e.g. by a deploy on this replica) are left as they are
+	prevCtxs := make(map[string]*context.Context)
+	for _, ctx := range CurrentContexts() {
+		prevCtxs[ctx.App.Name] = ctx
+	}
+
+	workflows, err := argo.ListCached()
+	if err != nil {
+		return errors.Wrap(err, "sync", "argo", "list")
+	}
+
+	workflowCtxIDs := make(map[string]strset.Set)
+	for _, wf := range workflows {
+		appName := wf.Labels["appName"]
+		if appName == "" {
+			continue
+		}
+		if _, ok := workflowCtxIDs[appName]; !ok {
+			workflowCtxIDs[appName] = strset.New()
+		}
+		workflowCtxIDs[appName].Add(wf.Labels["ctxID"])
+	}
+
+	var errs []error
+	for appName, ctxIDs := range workflowCtxIDs {
+		// Deploys and deletes in progress on this replica update the current context themselves
+		if len(ctxIDs) != 1 || isAppLocked(appName) {
+			continue
+		}
+		ctxID := ctxIDs.Slice()[0]
+		prevCtx := prevCtxs[appName]
+		if prevCtx != nil && prevCtx.ID == ctxID {
+			continue
+		}
+
+		ctx, err := ocontext.DownloadContext(ctxID, appName)
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, "sync", appName))
+			continue
+		}
+		if replaceCurrentContext(appName, prevCtx, ctx) {
+			uncacheDataSavedStatuses(nil, appName)
+			uncacheLatestWorkloadIDs(nil, appName)
+		}
+	}
+
+	for appName, prevCtx := range prevCtxs {
+		if _, ok := workflowCtxIDs[appName]; ok || isAppLocked(appName) {
+			continue
+		}
+		// Another replica may be between stopping the app's previous workflow and starting its new one
+		if storedLock, err := getStoredAppLock(appName); err != nil || (storedLock != nil && time.Now().Before(storedLock.ExpiresAt)) {
+			continue
+		}
+		if replaceCurrentContext(appName, prevCtx, nil) {
+			uncacheDataSavedStatuses(nil, appName)
+			uncacheLatestWorkloadIDs(nil, appName)
+			uncacheWorkloadSpecs(appName)
+		}
+	}
+
+	if !isLeader {
+		resetCaches()
+	}
+
+	return errors.FirstError(errs...)
+}
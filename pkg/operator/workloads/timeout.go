@@ -0,0 +1,116 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"time"
+
+	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
+	"github.com/cortexlabs/cortex/pkg/lib/errors"
+	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/spark"
+)
+
+// EnforceWorkloadTimeouts terminates the workloads which have run for longer than their timeouts, and marks their resources as timed out.
+// Training and python package jobs are also terminated by Kubernetes (via activeDeadlineSeconds), but Spark applications have no deadline of their own,
+// so the leader runs this periodically rather than only when workloads change (a hung Spark application doesn't change).
+func EnforceWorkloadTimeouts() error {
+	var errs []error
+	for _, ctx := range CurrentContexts() {
+		if err := enforceWorkloadTimeouts(ctx.App.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.FirstError(errs...)
+}
+
+func enforceWorkloadTimeouts(appName string) error {
+	wf, err := GetWorkflow(appName)
+	if err != nil {
+		return err
+	}
+	pWf, err := parseWorkflow(wf)
+	if err != nil || pWf == nil {
+		return err
+	}
+
+	now := time.Now()
+	for workloadID, wfItem := range pWf.Workloads {
+		if wfItem.WorkloadType == WorkloadTypeAPI || wfItem.StartedAt == nil {
+			continue
+		}
+
+		run := &userconfig.WorkloadRun{
+			StartedAt:  *wfItem.StartedAt,
+			FinishedAt: wfItem.FinishedAt,
+			Failed:     wfItem.ArgoPhase != nil && isFailedPhase(*wfItem.ArgoPhase),
+		}
+		if run.FinishedAt != nil && !run.Failed {
+			continue
+		}
+
+		// Jobs which exceeded their deadline have already been terminated by Kubernetes, and their tasks failed
+		if run.FinishedAt != nil && wfItem.WorkloadType != workloadTypeData {
+			job, err := k8s.GetCachedJob(workloadID)
+			if err != nil {
+				return errors.Wrap(err, appName, "timeout", workloadID)
+			}
+			run.DeadlineExceeded = k8s.IsJobDeadlineExceeded(job)
+		}
+
+		savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
+		if err != nil {
+			return err
+		}
+		if savedWorkloadSpec == nil || !run.TimedOut(savedWorkloadSpec.Timeout, now) {
+			continue
+		}
+
+		if run.FinishedAt != nil {
+			// The failed pods may have already been reported (as failed or terminated), so their exit codes are replaced
+			err = setDataWorkloadExitCode(workloadID, appName, resource.ExitCodeDataTimedOut, &now,
+				resource.ExitCodeDataFailed, resource.ExitCodeDataKilled)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Update the statuses first, so that the terminated pods aren't reported as failures
+		err = updateDataWorkloadError(workloadID, appName, resource.ExitCodeDataTimedOut, &now)
+		if err != nil {
+			return err
+		}
+
+		err = terminateWorkload(workloadID, wfItem.WorkloadType)
+		if err != nil {
+			return errors.Wrap(err, appName, "timeout", workloadID)
+		}
+	}
+	return nil
+}
+
+// terminateWorkload deletes the workload's Spark application or job, which fails its workflow task
+func terminateWorkload(workloadID string, workloadType string) error {
+	if workloadType == workloadTypeData {
+		_, err := spark.Delete(workloadID)
+		return err
+	}
+	_, err := k8s.DeleteJob(workloadID)
+	return err
+}
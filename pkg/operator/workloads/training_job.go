@@ -26,9 +26,9 @@ import (
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	cc "github.com/cortexlabs/cortex/pkg/operator/cortexconfig"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 func trainingJobSpec(
@@ -55,7 +55,8 @@ func trainingJobSpec(
 	}
 
 	spec := k8s.Job(&k8s.JobSpec{
-		Name: workloadID,
+		Name:                  workloadID,
+		ActiveDeadlineSeconds: tfCompute.Timeout,
 		Labels: map[string]string{
 			"appName":      ctx.App.Name,
 			"workloadType": workloadTypeTrain,
@@ -77,11 +78,11 @@ func trainingJobSpec(
 						ImagePullPolicy: "Always",
 						Args: []string{
 							"--workload-id=" + workloadID,
-							"--context=" + aws.S3Path(ctx.Key),
+							"--context=" + storage.Path(ctx.Key),
 							"--cache-dir=" + consts.ContextCacheDir,
 							"--model=" + modelID,
 						},
-						Env:          k8s.AWSCredentials(),
+						Env:          k8s.StorageEnvVars(storage.Endpoint()),
 						VolumeMounts: k8s.DefaultVolumeMounts(),
 						Resources: corev1.ResourceRequirements{
 							Requests: resourceList,
@@ -99,10 +100,10 @@ func trainingJobSpec(
 	return spec
 }
 
-func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
+func trainingWorkloadSpecs(ctx *context.Context, opts *createOptions) ([]*WorkloadSpec, error) {
 	modelsToTrain := make(map[string]*userconfig.TFCompute)
 	for _, model := range ctx.Models {
-		modelCached, err := checkResourceCached(model, ctx)
+		modelCached, err := checkResourceSkipped(model, ctx, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -120,6 +121,8 @@ func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 	var workloadSpecs []*WorkloadSpec
 	for modelID, tfCompute := range modelsToTrain {
 		workloadID := generateWorkloadID()
+		attempt := opts.attempt(strset.New(modelID))
+		tfCompute = tfCompute.ScaleMem(attempt.MemFactor)
 		workloadSpecs = append(workloadSpecs, &WorkloadSpec{
 			WorkloadID:       workloadID,
 			ResourceIDs:      strset.New(modelID),
@@ -128,6 +131,11 @@ func trainingWorkloadSpecs(ctx *context.Context) ([]*WorkloadSpec, error) {
 			SuccessCondition: k8s.JobSuccessCondition,
 			FailureCondition: k8s.JobFailureCondition,
 			WorkloadType:     workloadTypeTrain,
+			TFCompute:        tfCompute,
+			Timeout:          tfCompute.Timeout,
+			Retry:            tfCompute.Retry,
+			Attempt:          attempt.Attempt,
+			MemFactor:        attempt.MemFactor,
 		})
 	}
 
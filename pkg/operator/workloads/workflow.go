@@ -19,18 +19,23 @@ package workloads
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 
 	"github.com/cortexlabs/cortex/pkg/api/context"
+	"github.com/cortexlabs/cortex/pkg/api/resource"
 	"github.com/cortexlabs/cortex/pkg/consts"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	libjson "github.com/cortexlabs/cortex/pkg/lib/json"
+	"github.com/cortexlabs/cortex/pkg/lib/pointer"
+	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
 	"github.com/cortexlabs/cortex/pkg/lib/slices"
 	"github.com/cortexlabs/cortex/pkg/operator/argo"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 	"github.com/cortexlabs/cortex/pkg/operator/telemetry"
 )
 
@@ -54,7 +59,18 @@ func init() {
 }
 
 func Create(ctx *context.Context) (*awfv1.Workflow, error) {
-	err := populateLatestWorkloadIDs(ctx)
+	return create(ctx, nil)
+}
+
+// createOptions customize the workloads which create builds; nil options compute all uncached resources
+type createOptions struct {
+	attempts    map[string]*workloadAttempt // resource ID -> attempt, when retrying failed workloads
+	resourceIDs strset.Set                  // if set, only these resources are computed (the others are left as they are)
+}
+
+// create builds the workflow for ctx
+func create(ctx *context.Context, opts *createOptions) (*awfv1.Workflow, error) {
+	allSpecs, resourceWorkloadIDs, err := createWorkloadSpecs(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -65,40 +81,6 @@ func Create(ctx *context.Context) (*awfv1.Workflow, error) {
 	}
 	wf := argo.New(ctx.App.Name, labels)
 
-	var allSpecs []*WorkloadSpec
-
-	pythonPackageJobSpecs, err := pythonPackageWorkloadSpecs(ctx)
-	if err != nil {
-		return nil, err
-	}
-	allSpecs = append(allSpecs, pythonPackageJobSpecs...)
-
-	dataJobSpecs, err := dataWorkloadSpecs(ctx)
-	if err != nil {
-		return nil, err
-	}
-	allSpecs = append(allSpecs, dataJobSpecs...)
-
-	trainingJobSpecs, err := trainingWorkloadSpecs(ctx)
-	if err != nil {
-		return nil, err
-	}
-	allSpecs = append(allSpecs, trainingJobSpecs...)
-
-	apiSpecs, err := apiWorkloadSpecs(ctx)
-	if err != nil {
-		return nil, err
-	}
-	allSpecs = append(allSpecs, apiSpecs...)
-
-	resourceWorkloadIDs := make(map[string]string)
-	for _, spec := range allSpecs {
-		for resourceID := range spec.ResourceIDs {
-			resourceWorkloadIDs[resourceID] = spec.WorkloadID
-		}
-	}
-	ctx.PopulateWorkloadIDs(resourceWorkloadIDs)
-
 	for _, spec := range allSpecs {
 		var dependencyWorkloadIDs []string
 		for resourceID := range spec.ResourceIDs {
@@ -138,12 +120,70 @@ func Create(ctx *context.Context) (*awfv1.Workflow, error) {
 	return wf, nil
 }
 
-func populateLatestWorkloadIDs(ctx *context.Context) error {
-	resourceIDs := ctx.ComputedResourceIDs()
-	resourceWorkloadIDs, err := getSavedLatestWorkloadIDs(resourceIDs, ctx.App.Name)
+// createWorkloadSpecs determines which workloads are needed to compute ctx's uncached resources,
+// and populates the workload IDs of all of ctx's computed resources. The workloads are checked against the quota policy,
+// unless the app is being stopped (which computes no resources, and can only reduce its usage)
+func createWorkloadSpecs(ctx *context.Context, opts *createOptions) ([]*WorkloadSpec, map[string]string, error) {
+	err := populateLatestWorkloadIDs(ctx, opts == nil)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
 
+	var allSpecs []*WorkloadSpec
+
+	pythonPackageJobSpecs, err := pythonPackageWorkloadSpecs(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	allSpecs = append(allSpecs, pythonPackageJobSpecs...)
+
+	dataJobSpecs, err := dataWorkloadSpecs(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	allSpecs = append(allSpecs, dataJobSpecs...)
+
+	trainingJobSpecs, err := trainingWorkloadSpecs(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	allSpecs = append(allSpecs, trainingJobSpecs...)
+
+	apiSpecs, err := apiWorkloadSpecs(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	allSpecs = append(allSpecs, apiSpecs...)
+
+	if opts == nil || opts.resourceIDs == nil || len(opts.resourceIDs) > 0 {
+		err = checkQuotas(ctx, allSpecs)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	resourceWorkloadIDs := make(map[string]string)
+	for _, spec := range allSpecs {
+		for resourceID := range spec.ResourceIDs {
+			resourceWorkloadIDs[resourceID] = spec.WorkloadID
+		}
+	}
+	ctx.PopulateWorkloadIDs(resourceWorkloadIDs)
+
+	return allSpecs, resourceWorkloadIDs, nil
+}
+
+// populateLatestWorkloadIDs populates the workload IDs of ctx's computed resources with their latest workload IDs,
+// which are read from storage rather than the cache if current is true (see getCurrentLatestWorkloadIDs)
+func populateLatestWorkloadIDs(ctx *context.Context, current bool) error {
+	getLatestWorkloadIDs := getSavedLatestWorkloadIDs
+	if current {
+		getLatestWorkloadIDs = getCurrentLatestWorkloadIDs
+	}
+
+	resourceWorkloadIDs, err := getLatestWorkloadIDs(ctx.ComputedResourceIDs(), ctx.App.Name)
+	if err != nil {
+		return err
 	}
 	ctx.PopulateWorkloadIDs(resourceWorkloadIDs)
 	return nil
@@ -214,6 +254,50 @@ func Stop(wf *awfv1.Workflow, ctx *context.Context) error {
 	return nil
 }
 
+// StopApp terminates the app's running workflow, and marks the resources whose workloads hadn't finished as terminated.
+// The app remains deployed (with an empty workflow), its APIs are left as they are, and its computed resources stay cached.
+// It returns false if the app's workflow wasn't running.
+func StopApp(ctx *context.Context) (bool, error) {
+	existingWf, err := GetWorkflow(ctx.App.Name)
+	if err != nil {
+		return false, err
+	}
+	if !argo.IsRunning(existingWf) {
+		return false, nil
+	}
+
+	pWf, err := parseWorkflow(existingWf)
+	if err != nil {
+		return false, err
+	}
+	nowTime := pointer.Time(time.Now())
+	for workloadID, wfItem := range pWf.Workloads {
+		if wfItem.WorkloadType == WorkloadTypeAPI || wfItem.FinishedAt != nil {
+			continue
+		}
+		err := updateDataWorkloadError(workloadID, ctx.App.Name, resource.ExitCodeDataKilled, nowTime)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// The current context is shared, so the workflow is created from a fresh copy
+	newCtx, err := ocontext.DownloadContext(ctx.ID, ctx.App.Name)
+	if err != nil {
+		return false, err
+	}
+	newWf, err := create(newCtx, &createOptions{resourceIDs: strset.New()})
+	if err != nil {
+		return false, err
+	}
+
+	err = Run(newWf, newCtx, existingWf)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func DeleteApp(appName string, keepCache bool) bool {
 	ctx := CurrentContext(appName)
 	wasDeployed := false
@@ -248,14 +332,36 @@ func DeleteApp(appName string, keepCache bool) bool {
 	deleteCurrentContext(appName)
 	uncacheDataSavedStatuses(nil, appName)
 	uncacheLatestWorkloadIDs(nil, appName)
+	uncacheWorkloadSpecs(appName)
 
 	if !keepCache {
-		aws.DeleteFromS3ByPrefix(filepath.Join(consts.AppsDir, appName), true)
+		deleteAppFiles(appName)
 	}
 
 	return wasDeployed
 }
 
+// deleteAppFiles deletes everything in the app's directory except its audit log
+func deleteAppFiles(appName string) {
+	keys, err := storage.ListKeys(filepath.Join(consts.AppsDir, appName) + "/")
+	if err != nil {
+		errors.PrintError(err)
+		return
+	}
+
+	var deleteKeys []string
+	for _, key := range keys {
+		if !strings.HasPrefix(key, ocontext.AuditPrefix(appName)) {
+			deleteKeys = append(deleteKeys, key)
+		}
+	}
+	if len(deleteKeys) > 0 {
+		if err := storage.DeleteKeys(deleteKeys); err != nil {
+			errors.PrintError(err)
+		}
+	}
+}
+
 func GetWorkflow(appName string) (*awfv1.Workflow, error) {
 	wfs, err := argo.ListByLabel("appName", appName)
 	if err != nil {
@@ -19,17 +19,19 @@ package workloads
 import (
 	"time"
 
+	awfv1 "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cortexlabs/cortex/pkg/api/context"
 	"github.com/cortexlabs/cortex/pkg/api/resource"
+	"github.com/cortexlabs/cortex/pkg/api/userconfig"
 	"github.com/cortexlabs/cortex/pkg/lib/errors"
 	"github.com/cortexlabs/cortex/pkg/lib/pointer"
 	"github.com/cortexlabs/cortex/pkg/lib/sets/strset"
-	"github.com/cortexlabs/cortex/pkg/operator/aws"
 	ocontext "github.com/cortexlabs/cortex/pkg/operator/context"
 	"github.com/cortexlabs/cortex/pkg/operator/k8s"
+	"github.com/cortexlabs/cortex/pkg/operator/storage"
 )
 
 type WorkloadSpec struct {
@@ -40,6 +42,13 @@ type WorkloadSpec struct {
 	SuccessCondition string
 	FailureCondition string
 	WorkloadType     string
+	SparkCompute     *userconfig.SparkCompute
+	TFCompute        *userconfig.TFCompute
+	APICompute       *userconfig.APICompute
+	Timeout          *int64
+	Retry            *userconfig.Retry
+	Attempt          int32
+	MemFactor        float64
 }
 
 type SavedWorkloadSpec struct {
@@ -47,6 +56,10 @@ type SavedWorkloadSpec struct {
 	WorkloadID   string
 	WorkloadType string
 	Resources    map[string]*context.ResourceFields
+	Timeout      *int64
+	Retry        *userconfig.Retry
+	Attempt      int32
+	MemFactor    float64
 }
 
 func uploadWorkloadSpec(workloadSpec *WorkloadSpec, ctx *context.Context) error {
@@ -65,10 +78,14 @@ func uploadWorkloadSpec(workloadSpec *WorkloadSpec, ctx *context.Context) error
 		WorkloadID:   workloadSpec.WorkloadID,
 		WorkloadType: workloadSpec.WorkloadType,
 		Resources:    resources,
+		Timeout:      workloadSpec.Timeout,
+		Retry:        workloadSpec.Retry,
+		Attempt:      workloadSpec.Attempt,
+		MemFactor:    workloadSpec.MemFactor,
 	}
 
 	key := ocontext.WorkloadSpecKey(savedWorkloadSpec.WorkloadID, ctx.App.Name)
-	err := aws.UploadJSONToS3(savedWorkloadSpec, key)
+	err := storage.UploadJSON(savedWorkloadSpec, key)
 	if err != nil {
 		return errors.Wrap(err, "upload workload spec", ctx.App.Name, savedWorkloadSpec.WorkloadID)
 	}
@@ -76,15 +93,20 @@ func uploadWorkloadSpec(workloadSpec *WorkloadSpec, ctx *context.Context) error
 }
 
 func getSavedWorkloadSpec(workloadID string, appName string) (*SavedWorkloadSpec, error) {
+	if savedWorkloadSpec := getCachedWorkloadSpec(workloadID, appName); savedWorkloadSpec != nil {
+		return savedWorkloadSpec, nil
+	}
+
 	key := ocontext.WorkloadSpecKey(workloadID, appName)
 	var savedWorkloadSpec SavedWorkloadSpec
-	err := aws.ReadJSONFromS3(&savedWorkloadSpec, key)
-	if aws.IsNoSuchKeyErr(err) {
+	err := storage.ReadJSON(&savedWorkloadSpec, key)
+	if storage.IsNotFoundErr(err) {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "download workload spec", appName, workloadID)
 	}
+	cacheWorkloadSpec(&savedWorkloadSpec)
 	return &savedWorkloadSpec, nil
 }
 
@@ -111,62 +133,112 @@ func UpdateDataWorkflowErrors(failedPods []corev1.Pod) error {
 		}
 		checkedWorkloadIDs.Add(workloadID)
 
-		savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
+		var exitCode resource.DataExitCode
+		switch k8s.GetPodStatus(&pod) {
+		case k8s.PodStatusKilled:
+			exitCode = resource.ExitCodeDataKilled
+		case k8s.PodStatusKilledOOM:
+			exitCode = resource.ExitCodeDataOOM
+		default:
+			exitCode = resource.ExitCodeDataFailed
+		}
+
+		err := updateDataWorkloadError(workloadID, appName, exitCode, nowTime)
 		if err != nil {
 			return err
 		}
-		if savedWorkloadSpec == nil {
-			continue
-		}
+	}
+	return nil
+}
 
-		resourceWorkloadIDs := make(map[string]string, len(savedWorkloadSpec.Resources))
-		for _, resource := range savedWorkloadSpec.Resources {
-			resourceWorkloadIDs[resource.ID] = workloadID
-		}
+// UpdateDataWorkflowErrorsFromWorkflows marks the data workloads whose workflow tasks failed as failed. This is needed for the
+// native workflow engine, since tasks which fail before their resource's pods start (e.g. if the resource can't be created)
+// don't have a failed pod.
+func UpdateDataWorkflowErrorsFromWorkflows(wfs []awfv1.Workflow) error {
+	nowTime := pointer.Time(time.Now())
 
-		savedStatuses, err := getDataSavedStatuses(resourceWorkloadIDs, appName)
+	for i := range wfs {
+		pWf, err := parseWorkflow(&wfs[i])
 		if err != nil {
 			return err
 		}
+		appName := wfs[i].Labels["appName"]
 
-		var savedStatusesToUpload []*resource.DataSavedStatus
-		for resourceID, res := range savedWorkloadSpec.Resources {
-			savedStatus := savedStatuses[resourceID]
-
-			if savedStatus == nil {
-				savedStatus = &resource.DataSavedStatus{
-					BaseSavedStatus: resource.BaseSavedStatus{
-						ResourceID:   resourceID,
-						ResourceType: res.ResourceType,
-						WorkloadID:   workloadID,
-						AppName:      appName,
-					},
-				}
+		for workloadID, wfItem := range pWf.Workloads {
+			if wfItem.WorkloadType == WorkloadTypeAPI || wfItem.ArgoPhase == nil || *wfItem.ArgoPhase != awfv1.NodeFailed {
+				continue
+			}
+			err := updateDataWorkloadError(workloadID, appName, resource.ExitCodeDataFailed, nowTime)
+			if err != nil {
+				return err
 			}
+		}
+	}
+	return nil
+}
+
+// updateDataWorkloadError saves exitCode for the workload's resources which have not already ended
+func updateDataWorkloadError(workloadID string, appName string, exitCode resource.DataExitCode, nowTime *time.Time) error {
+	return setDataWorkloadExitCode(workloadID, appName, exitCode, nowTime)
+}
 
-			if savedStatus.End == nil {
-				savedStatus.End = nowTime
-				if savedStatus.Start == nil {
-					savedStatus.Start = nowTime
-				}
-
-				switch k8s.GetPodStatus(&pod) {
-				case k8s.PodStatusKilled:
-					savedStatus.ExitCode = resource.ExitCodeDataKilled
-				case k8s.PodStatusKilledOOM:
-					savedStatus.ExitCode = resource.ExitCodeDataOOM
-				default:
-					savedStatus.ExitCode = resource.ExitCodeDataFailed
-				}
-
-				savedStatusesToUpload = append(savedStatusesToUpload, savedStatus)
+// setDataWorkloadExitCode saves exitCode for the workload's resources which have not already ended, and replaces the exit codes
+// of the resources which have already ended with one of overwriteExitCodes (their end times are kept)
+func setDataWorkloadExitCode(workloadID string, appName string, exitCode resource.DataExitCode, nowTime *time.Time, overwriteExitCodes ...resource.DataExitCode) error {
+	savedWorkloadSpec, err := getSavedWorkloadSpec(workloadID, appName)
+	if err != nil {
+		return err
+	}
+	if savedWorkloadSpec == nil {
+		return nil
+	}
+
+	resourceWorkloadIDs := make(map[string]string, len(savedWorkloadSpec.Resources))
+	for _, resource := range savedWorkloadSpec.Resources {
+		resourceWorkloadIDs[resource.ID] = workloadID
+	}
+
+	savedStatuses, err := getDataSavedStatuses(resourceWorkloadIDs, appName)
+	if err != nil {
+		return err
+	}
+
+	var savedStatusesToUpload []*resource.DataSavedStatus
+	for resourceID, res := range savedWorkloadSpec.Resources {
+		savedStatus := savedStatuses[resourceID]
+
+		if savedStatus == nil {
+			savedStatus = &resource.DataSavedStatus{
+				BaseSavedStatus: resource.BaseSavedStatus{
+					ResourceID:   resourceID,
+					ResourceType: res.ResourceType,
+					WorkloadID:   workloadID,
+					AppName:      appName,
+				},
 			}
 		}
 
-		err = uploadDataSavedStatuses(savedStatusesToUpload)
-		if err != nil {
-			return err
+		if savedStatus.End == nil {
+			savedStatus.End = nowTime
+			if savedStatus.Start == nil {
+				savedStatus.Start = nowTime
+			}
+			savedStatus.ExitCode = exitCode
+			savedStatusesToUpload = append(savedStatusesToUpload, savedStatus)
+		} else if isDataExitCodeIn(savedStatus.ExitCode, overwriteExitCodes) {
+			savedStatus.ExitCode = exitCode
+			savedStatusesToUpload = append(savedStatusesToUpload, savedStatus)
 		}
 	}
-	return nil
+
+	return uploadDataSavedStatuses(savedStatusesToUpload)
+}
+
+func isDataExitCodeIn(exitCode resource.DataExitCode, exitCodes []resource.DataExitCode) bool {
+	for _, code := range exitCodes {
+		if exitCode == code {
+			return true
+		}
+	}
+	return false
 }
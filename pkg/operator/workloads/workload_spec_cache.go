@@ -0,0 +1,67 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"sync"
+)
+
+// appName -> map(workloadID -> saved workload spec); saved workload specs are never modified, so they don't need to be refreshed
+var workloadSpecCache = struct {
+	m map[string]map[string]*SavedWorkloadSpec
+	sync.RWMutex
+}{m: make(map[string]map[string]*SavedWorkloadSpec)}
+
+func getCachedWorkloadSpec(workloadID string, appName string) *SavedWorkloadSpec {
+	workloadSpecCache.RLock()
+	defer workloadSpecCache.RUnlock()
+	if _, ok := workloadSpecCache.m[appName]; ok {
+		return workloadSpecCache.m[appName][workloadID]
+	}
+	return nil
+}
+
+func cacheWorkloadSpec(savedWorkloadSpec *SavedWorkloadSpec) {
+	workloadSpecCache.Lock()
+	defer workloadSpecCache.Unlock()
+	if _, ok := workloadSpecCache.m[savedWorkloadSpec.AppName]; !ok {
+		workloadSpecCache.m[savedWorkloadSpec.AppName] = make(map[string]*SavedWorkloadSpec)
+	}
+	workloadSpecCache.m[savedWorkloadSpec.AppName][savedWorkloadSpec.WorkloadID] = savedWorkloadSpec
+}
+
+func uncacheWorkloadSpecs(appName string) {
+	workloadSpecCache.Lock()
+	defer workloadSpecCache.Unlock()
+	delete(workloadSpecCache.m, appName)
+}
+
+func resetWorkloadSpecCache() {
+	workloadSpecCache.Lock()
+	defer workloadSpecCache.Unlock()
+	workloadSpecCache.m = make(map[string]map[string]*SavedWorkloadSpec)
+}
+
+func workloadSpecCacheSize() int {
+	workloadSpecCache.RLock()
+	defer workloadSpecCache.RUnlock()
+	size := 0
+	for _, workloadSpecs := range workloadSpecCache.m {
+		size += len(workloadSpecs)
+	}
+	return size
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2019 Cortex Labs, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transformers
+
+import (
+	_ "embed"
+)
+
+// ConfigYAML is transformers.yaml, which declares the signatures of the built-in transformers (so that the CLI can check
+// resources which use them without the operator)
+//
+//go:embed transformers.yaml
+var ConfigYAML []byte
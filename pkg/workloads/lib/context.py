@@ -116,6 +116,12 @@ class Context:
 
         # This affects Tensorflow S3 access
         os.environ["AWS_REGION"] = self.cortex_config.get("region", "")
+        endpoint = os.environ.get("CORTEX_STORAGE_ENDPOINT")
+        if endpoint:
+            os.environ["S3_ENDPOINT"] = util.remove_prefix_if_present(
+                util.remove_prefix_if_present(endpoint, "https://"), "http://"
+            )
+            os.environ["S3_USE_HTTPS"] = "1" if endpoint.startswith("https://") else "0"
 
         # Id map
         self.pp_id_map = ResourceMap(self.python_packages)
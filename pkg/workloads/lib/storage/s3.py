@@ -44,6 +44,12 @@ class S3(object):
         if region is not None:
             client_config["region_name"] = region
 
+        # Set by the operator when it uses an S3-compatible store (e.g. MinIO)
+        endpoint = os.environ.get("CORTEX_STORAGE_ENDPOINT")
+        if endpoint:
+            client_config["endpoint_url"] = endpoint
+            client_config["config"] = botocore.client.Config(s3={"addressing_style": "path"})
+
         merged_client_config = util.merge_dicts_in_place_no_overwrite(client_config, default_config)
 
         self.s3 = boto3.client("s3", **client_config)